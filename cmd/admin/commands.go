@@ -0,0 +1,360 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/frostnzx/go-ecommerce-api/internal/bootstrap"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/audit"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/services/order"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/services/product"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/services/user"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/utils"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/validation"
+	"github.com/frostnzx/go-ecommerce-api/internal/seed"
+	"github.com/google/uuid"
+)
+
+// operation is a parsed command, run once the services are available
+type operation func(ctx context.Context, svc *bootstrap.Services) (any, error)
+
+type command struct {
+	name    string
+	summary string
+	// parse validates the arguments before anything connects to the database
+	parse func(fs *flag.FlagSet, args []string) (operation, error)
+}
+
+var commands = []command{
+	{"create-admin", "create an administrator account", parseCreateAdmin},
+	{"reset-password", "replace a user's password with a temporary one and log them out", parseResetPassword},
+	{"revoke-sessions", "log a user out everywhere", parseRevokeSessions},
+	{"adjust-stock", "add to or remove from a product's stock", parseAdjustStock},
+	{"set-order-status", "change the status of an order", parseSetOrderStatus},
+	{"purge-sessions", "delete expired sessions", parsePurgeSessions},
+	{"import-catalog", "create or update products from a JSON catalog", parseImportCatalog},
+	{"seed", "load a reproducible demo or load test data set", parseSeed},
+}
+
+var (
+	errUnknownCommand = errors.New("unknown command")
+	// errFlagsReported wraps flag errors the flag set has already printed along with its usage
+	errFlagsReported = errors.New("invalid flags")
+)
+
+// parseCommand finds the named command and parses its flags
+func parseCommand(name string, args []string) (operation, error) {
+	for _, c := range commands {
+		if c.name != name {
+			continue
+		}
+		fs := flag.NewFlagSet("admin "+c.name, flag.ContinueOnError)
+		op, err := c.parse(fs, args)
+		if err != nil {
+			return nil, err
+		}
+		if fs.NArg() > 0 {
+			return nil, fmt.Errorf("%s: unexpected argument %q", c.name, fs.Arg(0))
+		}
+		return op, nil
+	}
+	return nil, fmt.Errorf("%w %q", errUnknownCommand, name)
+}
+
+func parseFlags(fs *flag.FlagSet, args []string) error {
+	err := fs.Parse(args)
+	if err != nil && !errors.Is(err, flag.ErrHelp) {
+		return fmt.Errorf("%w: %w", errFlagsReported, err)
+	}
+	return err
+}
+
+// requireFlags reports the first of the named flags that was left empty
+func requireFlags(fs *flag.FlagSet, names ...string) error {
+	for _, name := range names {
+		if fs.Lookup(name).Value.String() == "" {
+			return fmt.Errorf("%s: -%s is required", fs.Name(), name)
+		}
+	}
+	return nil
+}
+
+type createAdminResult struct {
+	ID       uuid.UUID `json:"id"`
+	Email    string    `json:"email"`
+	Name     string    `json:"name"`
+	Password string    `json:"password,omitempty"` // Only when generated
+}
+
+func parseCreateAdmin(fs *flag.FlagSet, args []string) (operation, error) {
+	email := fs.String("email", "", "email address to log in with")
+	name := fs.String("name", "", "display name")
+	password := fs.String("password", "", "initial password; a random one is generated and printed when empty")
+	if err := parseFlags(fs, args); err != nil {
+		return nil, err
+	}
+	if err := requireFlags(fs, "email", "name"); err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context, svc *bootstrap.Services) (any, error) {
+		result := createAdminResult{Email: *email, Name: *name}
+		pw := *password
+		if pw == "" {
+			generated, err := generatePassword()
+			if err != nil {
+				return nil, err
+			}
+			pw, result.Password = generated, generated
+		}
+
+		resp, err := svc.User.RegisterUser(ctx, user.RegisterUserReq{
+			Name:     *name,
+			Email:    *email,
+			Password: pw,
+			IsAdmin:  true,
+		})
+		if err != nil {
+			return nil, err
+		}
+		result.ID = resp.ID
+		return result, nil
+	}, nil
+}
+
+// generatePassword returns a random password that satisfies the password policy
+func generatePassword() (string, error) {
+	for {
+		pw, err := utils.RandomToken(12)
+		if err != nil {
+			return "", err
+		}
+		if validation.StrongPassword(pw) {
+			return pw, nil
+		}
+	}
+}
+
+type resetPasswordResult struct {
+	UserID            uuid.UUID `json:"user_id"`
+	Email             string    `json:"email"`
+	TemporaryPassword string    `json:"temporary_password"`
+}
+
+func parseResetPassword(fs *flag.FlagSet, args []string) (operation, error) {
+	ref := fs.String("user", "", "user ID or email")
+	if err := parseFlags(fs, args); err != nil {
+		return nil, err
+	}
+	if err := requireFlags(fs, "user"); err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context, svc *bootstrap.Services) (any, error) {
+		u, err := findUser(ctx, svc, *ref)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := svc.User.ResetUserPassword(ctx, user.ResetUserPasswordReq{
+			AdminID: audit.SystemActorID,
+			ID:      u.ID,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return resetPasswordResult{UserID: u.ID, Email: u.Email, TemporaryPassword: resp.TemporaryPassword}, nil
+	}, nil
+}
+
+type revokeSessionsResult struct {
+	UserID  uuid.UUID `json:"user_id"`
+	Revoked int       `json:"revoked"`
+}
+
+func parseRevokeSessions(fs *flag.FlagSet, args []string) (operation, error) {
+	ref := fs.String("user", "", "user ID or email")
+	if err := parseFlags(fs, args); err != nil {
+		return nil, err
+	}
+	if err := requireFlags(fs, "user"); err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context, svc *bootstrap.Services) (any, error) {
+		u, err := findUser(ctx, svc, *ref)
+		if err != nil {
+			return nil, err
+		}
+		n, err := svc.Session.RevokeUserSessions(ctx, u.ID, "")
+		if err != nil {
+			return nil, err
+		}
+		return revokeSessionsResult{UserID: u.ID, Revoked: n}, nil
+	}, nil
+}
+
+// findUser resolves a user ID or an email address
+func findUser(ctx context.Context, svc *bootstrap.Services, ref string) (*user.GetUserProfileResp, error) {
+	if id, err := uuid.Parse(ref); err == nil {
+		return svc.User.GetUserProfile(ctx, user.GetUserProfileReq{ID: id})
+	}
+	return svc.User.GetUserByEmail(ctx, user.GetUserByEmailReq{Email: ref})
+}
+
+func parseAdjustStock(fs *flag.FlagSet, args []string) (operation, error) {
+	ref := fs.String("product", "", "product ID or SKU")
+	delta := fs.Int("delta", 0, "units to add; negative to remove")
+	if err := parseFlags(fs, args); err != nil {
+		return nil, err
+	}
+	if err := requireFlags(fs, "product"); err != nil {
+		return nil, err
+	}
+	if *delta == 0 {
+		return nil, fmt.Errorf("%s: -delta must not be zero", fs.Name())
+	}
+
+	return func(ctx context.Context, svc *bootstrap.Services) (any, error) {
+		id, err := uuid.Parse(*ref)
+		if err != nil {
+			p, err := svc.Product.GetProductBySKU(ctx, product.GetProductBySKUReq{SKU: *ref})
+			if err != nil {
+				return nil, err
+			}
+			id = p.ID
+		}
+		return svc.Product.AdjustStock(ctx, product.AdjustStockReq{ID: id, Delta: *delta})
+	}, nil
+}
+
+type setOrderStatusResult struct {
+	OrderID uuid.UUID `json:"order_id"`
+	Status  string    `json:"status"`
+}
+
+func parseSetOrderStatus(fs *flag.FlagSet, args []string) (operation, error) {
+	ref := fs.String("order", "", "order ID")
+	status := fs.String("status", "", "new status: pending, paid, shipped or cancelled")
+	if err := parseFlags(fs, args); err != nil {
+		return nil, err
+	}
+	if err := requireFlags(fs, "order", "status"); err != nil {
+		return nil, err
+	}
+	orderID, err := uuid.Parse(*ref)
+	if err != nil {
+		return nil, fmt.Errorf("%s: -order must be an order ID", fs.Name())
+	}
+
+	return func(ctx context.Context, svc *bootstrap.Services) (any, error) {
+		err := svc.Order.UpdateOrderStatus(ctx, order.UpdateOrderStatusReq{OrderID: orderID, Status: *status})
+		if err != nil {
+			return nil, err
+		}
+		return setOrderStatusResult{OrderID: orderID, Status: *status}, nil
+	}, nil
+}
+
+type purgeSessionsResult struct {
+	Purged int64 `json:"purged"`
+}
+
+func parsePurgeSessions(fs *flag.FlagSet, args []string) (operation, error) {
+	if err := parseFlags(fs, args); err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context, svc *bootstrap.Services) (any, error) {
+		n, err := svc.Session.PurgeExpiredSessions(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return purgeSessionsResult{Purged: n}, nil
+	}, nil
+}
+
+func parseImportCatalog(fs *flag.FlagSet, args []string) (operation, error) {
+	file := fs.String("file", "", `catalog file, or "-" for stdin`)
+	if err := parseFlags(fs, args); err != nil {
+		return nil, err
+	}
+	if err := requireFlags(fs, "file"); err != nil {
+		return nil, err
+	}
+
+	// Read the catalog up front so a bad file fails before connecting
+	var (
+		data []byte
+		err  error
+	)
+	if *file == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(*file)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading catalog: %w", err)
+	}
+	req, err := decodeCatalog(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context, svc *bootstrap.Services) (any, error) {
+		return svc.Product.ImportProducts(ctx, req)
+	}, nil
+}
+
+// decodeCatalog accepts {"products": [...]} or a bare array of products, in the
+// same shape as the body of POST /products
+func decodeCatalog(data []byte) (product.ImportProductsReq, error) {
+	var req product.ImportProductsReq
+	data = bytes.TrimSpace(data)
+	dst := any(&req)
+	if bytes.HasPrefix(data, []byte("[")) {
+		dst = &req.Products
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(dst); err != nil {
+		return req, fmt.Errorf("invalid catalog: %w", err)
+	}
+	if dec.More() {
+		return req, errors.New("invalid catalog: unexpected data after the catalog")
+	}
+	return req, nil
+}
+
+func parseSeed(fs *flag.FlagSet, args []string) (operation, error) {
+	opts := seed.DefaultOptions()
+	fs.Uint64Var(&opts.Seed, "seed", opts.Seed, "seed value; the same seed and counts generate the same data")
+	fs.IntVar(&opts.Users, "users", opts.Users, "users to create, each with one to three addresses")
+	fs.IntVar(&opts.Products, "products", opts.Products, "products in the catalog")
+	fs.IntVar(&opts.Orders, "orders", opts.Orders, "orders to place, each with one to five items")
+	fs.IntVar(&opts.Concurrency, "concurrency", opts.Concurrency, "records created in parallel")
+	if err := parseFlags(fs, args); err != nil {
+		return nil, err
+	}
+	if opts.Users < 0 || opts.Products < 0 || opts.Orders < 0 || opts.Concurrency < 1 {
+		return nil, fmt.Errorf("%s: counts must not be negative and -concurrency must be at least 1", fs.Name())
+	}
+	if opts.Orders > 0 && (opts.Users == 0 || opts.Products == 0) {
+		return nil, fmt.Errorf("%s: -orders needs at least one user and one product", fs.Name())
+	}
+
+	return func(ctx context.Context, svc *bootstrap.Services) (any, error) {
+		return seed.Run(ctx, seed.Services{
+			User:    svc.User,
+			Address: svc.Address,
+			Product: svc.Product,
+			Order:   svc.Order,
+		}, opts)
+	}, nil
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestParseCommandValidatesBeforeConnecting(t *testing.T) {
+	flag.CommandLine.SetOutput(io.Discard)
+
+	tests := []struct {
+		name string
+		args []string
+		want string // error substring; empty means valid
+	}{
+		{"unknown", []string{"drop-tables"}, "unknown command"},
+		{"create-admin", []string{"-email", "a@example.com", "-name", "Ops"}, ""},
+		{"create-admin", []string{"-email", "a@example.com"}, "-name is required"},
+		{"reset-password", []string{}, "-user is required"},
+		{"revoke-sessions", []string{"-user", "a@example.com"}, ""},
+		{"adjust-stock", []string{"-product", "SKU-1"}, "-delta must not be zero"},
+		{"adjust-stock", []string{"-product", "SKU-1", "-delta", "-3"}, ""},
+		{"set-order-status", []string{"-order", "42", "-status", "paid"}, "-order must be an order ID"},
+		{"set-order-status", []string{"-order", uuid.NewString(), "-status", "paid"}, ""},
+		{"purge-sessions", []string{"now"}, `unexpected argument "now"`},
+		{"import-catalog", []string{}, "-file is required"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name+" "+strings.Join(tt.args, " "), func(t *testing.T) {
+			op, err := parseCommand(tt.name, tt.args)
+			if tt.want == "" {
+				if err != nil || op == nil {
+					t.Fatalf("parseCommand = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("parseCommand error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseCommandUnknownFlag(t *testing.T) {
+	_, err := parseCommand("purge-sessions", []string{"-force"})
+	if !errors.Is(err, errFlagsReported) {
+		t.Fatalf("err = %v, want errFlagsReported", err)
+	}
+}
+
+func TestDecodeCatalog(t *testing.T) {
+	for name, data := range map[string]string{
+		"object": `{"products": [{"sku": "A-1", "name": "Mug", "price": 9.5, "stock_qty": 3}]}`,
+		"array":  ` [{"sku": "A-1", "name": "Mug", "price": 9.5, "stock_qty": 3}]`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			req, err := decodeCatalog([]byte(data))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(req.Products) != 1 || req.Products[0].SKU != "A-1" || req.Products[0].StockQty != 3 {
+				t.Fatalf("products = %+v", req.Products)
+			}
+		})
+	}
+
+	for name, data := range map[string]string{
+		"unknown field": `[{"sku": "A-1", "name": "Mug", "price": 9.5, "colour": "red"}]`,
+		"trailing data": `[] []`,
+		"not json":      `sku,name,price`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := decodeCatalog([]byte(data)); err == nil {
+				t.Fatal("expected an error")
+			}
+		})
+	}
+}
+
+func TestImportCatalogReadsFileUpFront(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "catalog.json")
+	if err := os.WriteFile(path, []byte(`{"products": [`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := parseCommand("import-catalog", []string{"-file", path}); err == nil || !strings.Contains(err.Error(), "invalid catalog") {
+		t.Fatalf("err = %v, want invalid catalog", err)
+	}
+}
+
+func TestPrintResult(t *testing.T) {
+	result := revokeSessionsResult{UserID: uuid.MustParse("7b0a5f7e-3c1d-4a52-9d8e-2f1b6c4a9e01"), Revoked: 2}
+
+	var text bytes.Buffer
+	if err := printResult(&text, false, result); err != nil {
+		t.Fatal(err)
+	}
+	if want := "revoked: 2\nuser_id: 7b0a5f7e-3c1d-4a52-9d8e-2f1b6c4a9e01\n"; text.String() != want {
+		t.Errorf("text output = %q, want %q", text.String(), want)
+	}
+
+	var js bytes.Buffer
+	if err := printResult(&js, true, result); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(js.String(), `"revoked": 2`) {
+		t.Errorf("json output = %s", js.String())
+	}
+}
+
+func TestPrintErrorAsJSON(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	printError(&stdout, &stderr, true, errors.New("product not found"))
+	if stdout.String() != "{\"error\":\"product not found\"}\n" || stderr.Len() != 0 {
+		t.Fatalf("stdout = %q, stderr = %q", stdout.String(), stderr.String())
+	}
+}
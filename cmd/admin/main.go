@@ -0,0 +1,138 @@
+// Command admin runs operational tasks against the database through the core
+// services, so the same validation and side effects apply as through the API.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"os/signal"
+	"slices"
+	"syscall"
+
+	"github.com/frostnzx/go-ecommerce-api/internal/adapters/secondary/postgres"
+	"github.com/frostnzx/go-ecommerce-api/internal/bootstrap"
+)
+
+func main() {
+	configPath := flag.String("config", "", "path to a JSON config file (defaults to $CONFIG_FILE)")
+	jsonOutput := flag.Bool("json", false, "print results and errors as JSON on stdout")
+	flag.Usage = usage
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	op, err := parseCommand(flag.Arg(0), flag.Args()[1:])
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		// Flag errors were already reported with the command's own usage
+		if !errors.Is(err, errFlagsReported) {
+			fmt.Fprintln(flag.CommandLine.Output(), err)
+		}
+		if errors.Is(err, errUnknownCommand) {
+			fmt.Fprintln(flag.CommandLine.Output())
+			flag.Usage()
+		}
+		os.Exit(2)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Logs go to stderr so stdout only carries the result
+	result, err := run(ctx, *configPath, op)
+	if err != nil {
+		printError(os.Stdout, os.Stderr, *jsonOutput, err)
+		stop()
+		os.Exit(1)
+	}
+	if err := printResult(os.Stdout, *jsonOutput, result); err != nil {
+		fmt.Fprintln(os.Stderr, "error writing result:", err)
+		stop()
+		os.Exit(1)
+	}
+}
+
+// run connects to the database and performs op with the core services
+func run(ctx context.Context, configPath string, op operation) (any, error) {
+	cfg, _, err := bootstrap.Load(configPath, os.Stderr)
+	if err != nil {
+		return nil, err
+	}
+	db, err := postgres.Open(ctx, cfg.Database.DSN(), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	// Services must not run against a schema they do not know
+	migrator, err := postgres.NewMigrator(db)
+	if err != nil {
+		return nil, err
+	}
+	if err := migrator.Check(ctx); err != nil {
+		return nil, err
+	}
+
+	services, err := bootstrap.NewServices(db, cfg, bootstrap.Options{})
+	if err != nil {
+		return nil, err
+	}
+	return op(ctx, services)
+}
+
+func usage() {
+	out := flag.CommandLine.Output()
+	fmt.Fprintf(out, "usage: admin [flags] <command> [command flags]\n\nflags:\n")
+	flag.PrintDefaults()
+	fmt.Fprintf(out, "\ncommands:\n")
+	for _, c := range commands {
+		fmt.Fprintf(out, "  %-18s %s\n", c.name, c.summary)
+	}
+	fmt.Fprintf(out, "\nRun admin <command> -h for the flags of a command.\n")
+}
+
+// printResult writes result as indented JSON, or as one "key: value" line per field
+func printResult(w io.Writer, jsonOutput bool, result any) error {
+	if jsonOutput {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(result)
+	}
+
+	// Results are flat structs; going through JSON reuses their field names
+	b, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return err
+	}
+	for _, k := range slices.Sorted(maps.Keys(fields)) {
+		if _, err := fmt.Fprintf(w, "%s: %v\n", k, fields[k]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// printError reports err on stdout as {"error": "..."} when scripting, otherwise on stderr
+func printError(stdout, stderr io.Writer, jsonOutput bool, err error) {
+	if jsonOutput {
+		json.NewEncoder(stdout).Encode(struct {
+			Error string `json:"error"`
+		}{err.Error()})
+		return
+	}
+	fmt.Fprintln(stderr, "error:", err)
+}
@@ -1,21 +1,26 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"flag"
 	"fmt"
-	"log"
+	"log/slog"
 	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/frostnzx/go-ecommerce-api/internal/adapters/primary/api"
+	"github.com/frostnzx/go-ecommerce-api/internal/adapters/secondary/oidc"
 	"github.com/frostnzx/go-ecommerce-api/internal/adapters/secondary/postgres"
-	"github.com/frostnzx/go-ecommerce-api/internal/core/services/address"
-	"github.com/frostnzx/go-ecommerce-api/internal/core/services/items"
-	"github.com/frostnzx/go-ecommerce-api/internal/core/services/order"
-	"github.com/frostnzx/go-ecommerce-api/internal/core/services/product"
-	"github.com/frostnzx/go-ecommerce-api/internal/core/services/session"
-	"github.com/frostnzx/go-ecommerce-api/internal/core/services/user"
-	"github.com/jmoiron/sqlx"
-	"github.com/joho/godotenv"
-	_ "github.com/lib/pq" // PostgreSQL driver
+	"github.com/frostnzx/go-ecommerce-api/internal/bootstrap"
+	"github.com/frostnzx/go-ecommerce-api/internal/config"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/utils"
+	"github.com/frostnzx/go-ecommerce-api/internal/metrics"
+	"github.com/frostnzx/go-ecommerce-api/internal/ports"
+	"github.com/frostnzx/go-ecommerce-api/internal/ratelimit"
+	"github.com/frostnzx/go-ecommerce-api/internal/tracing"
+	"github.com/frostnzx/go-ecommerce-api/internal/worker"
 
 	_ "github.com/frostnzx/go-ecommerce-api/docs" // Swagger docs
 )
@@ -39,92 +44,184 @@ import (
 // @name Authorization
 // @description Type "Bearer" followed by a space and JWT token.
 
+// @securityDefinitions.apikey ApiKeyAuth
+// @in header
+// @name X-API-Key
+// @description Personal API key, also accepted as "Authorization: ApiKey <key>".
+
 func main() {
-	// Load .env file
-	if err := godotenv.Load(); err != nil {
-		log.Println("No .env file found, using environment variables")
-	}
-
-	// Load configuration from environment variables
-	dbHost := getEnv("DB_HOST", "localhost")
-	dbPort := getEnv("DB_PORT", "5432")
-	dbUser := getEnv("DB_USER", "postgres")
-	dbPassword := getEnv("DB_PASSWORD", "postgres")
-	dbName := getEnv("DB_NAME", "ecommerce")
-	serverPort := getEnv("SERVER_PORT", "8080")
-
-	// Build database connection string
-	dsn := fmt.Sprintf(
-		"host=%s port=%s user=%s password=%s dbname=%s sslmode=disable",
-		dbHost, dbPort, dbUser, dbPassword, dbName,
-	)
-
-	// Connect to database
-	db, err := sqlx.Connect("postgres", dsn)
+	configPath := flag.String("config", "", "path to a JSON config file (defaults to $CONFIG_FILE)")
+	autoMigrate := flag.Bool("auto-migrate", false, "apply pending migrations before serving (also $DB_AUTO_MIGRATE)")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: web [flags] [migrate <command>]\n\nflags:\n")
+		flag.PrintDefaults()
+		fmt.Fprintf(flag.CommandLine.Output(), "\n%s\n", migrateUsage)
+	}
+	flag.Parse()
+
+	// Load and validate configuration; refuse to start on any invalid setting
+	cfg, logger, err := bootstrap.Load(*configPath, os.Stdout)
 	if err != nil {
-		log.Fatalf("failed to connect to database: %v", err)
+		fatal("failed to load configuration", err)
 	}
-	defer db.Close()
 
-	// Ping to verify connection
-	if err := db.Ping(); err != nil {
-		log.Fatalf("failed to ping database: %v", err)
+	// Subcommands run against the database and exit
+	if flag.NArg() > 0 {
+		if flag.Arg(0) != "migrate" {
+			flag.Usage()
+			os.Exit(2)
+		}
+		err := runMigrate(context.Background(), cfg, flag.Args()[1:], os.Stdout)
+		if errors.Is(err, errUsage) {
+			fmt.Fprintln(flag.CommandLine.Output(), err)
+			flag.Usage()
+			os.Exit(2)
+		}
+		if err != nil {
+			fatal("migrate failed", err)
+		}
+		return
 	}
-	log.Println("Connected to database successfully")
 
-	// Initialize repositories (secondary adapters)
-	userRepo, err := postgres.NewUserRepo(db)
+	// Load JWT signing keys; refuse to start without usable key material
+	jwtKeys, err := utils.LoadSigningKeys(cfg.JWT.KeysDir)
 	if err != nil {
-		log.Fatalf("failed to create user repository: %v", err)
+		fatal("failed to load JWT keys", err)
 	}
-
-	sessionRepo, err := postgres.NewSessionRepo(db)
+	tokenMaker, err := utils.NewJWTMaker(cfg.JWT.ActiveKeyID, jwtKeys)
 	if err != nil {
-		log.Fatalf("failed to create session repository: %v", err)
+		fatal("failed to configure JWT signing", err)
 	}
+	logger.Info("signing tokens", "kid", tokenMaker.ActiveKeyID(), "keys_loaded", len(jwtKeys))
 
-	addressRepo, err := postgres.NewAddressRepo(db)
+	// Tracing; spans are only exported when enabled, but trace context is always propagated
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.Tracing)
 	if err != nil {
-		log.Fatalf("failed to create address repository: %v", err)
+		fatal("failed to configure tracing", err)
+	}
+	if cfg.Tracing.Enabled {
+		logger.Info("exporting traces", "endpoint", cfg.Tracing.Endpoint, "sample_ratio", cfg.Tracing.SampleRatio)
 	}
 
-	orderRepo, err := postgres.NewOrderRepo(db)
+	// Metrics for HTTP traffic, queries, the connection pool and business events
+	appMetrics := metrics.New()
+
+	// Connect to database and verify the connection
+	db, err := postgres.Open(context.Background(), cfg.Database.DSN(), appMetrics)
 	if err != nil {
-		log.Fatalf("failed to create order repository: %v", err)
+		fatal("failed to connect to database", err)
 	}
+	appMetrics.RegisterDB(db.DB, "ecommerce")
+	logger.Info("connected to database")
 
-	itemsRepo, err := postgres.NewItemsRepo(db)
+	// Refuse to serve against a schema older than this binary expects
+	migrator, err := postgres.NewMigrator(db)
 	if err != nil {
-		log.Fatalf("failed to create items repository: %v", err)
+		fatal("failed to load migrations", err)
+	}
+	if *autoMigrate || cfg.Database.AutoMigrate {
+		applied, err := migrator.Up(context.Background())
+		for _, m := range applied {
+			logger.Info("applied migration", "migration", m.String())
+		}
+		if err != nil {
+			fatal("failed to apply migrations", err)
+		}
+	}
+	if err := migrator.Check(context.Background()); err != nil {
+		fatal("database schema is not up to date; run migrate up or start with -auto-migrate", err)
 	}
 
-	productRepo, err := postgres.NewProductRepo(db)
+	// Repositories (secondary adapters) and services (core business logic)
+	services, err := bootstrap.NewServices(db, cfg, bootstrap.Options{
+		TokenMaker:        tokenMaker,
+		Metrics:           appMetrics,
+		IdentityProviders: loadIdentityProviders(cfg.OIDC),
+	})
 	if err != nil {
-		log.Fatalf("failed to create product repository: %v", err)
+		fatal("failed to initialize services", err)
 	}
 
-	// Initialize services (core business logic)
-	sessionService := session.NewService(sessionRepo)
-	userService := user.NewService(userRepo, sessionService)
-	addressService := address.NewService(addressRepo)
-	orderService := order.NewService(orderRepo, itemsRepo, productRepo)
-	productService := product.NewService(productRepo)
-	itemsService := items.NewService(itemsRepo, productRepo, orderRepo)
+	// Stop on SIGINT/SIGTERM
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Background jobs
+	workers := worker.NewGroup()
+	workers.Every("session-purge", cfg.Workers.SessionPurgeInterval.Duration, func(ctx context.Context) error {
+		n, err := services.Session.PurgeExpiredSessions(ctx)
+		if err == nil && n > 0 {
+			logger.Info("purged expired sessions", "count", n)
+		}
+		return err
+	})
+	workers.Every("idempotency-purge", cfg.Workers.IdempotencyPurgeInterval.Duration, func(ctx context.Context) error {
+		n, err := services.Idempotency.PurgeExpiredKeys(ctx)
+		if err == nil && n > 0 {
+			logger.Info("purged expired idempotency keys", "count", n)
+		}
+		return err
+	})
+
+	// Rate limit buckets live in memory, so each instance enforces its own limits
+	rateLimits := ratelimit.NewMemoryStore()
+	if cfg.Server.RateLimit.Enabled {
+		workers.Every("rate-limit-sweep", cfg.Server.RateLimit.SweepInterval.Duration, rateLimits.Sweep)
+	}
+	workers.Start(ctx)
 
-	// Create and run HTTP server
-	addr := ":" + serverPort
-	app := api.NewApp(userService, orderService, addressService, productService, itemsService, addr)
+	// Dependencies /readyz verifies before the instance takes traffic
+	readiness := []api.HealthCheck{
+		{Name: "database", Check: db.PingContext},
+		{Name: "migrations", Check: migrator.Check},
+		{Name: "workers", Check: workers.Check},
+	}
 
-	log.Printf("Starting server on %s", addr)
-	if err := app.Run(); err != nil {
-		log.Fatalf("server error: %v", err)
+	// Create and run HTTP server until a shutdown signal arrives
+	app := api.NewApp(services.User, services.Session, services.Order, services.Address, services.Product, services.Items, services.APIKey, services.Privacy, services.Idempotency, tokenMaker, cfg.Server, logger, appMetrics, rateLimits, readiness...)
+	serveErr := app.Run(ctx)
+	stop()
+
+	// Shut down in dependency order: the server has drained, then workers stop, then the pool closes
+	// and the last spans are flushed
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout.Duration)
+	defer cancel()
+	if err := workers.Stop(shutdownCtx); err != nil {
+		logger.Warn("background workers did not stop in time", "error", err)
+	}
+	if err := db.Close(); err != nil {
+		logger.Error("error closing database", "error", err)
 	}
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		logger.Error("error flushing traces", "error", err)
+	}
+	if serveErr != nil {
+		fatal("server error", serveErr)
+	}
+	logger.Info("server stopped")
 }
 
-// getEnv returns the value of an environment variable or a default value if not set
-func getEnv(key, defaultValue string) string {
-	if value, exists := os.LookupEnv(key); exists {
-		return value
+// loadIdentityProviders discovers each configured OIDC provider
+func loadIdentityProviders(configs []config.OIDCProvider) []ports.IdentityProvider {
+	var providers []ports.IdentityProvider
+	for _, c := range configs {
+		p, err := oidc.NewProvider(context.Background(), c.Name, oidc.Config{
+			Issuer:       c.Issuer,
+			ClientID:     c.ClientID,
+			ClientSecret: c.ClientSecret,
+			RedirectURL:  c.RedirectURL,
+		})
+		if err != nil {
+			fatal("failed to configure identity provider "+c.Name, err)
+		}
+		providers = append(providers, p)
+		slog.Info("social login enabled", "provider", c.Name)
 	}
-	return defaultValue
+	return providers
+}
+
+// fatal logs err and exits; deferred calls do not run
+func fatal(msg string, err error) {
+	slog.Error(msg, "error", err)
+	os.Exit(1)
 }
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/frostnzx/go-ecommerce-api/internal/adapters/secondary/postgres"
+	"github.com/frostnzx/go-ecommerce-api/internal/config"
+)
+
+const migrateUsage = `usage: web [flags] migrate <command>
+
+commands:
+  up             apply every pending migration
+  down [N]       roll back the last N migrations (default 1)
+  to VERSION     migrate up or down to VERSION; 0 rolls back everything
+  status         show the applied version and pending migrations
+  force VERSION  mark VERSION as applied and clear the dirty flag after
+                 repairing a failed migration by hand`
+
+// errUsage asks main to print the usage text
+var errUsage = errors.New("invalid command")
+
+// runMigrate runs the migrate subcommand against the configured database
+func runMigrate(ctx context.Context, cfg *config.Config, args []string, out io.Writer) error {
+	run, err := parseMigrateCommand(args, out)
+	if err != nil {
+		return err
+	}
+	db, err := postgres.Open(ctx, cfg.Database.DSN(), nil)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	migrator, err := postgres.NewMigrator(db)
+	if err != nil {
+		return err
+	}
+	return run(ctx, migrator)
+}
+
+type migrateCommand func(ctx context.Context, m *postgres.Migrator) error
+
+// parseMigrateCommand validates the arguments before anything connects to the database
+func parseMigrateCommand(args []string, out io.Writer) (migrateCommand, error) {
+	if len(args) == 0 {
+		return nil, errUsage
+	}
+	cmd, rest := args[0], args[1:]
+	switch {
+	case cmd == "status" && len(rest) == 0:
+		return func(ctx context.Context, m *postgres.Migrator) error {
+			st, err := m.Status(ctx)
+			if err != nil {
+				return err
+			}
+			printMigrationStatus(st, out)
+			return nil
+		}, nil
+
+	case cmd == "up" && len(rest) == 0:
+		return reportingMigrations(out, (*postgres.Migrator).Up), nil
+
+	case cmd == "down" && len(rest) <= 1:
+		steps := 1
+		if len(rest) == 1 {
+			n, err := strconv.Atoi(rest[0])
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("%w: step count %q must be a positive integer", errUsage, rest[0])
+			}
+			steps = n
+		}
+		return reportingMigrations(out, func(m *postgres.Migrator, ctx context.Context) ([]postgres.Migration, error) {
+			return m.Down(ctx, steps)
+		}), nil
+
+	case cmd == "to" && len(rest) == 1:
+		version, err := strconv.ParseUint(rest[0], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: version %q must be a number", errUsage, rest[0])
+		}
+		return reportingMigrations(out, func(m *postgres.Migrator, ctx context.Context) ([]postgres.Migration, error) {
+			return m.To(ctx, version)
+		}), nil
+
+	case cmd == "force" && len(rest) == 1:
+		version, err := strconv.ParseUint(rest[0], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: version %q must be a number", errUsage, rest[0])
+		}
+		return func(ctx context.Context, m *postgres.Migrator) error {
+			if err := m.Force(ctx, version); err != nil {
+				return err
+			}
+			fmt.Fprintf(out, "forced version %d\n", version)
+			return nil
+		}, nil
+	}
+	return nil, errUsage
+}
+
+// reportingMigrations wraps an operation that moves the schema and prints each migration it ran
+func reportingMigrations(out io.Writer, op func(*postgres.Migrator, context.Context) ([]postgres.Migration, error)) migrateCommand {
+	return func(ctx context.Context, m *postgres.Migrator) error {
+		before, err := m.Status(ctx)
+		if err != nil {
+			return err
+		}
+		done, err := op(m, ctx)
+		for _, mig := range done {
+			if mig.Version > before.Version {
+				fmt.Fprintf(out, "applied %s\n", mig)
+			} else {
+				fmt.Fprintf(out, "rolled back %s\n", mig)
+			}
+		}
+		if err == nil && len(done) == 0 {
+			fmt.Fprintln(out, "no change")
+		}
+		return err
+	}
+}
+
+func printMigrationStatus(st *postgres.MigrationStatus, out io.Writer) {
+	fmt.Fprintf(out, "version: %d", st.Version)
+	if st.Dirty {
+		fmt.Fprint(out, " (dirty)")
+	}
+	fmt.Fprintf(out, "\nlatest:  %d\n", st.Latest)
+	if st.Version > st.Latest {
+		fmt.Fprintln(out, "the database is ahead of this binary")
+	}
+	if len(st.Pending) == 0 {
+		fmt.Fprintln(out, "no pending migrations")
+		return
+	}
+	fmt.Fprintf(out, "pending (%d):\n", len(st.Pending))
+	for _, m := range st.Pending {
+		fmt.Fprintf(out, "  %s\n", m)
+	}
+}
@@ -0,0 +1,9 @@
+// Package db embeds the SQL migrations so the binary always knows the schema version it expects.
+package db
+
+import "embed"
+
+// Migrations holds the golang-migrate style <version>_<name>.up.sql / .down.sql files under migrations/
+//
+//go:embed migrations/*.sql
+var Migrations embed.FS
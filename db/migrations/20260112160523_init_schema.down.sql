@@ -1,6 +1,5 @@
-DROP TABLE IF EXISTS users
-DROP TABLE IF EXISTS products
-DROP TABLE IF EXISTS addresses
-DROP TABLE IF EXISTS orders
-DROP TABLE IF EXISTS items
-
+DROP TABLE IF EXISTS items;
+DROP TABLE IF EXISTS orders;
+DROP TABLE IF EXISTS addresses;
+DROP TABLE IF EXISTS products;
+DROP TABLE IF EXISTS users;
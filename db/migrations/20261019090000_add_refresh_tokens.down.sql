@@ -0,0 +1,2 @@
+ALTER TABLE sessions ADD COLUMN refresh_token VARCHAR(512) NOT NULL DEFAULT '';
+DROP TABLE IF EXISTS refresh_tokens;
@@ -0,0 +1,18 @@
+CREATE TABLE refresh_tokens (
+  id UUID PRIMARY KEY NOT NULL,
+  session_id UUID NOT NULL REFERENCES sessions(id) ON DELETE CASCADE,
+  token_hash VARCHAR(64) UNIQUE NOT NULL,
+  consumed_at TIMESTAMP,
+  created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+  expires_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_refresh_tokens_session_id ON refresh_tokens(session_id);
+
+-- Carry over live sessions by hashing their current refresh token
+INSERT INTO refresh_tokens (id, session_id, token_hash, created_at, expires_at)
+SELECT gen_random_uuid(), id, encode(sha256(refresh_token::bytea), 'hex'), COALESCE(created_at, NOW()), expires_at
+FROM sessions
+WHERE expires_at IS NOT NULL;
+
+ALTER TABLE sessions DROP COLUMN refresh_token;
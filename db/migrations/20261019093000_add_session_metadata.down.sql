@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_sessions_user_id;
+
+ALTER TABLE sessions
+DROP COLUMN last_used_at,
+DROP COLUMN ip_address,
+DROP COLUMN user_agent,
+DROP COLUMN user_id;
@@ -0,0 +1,12 @@
+ALTER TABLE sessions
+ADD COLUMN user_id UUID REFERENCES users(id) ON DELETE CASCADE,
+ADD COLUMN user_agent VARCHAR(512) NOT NULL DEFAULT '',
+ADD COLUMN ip_address VARCHAR(45) NOT NULL DEFAULT '',
+ADD COLUMN last_used_at TIMESTAMP NOT NULL DEFAULT NOW();
+
+UPDATE sessions s SET user_id = u.id FROM users u WHERE u.email = s.user_email;
+DELETE FROM sessions WHERE user_id IS NULL;
+
+ALTER TABLE sessions ALTER COLUMN user_id SET NOT NULL;
+
+CREATE INDEX idx_sessions_user_id ON sessions(user_id);
@@ -0,0 +1,8 @@
+CREATE TABLE login_attempts (
+  scope VARCHAR(20) NOT NULL,
+  subject VARCHAR(255) NOT NULL,
+  failures INT NOT NULL DEFAULT 0,
+  last_failed_at TIMESTAMP NOT NULL,
+  locked_until TIMESTAMP,
+  PRIMARY KEY (scope, subject)
+);
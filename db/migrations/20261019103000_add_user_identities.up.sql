@@ -0,0 +1,20 @@
+CREATE TABLE user_identities (
+  id UUID PRIMARY KEY,
+  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  provider VARCHAR(50) NOT NULL,
+  subject VARCHAR(255) NOT NULL,
+  email VARCHAR(255) NOT NULL,
+  created_at TIMESTAMP NOT NULL,
+  UNIQUE (provider, subject)
+);
+
+CREATE INDEX idx_user_identities_user_id ON user_identities(user_id);
+
+CREATE TABLE oidc_login_states (
+  state VARCHAR(64) PRIMARY KEY,
+  provider VARCHAR(50) NOT NULL,
+  code_verifier VARCHAR(128) NOT NULL,
+  nonce VARCHAR(64) NOT NULL,
+  created_at TIMESTAMP NOT NULL,
+  expires_at TIMESTAMP NOT NULL
+);
@@ -0,0 +1,14 @@
+CREATE TABLE api_keys (
+  id UUID PRIMARY KEY,
+  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  name VARCHAR(100) NOT NULL,
+  prefix VARCHAR(32) NOT NULL,
+  key_hash VARCHAR(64) NOT NULL UNIQUE,
+  scopes VARCHAR(100) NOT NULL,
+  created_at TIMESTAMP NOT NULL,
+  expires_at TIMESTAMP NOT NULL,
+  last_used_at TIMESTAMP,
+  revoked_at TIMESTAMP
+);
+
+CREATE INDEX idx_api_keys_user_id ON api_keys(user_id);
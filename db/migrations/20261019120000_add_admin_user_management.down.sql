@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS admin_audit_log;
+
+ALTER TABLE sessions
+DROP COLUMN IF EXISTS impersonator_id;
+
+ALTER TABLE users
+DROP COLUMN IF EXISTS suspended_at;
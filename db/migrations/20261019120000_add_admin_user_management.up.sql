@@ -0,0 +1,18 @@
+ALTER TABLE users
+ADD COLUMN suspended_at TIMESTAMP;
+
+ALTER TABLE sessions
+ADD COLUMN impersonator_id UUID REFERENCES users(id) ON DELETE CASCADE;
+
+-- No foreign keys: the audit trail must outlive the accounts it mentions
+CREATE TABLE admin_audit_log (
+  id UUID PRIMARY KEY,
+  actor_id UUID NOT NULL,
+  action VARCHAR(50) NOT NULL,
+  target_user_id UUID NOT NULL,
+  details TEXT NOT NULL DEFAULT '',
+  created_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_admin_audit_log_target_user_id ON admin_audit_log(target_user_id);
+CREATE INDEX idx_admin_audit_log_created_at ON admin_audit_log(created_at);
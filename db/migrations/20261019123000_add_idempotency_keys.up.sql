@@ -0,0 +1,13 @@
+CREATE TABLE idempotency_keys (
+  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  key VARCHAR(255) NOT NULL,
+  fingerprint VARCHAR(64) NOT NULL,
+  status_code INTEGER NOT NULL DEFAULT 0,
+  content_type VARCHAR(255) NOT NULL DEFAULT '',
+  body BYTEA,
+  created_at TIMESTAMP NOT NULL,
+  expires_at TIMESTAMP NOT NULL,
+  PRIMARY KEY (user_id, key)
+);
+
+CREATE INDEX idx_idempotency_keys_expires_at ON idempotency_keys(expires_at);
@@ -22,13 +22,28 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/addresses": {
+        "/.well-known/jwks.json": {
             "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
+                "description": "Public keys used to verify access tokens, identified by the token's kid header",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
                 ],
+                "summary": "JSON Web Key Set",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_core_utils.JWKSet"
+                        }
+                    }
+                }
+            }
+        },
+        "/addresses": {
+            "get": {
                 "description": "Get all addresses for the authenticated user",
                 "consumes": [
                     "application/json"
@@ -50,23 +65,23 @@ const docTemplate = `{
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
                         }
                     }
-                }
-            },
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
+                ]
+            },
+            "post": {
                 "description": "Create a new address for the authenticated user",
                 "consumes": [
                     "application/json"
@@ -99,25 +114,25 @@ const docTemplate = `{
                     "400": {
                         "description": "Invalid request",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
                         }
                     }
-                }
-            }
-        },
-        "/addresses/default": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
+                ]
+            }
+        },
+        "/addresses/default": {
+            "get": {
                 "description": "Get the default address for the authenticated user",
                 "consumes": [
                     "application/json"
@@ -139,25 +154,25 @@ const docTemplate = `{
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
                         }
                     },
                     "404": {
                         "description": "Not found",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
                         }
                     }
-                }
-            }
-        },
-        "/addresses/{id}": {
-            "delete": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
+                ]
+            }
+        },
+        "/addresses/{id}": {
+            "delete": {
                 "description": "Delete an address by ID",
                 "consumes": [
                     "application/json"
@@ -188,25 +203,25 @@ const docTemplate = `{
                     "400": {
                         "description": "Invalid request",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
                         }
                     }
-                }
-            }
-        },
-        "/addresses/{id}/default": {
-            "put": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
+                ]
+            }
+        },
+        "/addresses/{id}/default": {
+            "put": {
                 "description": "Set an address as the default address",
                 "consumes": [
                     "application/json"
@@ -237,25 +252,94 @@ const docTemplate = `{
                     "400": {
                         "description": "Invalid request",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
-        "/admin/orders/{id}/status": {
-            "put": {
+        "/admin/audit-log": {
+            "get": {
+                "description": "Get recorded admin actions on user accounts, newest first (admin only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Admin audit log (Admin)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Only entries about this user",
+                        "name": "user_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default 20, max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of entries to skip",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapters_primary_api_user.listAuditLogResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
+                        }
+                    }
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
+                ]
+            }
+        },
+        "/admin/orders/{id}/status": {
+            "put": {
                 "description": "Update the status of an order (admin only)",
                 "consumes": [
                     "application/json"
@@ -295,31 +379,31 @@ const docTemplate = `{
                     "400": {
                         "description": "Invalid request",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
                         }
                     },
                     "403": {
                         "description": "Forbidden",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
                         }
                     }
-                }
-            }
-        },
-        "/admin/products": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
+                ]
+            }
+        },
+        "/admin/products": {
+            "post": {
                 "description": "Create a new product (admin only)",
                 "consumes": [
                     "application/json"
@@ -352,31 +436,31 @@ const docTemplate = `{
                     "400": {
                         "description": "Invalid request",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
                         }
                     },
                     "403": {
                         "description": "Forbidden",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
                         }
                     }
-                }
-            }
-        },
-        "/admin/products/{id}": {
-            "put": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
+                ]
+            }
+        },
+        "/admin/products/{id}": {
+            "put": {
                 "description": "Update product details (admin only)",
                 "consumes": [
                     "application/json"
@@ -416,29 +500,29 @@ const docTemplate = `{
                     "400": {
                         "description": "Invalid request",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
                         }
                     },
                     "403": {
                         "description": "Forbidden",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
                         }
                     }
-                }
-            },
-            "delete": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
+                ]
+            },
+            "delete": {
                 "description": "Delete a product by ID (admin only)",
                 "consumes": [
                     "application/json"
@@ -469,38 +553,38 @@ const docTemplate = `{
                     "400": {
                         "description": "Invalid request",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
                         }
                     },
                     "403": {
                         "description": "Forbidden",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
                         }
                     },
                     "404": {
                         "description": "Not found",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
                         }
                     }
-                }
-            }
-        },
-        "/admin/users": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Get a list of all users (admin only)",
+                ]
+            }
+        },
+        "/admin/users": {
+            "get": {
+                "description": "Get a page of users, optionally filtered by email or name (admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -510,7 +594,27 @@ const docTemplate = `{
                 "tags": [
                     "Admin"
                 ],
-                "summary": "List all users (Admin)",
+                "summary": "Search users (Admin)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Matches email or name",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default 20, max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of users to skip",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -518,30 +622,41 @@ const docTemplate = `{
                             "$ref": "#/definitions/internal_adapters_primary_api_user.listUsersResp"
                         }
                     },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
+                        }
+                    },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
                         }
                     },
                     "403": {
                         "description": "Forbidden",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
-        "/auth/login": {
-            "post": {
-                "description": "Authenticate user and return tokens",
+        "/admin/users/{id}/addresses": {
+            "get": {
+                "description": "Get all addresses of any user (admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -549,85 +664,116 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
+                    "Admin"
                 ],
-                "summary": "Login user",
+                "summary": "List a user's addresses (Admin)",
                 "parameters": [
                     {
-                        "description": "Login credentials",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/internal_adapters_primary_api_user.loginUserReq"
-                        }
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/internal_adapters_primary_api_user.loginUserResp"
+                            "$ref": "#/definitions/internal_adapters_primary_api_address.listAddressesResp"
                         }
                     },
                     "400": {
                         "description": "Invalid request",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
                         }
                     }
-                }
-            }
-        },
-        "/auth/logout": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Invalidate user session",
-                "consumes": [
-                    "application/json"
-                ],
+                ]
+            }
+        },
+        "/admin/users/{id}/api-keys": {
+            "get": {
+                "description": "Get the API keys of any user (admin only)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
+                    "Admin"
                 ],
-                "summary": "Logout user",
-                "responses": {
-                    "204": {
-                        "description": "No Content",
+                "summary": "List a user's API keys (Admin)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/internal_adapters_primary_api_apikey.listAPIKeysResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
                         }
                     }
-                }
-            }
-        },
-        "/auth/register": {
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
             "post": {
-                "description": "Create a new user account",
+                "description": "Create an API key on behalf of a user, e.g. a service account (admin only). The key and the admin who created it are written to the audit log",
                 "consumes": [
                     "application/json"
                 ],
@@ -635,17 +781,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
+                    "Admin"
                 ],
-                "summary": "Register a new user",
+                "summary": "Create an API key for a user (Admin)",
                 "parameters": [
                     {
-                        "description": "User registration data",
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Key name, scopes (read, write, admin) and lifetime",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/internal_adapters_primary_api_user.registerUserRequest"
+                            "$ref": "#/definitions/internal_adapters_primary_api_apikey.createAPIKeyReq"
                         }
                     }
                 ],
@@ -653,118 +806,106 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/internal_adapters_primary_api_user.registerUserResp"
+                            "$ref": "#/definitions/internal_adapters_primary_api_apikey.createAPIKeyResp"
                         }
                     },
                     "400": {
                         "description": "Invalid request",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
-        "/auth/renew": {
-            "post": {
-                "description": "Get a new access token using refresh token",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
+        "/admin/users/{id}/api-keys/{keyId}": {
+            "delete": {
+                "description": "Revoke an API key of any user (admin only)",
                 "tags": [
-                    "Auth"
+                    "Admin"
                 ],
-                "summary": "Renew access token",
+                "summary": "Revoke a user's API key (Admin)",
                 "parameters": [
                     {
-                        "description": "Refresh token",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/internal_adapters_primary_api_user.renewAccessTokenReq"
-                        }
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API key ID",
+                        "name": "keyId",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "204": {
+                        "description": "No Content",
                         "schema": {
-                            "$ref": "#/definitions/internal_adapters_primary_api_user.renewAccessTokenResp"
+                            "type": "string"
                         }
                     },
                     "400": {
                         "description": "Invalid request",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "type": "string"
-                        }
-                    }
-                }
-            }
-        },
-        "/items": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Get all items across all orders for the authenticated user",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Items"
-                ],
-                "summary": "List all user items",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/internal_adapters_primary_api_items.listItemsResp"
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
                         }
                     },
-                    "500": {
-                        "description": "Internal server error",
+                    "404": {
+                        "description": "Not found",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
                         }
                     }
-                }
-            }
-        },
-        "/orders": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Get all orders for the authenticated user",
+                ]
+            }
+        },
+        "/admin/users/{id}/impersonate": {
+            "post": {
+                "description": "Get a 15 minute access token acting as a customer. The token cannot be renewed, carries an impersonator_id claim and is recorded in the audit log (admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -772,37 +913,75 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Orders"
+                    "Admin"
+                ],
+                "summary": "Impersonate a user (Admin)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reason, recorded in the audit log",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapters_primary_api_user.impersonateUserReq"
+                        }
+                    }
                 ],
-                "summary": "List user orders",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/internal_adapters_primary_api_order.listOrdersResp"
+                            "$ref": "#/definitions/internal_adapters_primary_api_user.impersonateUserResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
                         }
                     }
-                }
-            },
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Create a new order with items",
+                ]
+            }
+        },
+        "/admin/users/{id}/orders": {
+            "get": {
+                "description": "Get all orders of any user (admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -810,64 +989,71 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Orders"
+                    "Admin"
                 ],
-                "summary": "Place a new order",
+                "summary": "List a user's orders (Admin)",
                 "parameters": [
                     {
-                        "description": "Order data",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/internal_adapters_primary_api_order.placeOrderReq"
-                        }
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/internal_adapters_primary_api_order.placeOrderResp"
+                            "$ref": "#/definitions/internal_adapters_primary_api_order.listOrdersResp"
                         }
                     },
                     "400": {
                         "description": "Invalid request",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
                         }
                     }
-                }
-            }
-        },
-        "/orders/{id}": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Get order details by ID",
-                "consumes": [
-                    "application/json"
-                ],
+                ]
+            }
+        },
+        "/admin/users/{id}/password-reset": {
+            "post": {
+                "description": "Replace the password with a temporary one, returned once, and log the user out everywhere (admin only)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Orders"
+                    "Admin"
                 ],
-                "summary": "Get order details",
+                "summary": "Reset a user's password (Admin)",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Order ID",
+                        "description": "User ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -877,52 +1063,58 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/internal_adapters_primary_api_order.getOrderResp"
+                            "$ref": "#/definitions/internal_adapters_primary_api_user.resetUserPasswordResp"
                         }
                     },
                     "400": {
                         "description": "Invalid request",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
                         }
                     },
-                    "404": {
-                        "description": "Not found",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
                         }
                     }
-                }
-            }
-        },
-        "/orders/{id}/cancel": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Cancel an order by ID",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
+                ]
+            }
+        },
+        "/admin/users/{id}/reactivate": {
+            "post": {
+                "description": "Lift a suspension (admin only)",
                 "tags": [
-                    "Orders"
+                    "Admin"
                 ],
-                "summary": "Cancel an order",
+                "summary": "Reactivate a user (Admin)",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Order ID",
+                        "description": "User ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -938,26 +1130,44 @@ const docTemplate = `{
                     "400": {
                         "description": "Invalid request",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
                         }
                     }
-                }
-            }
-        },
-        "/orders/{orderId}/items": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Get all items for a specific order",
+                ]
+            }
+        },
+        "/admin/users/{id}/sessions": {
+            "get": {
+                "description": "Get the active sessions of any user (admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -965,14 +1175,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Items"
+                    "Admin"
                 ],
-                "summary": "List items by order",
+                "summary": "List a user's sessions (Admin)",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Order ID",
-                        "name": "orderId",
+                        "description": "User ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
@@ -981,30 +1191,42 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/internal_adapters_primary_api_items.listItemsResp"
+                            "$ref": "#/definitions/internal_adapters_primary_api_session.listSessionsResp"
                         }
                     },
                     "400": {
                         "description": "Invalid request",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
                         }
                     }
-                }
-            },
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Add a new item to an existing order",
+                ]
+            },
+            "delete": {
+                "description": "Revoke every session of a user (admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -1012,111 +1234,132 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Items"
+                    "Admin"
                 ],
-                "summary": "Add item to order",
+                "summary": "Force logout a user (Admin)",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Order ID",
-                        "name": "orderId",
+                        "description": "User ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Item data",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/internal_adapters_primary_api_items.addItemReq"
-                        }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/internal_adapters_primary_api_items.addItemResp"
+                            "$ref": "#/definitions/internal_adapters_primary_api_session.revokeSessionsResp"
                         }
                     },
                     "400": {
                         "description": "Invalid request",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
                         }
                     }
-                }
-            }
-        },
-        "/orders/{orderId}/items/{id}": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Get item details by ID",
+                ]
+            }
+        },
+        "/admin/users/{id}/suspend": {
+            "post": {
+                "description": "Block login and API key use for the account and revoke all of its sessions (admin only)",
                 "consumes": [
                     "application/json"
                 ],
-                "produces": [
-                    "application/json"
-                ],
                 "tags": [
-                    "Items"
+                    "Admin"
                 ],
-                "summary": "Get item details",
+                "summary": "Suspend a user (Admin)",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Order ID",
-                        "name": "orderId",
+                        "description": "User ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Item ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Reason, recorded in the audit log",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapters_primary_api_user.suspendUserReq"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "204": {
+                        "description": "No Content",
                         "schema": {
-                            "$ref": "#/definitions/internal_adapters_primary_api_items.getItemResp"
+                            "type": "string"
                         }
                     },
                     "400": {
                         "description": "Invalid request",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
                         }
                     },
                     "404": {
-                        "description": "Not found",
+                        "description": "User not found",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
                         }
                     }
-                }
-            },
-            "delete": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Delete an item from an order",
+                ]
+            }
+        },
+        "/admin/users/{id}/unlock": {
+            "post": {
+                "description": "Clear failed login attempts and any lockout on a user's account (admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -1124,20 +1367,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Items"
+                    "Admin"
                 ],
-                "summary": "Delete an item",
+                "summary": "Unlock a user account (Admin)",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Order ID",
-                        "name": "orderId",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Item ID",
+                        "description": "User ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1153,50 +1389,38 @@ const docTemplate = `{
                     "400": {
                         "description": "Invalid request",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
                         }
-                    }
-                }
-            }
-        },
-        "/products": {
-            "get": {
-                "description": "Get a list of all products",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Products"
-                ],
-                "summary": "List all products",
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
-                            "$ref": "#/definitions/internal_adapters_primary_api_product.listProductsResp"
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
-        "/products/{id}": {
-            "get": {
-                "description": "Get product details by ID",
+        "/auth/login": {
+            "post": {
+                "description": "Authenticate user and return tokens",
                 "consumes": [
                     "application/json"
                 ],
@@ -1204,48 +1428,63 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Products"
+                    "Auth"
                 ],
-                "summary": "Get a product",
+                "summary": "Login user",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Product ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Login credentials",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapters_primary_api_user.loginUserReq"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/internal_adapters_primary_api_product.getProductResp"
+                            "$ref": "#/definitions/internal_adapters_primary_api_user.loginUserResp"
                         }
                     },
                     "400": {
                         "description": "Invalid request",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
                         }
                     },
-                    "404": {
-                        "description": "Not found",
+                    "401": {
+                        "description": "Invalid email or password",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Account suspended",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many failed attempts or rate limited",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
                         }
                     }
                 }
             }
         },
-        "/users/{id}": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Get user profile by ID",
+        "/auth/logout": {
+            "post": {
+                "description": "Invalidate user session",
                 "consumes": [
                     "application/json"
                 ],
@@ -1253,36 +1492,12 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
-                ],
-                "summary": "Get user profile",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "User ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "User ID",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/internal_adapters_primary_api_user.getUserProfileReq"
-                        }
-                    }
+                    "Auth"
                 ],
+                "summary": "Logout user",
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/internal_adapters_primary_api_user.getUserProfileResp"
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid request",
+                    "204": {
+                        "description": "No Content",
                         "schema": {
                             "type": "string"
                         }
@@ -1290,86 +1505,137 @@ const docTemplate = `{
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
                         }
                     }
-                }
-            },
-            "put": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Update user profile information",
-                "consumes": [
-                    "application/json"
-                ],
+                ]
+            }
+        },
+        "/auth/oidc/{provider}/callback": {
+            "get": {
+                "description": "Redirect target of the OpenID Connect provider; links the identity and returns tokens",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Auth"
                 ],
-                "summary": "Update user profile",
+                "summary": "Finish social login",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID",
-                        "name": "id",
+                        "description": "Provider name",
+                        "name": "provider",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Updated profile data",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/internal_adapters_primary_api_user.updateUserProfileReq"
-                        }
+                        "type": "string",
+                        "description": "Authorization code",
+                        "name": "code",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Login state",
+                        "name": "state",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/internal_adapters_primary_api_user.loginUserResp"
                         }
                     },
                     "400": {
                         "description": "Invalid request",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
                         }
                     },
                     "401": {
-                        "description": "Unauthorized",
+                        "description": "Login failed",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Account suspended",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Unknown provider",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
                         }
                     }
                 }
-            },
-            "delete": {
-                "security": [
+            }
+        },
+        "/auth/oidc/{provider}/login": {
+            "get": {
+                "description": "Redirect to the OpenID Connect provider (authorization code flow with PKCE)",
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Start social login",
+                "parameters": [
                     {
-                        "BearerAuth": []
+                        "type": "string",
+                        "description": "Provider name",
+                        "name": "provider",
+                        "in": "path",
+                        "required": true
                     }
                 ],
-                "description": "Delete user account",
+                "responses": {
+                    "302": {
+                        "description": "Redirect to the provider",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Unknown provider",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/register": {
+            "post": {
+                "description": "Create a new user account",
                 "consumes": [
                     "application/json"
                 ],
@@ -1377,63 +1643,51 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Auth"
                 ],
-                "summary": "Delete account",
+                "summary": "Register a new user",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "User ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Account deletion data",
+                        "description": "User registration data",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/internal_adapters_primary_api_user.deleteAccountReq"
+                            "$ref": "#/definitions/internal_adapters_primary_api_user.registerUserRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/internal_adapters_primary_api_user.registerUserResp"
                         }
                     },
                     "400": {
                         "description": "Invalid request",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "429": {
+                        "description": "Rate limited",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
                         }
                     }
                 }
             }
         },
-        "/users/{id}/password": {
-            "put": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Change user password",
+        "/auth/renew": {
+            "post": {
+                "description": "Exchange a refresh token for a new access and refresh token pair. Each refresh token can be used once; replaying a used token revokes the session",
                 "consumes": [
                     "application/json"
                 ],
@@ -1441,110 +1695,1493 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Auth"
                 ],
-                "summary": "Change password",
+                "summary": "Renew access token",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "User ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Password change data",
+                        "description": "Refresh token",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/internal_adapters_primary_api_user.changePasswordProfileReq"
+                            "$ref": "#/definitions/internal_adapters_primary_api_user.renewAccessTokenReq"
                         }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/internal_adapters_primary_api_user.renewAccessTokenResp"
                         }
                     },
                     "400": {
                         "description": "Invalid request",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
                         }
                     },
-                    "500": {
-                        "description": "Internal server error",
+                    "429": {
+                        "description": "Rate limited",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "internal_adapters_primary_api_address.addAddressReq": {
-            "type": "object",
-            "properties": {
-                "city": {
-                    "type": "string"
-                },
-                "country": {
-                    "type": "string"
-                },
-                "line1": {
-                    "type": "string"
-                },
-                "postal_code": {
-                    "type": "string"
-                },
-                "province": {
-                    "type": "string"
-                }
-            }
         },
-        "internal_adapters_primary_api_address.addAddressResp": {
-            "type": "object",
-            "properties": {
-                "id": {
-                    "type": "string"
+        "/healthz": {
+            "get": {
+                "description": "Reports that the process is up and serving; it checks no dependencies",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Health"
+                ],
+                "summary": "Liveness probe",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapters_primary_api.HealthStatus"
+                        }
+                    }
                 }
             }
         },
-        "internal_adapters_primary_api_address.addressInfoResp": {
-            "type": "object",
-            "properties": {
-                "city": {
-                    "type": "string"
-                },
-                "country": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "string"
-                },
-                "is_default": {
-                    "type": "boolean"
-                },
-                "line1": {
-                    "type": "string"
-                },
-                "postal_code": {
-                    "type": "string"
-                },
-                "province": {
-                    "type": "string"
-                }
-            }
+        "/items": {
+            "get": {
+                "description": "Get all items across all orders for the authenticated user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Items"
+                ],
+                "summary": "List all user items",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapters_primary_api_items.listItemsResp"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/orders": {
+            "get": {
+                "description": "Get all orders for the authenticated user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "List user orders",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapters_primary_api_order.listOrdersResp"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "post": {
+                "description": "Create a new order with items. Send an Idempotency-Key to retry safely: a repeated request returns the original response instead of placing a second order",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Place a new order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Unique key for this order, e.g. a UUID",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Order data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapters_primary_api_order.placeOrderReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapters_primary_api_order.placeOrderResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "A request with this idempotency key is still in progress",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Idempotency key already used for a different request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/orders/{id}": {
+            "get": {
+                "description": "Get order details by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Get order details",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapters_primary_api_order.getOrderResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/orders/{id}/cancel": {
+            "post": {
+                "description": "Cancel an order by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Cancel an order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Unique key for this cancellation, e.g. a UUID",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "A request with this idempotency key is still in progress",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Idempotency key already used for a different request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/orders/{orderId}/items": {
+            "get": {
+                "description": "Get all items for a specific order",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Items"
+                ],
+                "summary": "List items by order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order ID",
+                        "name": "orderId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapters_primary_api_items.listItemsResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "post": {
+                "description": "Add a new item to an existing order",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Items"
+                ],
+                "summary": "Add item to order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order ID",
+                        "name": "orderId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Item data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapters_primary_api_items.addItemReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapters_primary_api_items.addItemResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Not the order owner",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/orders/{orderId}/items/{id}": {
+            "get": {
+                "description": "Get item details by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Items"
+                ],
+                "summary": "Get item details",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order ID",
+                        "name": "orderId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Item ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapters_primary_api_items.getItemResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Not the order owner",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "delete": {
+                "description": "Delete an item from an order",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Items"
+                ],
+                "summary": "Delete an item",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order ID",
+                        "name": "orderId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Item ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/products": {
+            "get": {
+                "description": "Get a list of all products",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "List all products",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapters_primary_api_product.listProductsResp"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limited",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}": {
+            "get": {
+                "description": "Get product details by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Get a product",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapters_primary_api_product.getProductResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limited",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/readyz": {
+            "get": {
+                "description": "Runs every dependency check concurrently, each under its own timeout, and reports the per-check results.\nFails with 503 when any check fails or once the server has started shutting down.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Health"
+                ],
+                "summary": "Readiness probe",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapters_primary_api.HealthStatus"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapters_primary_api.HealthStatus"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me": {
+            "delete": {
+                "description": "Anonymise the authenticated user's personal data and log out everywhere. Orders are kept, without personal details, for accounting",
+                "tags": [
+                    "Privacy"
+                ],
+                "summary": "Erase my account",
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/users/me/api-keys": {
+            "get": {
+                "description": "Get the API keys of the authenticated user, including revoked and expired ones",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "API Keys"
+                ],
+                "summary": "List my API keys",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapters_primary_api_apikey.listAPIKeysResp"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "post": {
+                "description": "Create a named, scoped and expiring API key for the authenticated user. The key is only returned once",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "API Keys"
+                ],
+                "summary": "Create an API key",
+                "parameters": [
+                    {
+                        "description": "Key name, scopes (read, write, admin) and lifetime",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapters_primary_api_apikey.createAPIKeyReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapters_primary_api_apikey.createAPIKeyResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/users/me/api-keys/{id}": {
+            "delete": {
+                "description": "Revoke one of the authenticated user's API keys",
+                "tags": [
+                    "API Keys"
+                ],
+                "summary": "Revoke an API key",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "API key ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/users/me/export": {
+            "get": {
+                "description": "Download a JSON archive of everything stored about the authenticated user: profile, addresses, orders with items, sessions and linked identities",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Privacy"
+                ],
+                "summary": "Export my data",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapters_primary_api_privacy.exportResp"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/users/me/sessions": {
+            "get": {
+                "description": "Get the active sessions (logged in devices) of the authenticated user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sessions"
+                ],
+                "summary": "List my sessions",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapters_primary_api_session.listSessionsResp"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "delete": {
+                "description": "Revoke every session of the authenticated user except the current one",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sessions"
+                ],
+                "summary": "Log out everywhere else",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapters_primary_api_session.revokeSessionsResp"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Not allowed while impersonating",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/users/me/sessions/{id}": {
+            "delete": {
+                "description": "Log out a single session of the authenticated user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sessions"
+                ],
+                "summary": "Revoke one of my sessions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Session ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Not allowed while impersonating",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/users/{id}": {
+            "get": {
+                "description": "Get a user's profile. Use \"me\" as the ID for the caller's own profile; other IDs require admin",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get user profile",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID or \\",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapters_primary_api_user.getUserProfileResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "put": {
+                "description": "Update a user's name and email. Use \"me\" as the ID for the caller's own profile; other IDs require admin",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Update user profile",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID or \\",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated profile data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapters_primary_api_user.updateUserProfileReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden or not allowed while impersonating",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "delete": {
+                "description": "Erase a user account (anonymise personal data, keep order history). Users can only erase their own account, admins any account",
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Delete account",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Already erased",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/users/{id}/password": {
+            "put": {
+                "description": "Change a user's password. Use \"me\" as the ID for the caller's own account; other IDs require admin",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Change password",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID or \\",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Password change data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_adapters_primary_api_user.changePasswordProfileReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden or current password is incorrect",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        }
+    },
+    "definitions": {
+        "github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.FieldError": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "field": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.Problem": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "detail": {
+                    "type": "string"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_adapters_primary_api_httpio.FieldError"
+                    }
+                },
+                "status": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_frostnzx_go-ecommerce-api_internal_core_utils.JWK": {
+            "type": "object",
+            "properties": {
+                "alg": {
+                    "type": "string"
+                },
+                "crv": {
+                    "description": "Ed25519 (OKP)",
+                    "type": "string"
+                },
+                "e": {
+                    "type": "string"
+                },
+                "kid": {
+                    "type": "string"
+                },
+                "kty": {
+                    "type": "string"
+                },
+                "n": {
+                    "description": "RSA",
+                    "type": "string"
+                },
+                "use": {
+                    "type": "string"
+                },
+                "x": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_frostnzx_go-ecommerce-api_internal_core_utils.JWKSet": {
+            "type": "object",
+            "properties": {
+                "keys": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_frostnzx_go-ecommerce-api_internal_core_utils.JWK"
+                    }
+                }
+            }
+        },
+        "internal_adapters_primary_api.CheckResult": {
+            "type": "object",
+            "properties": {
+                "duration_ms": {
+                    "type": "number",
+                    "example": 1.7
+                },
+                "error": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "ok"
+                }
+            }
+        },
+        "internal_adapters_primary_api.HealthStatus": {
+            "type": "object",
+            "properties": {
+                "checks": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/internal_adapters_primary_api.CheckResult"
+                    }
+                },
+                "status": {
+                    "type": "string",
+                    "example": "ok"
+                }
+            }
+        },
+        "internal_adapters_primary_api_address.addAddressReq": {
+            "type": "object",
+            "properties": {
+                "city": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "country": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "line1": {
+                    "type": "string",
+                    "maxLength": 200
+                },
+                "postal_code": {
+                    "type": "string",
+                    "maxLength": 20
+                },
+                "province": {
+                    "type": "string",
+                    "maxLength": 100
+                }
+            }
+        },
+        "internal_adapters_primary_api_address.addAddressResp": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_adapters_primary_api_address.addressInfoResp": {
+            "type": "object",
+            "properties": {
+                "city": {
+                    "type": "string"
+                },
+                "country": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_default": {
+                    "type": "boolean"
+                },
+                "line1": {
+                    "type": "string"
+                },
+                "postal_code": {
+                    "type": "string"
+                },
+                "province": {
+                    "type": "string"
+                }
+            }
         },
         "internal_adapters_primary_api_address.getDefaultAddressResp": {
             "type": "object",
@@ -1580,14 +3217,113 @@ const docTemplate = `{
                 }
             }
         },
+        "internal_adapters_primary_api_apikey.apiKeyInfoResp": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "last_used_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "prefix": {
+                    "type": "string"
+                },
+                "revoked_at": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "internal_adapters_primary_api_apikey.createAPIKeyReq": {
+            "type": "object",
+            "required": [
+                "scopes"
+            ],
+            "properties": {
+                "expires_in_days": {
+                    "type": "integer",
+                    "maximum": 365,
+                    "minimum": 0
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "scopes": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "internal_adapters_primary_api_apikey.createAPIKeyResp": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "key": {
+                    "description": "Shown once, store it safely",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "prefix": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "internal_adapters_primary_api_apikey.listAPIKeysResp": {
+            "type": "object",
+            "properties": {
+                "api_keys": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_adapters_primary_api_apikey.apiKeyInfoResp"
+                    }
+                }
+            }
+        },
         "internal_adapters_primary_api_items.addItemReq": {
             "type": "object",
+            "required": [
+                "product_id"
+            ],
             "properties": {
                 "product_id": {
                     "type": "string"
                 },
                 "quantity": {
-                    "type": "integer"
+                    "type": "integer",
+                    "maximum": 1000,
+                    "minimum": 1
                 }
             }
         },
@@ -1624,101 +3360,266 @@ const docTemplate = `{
                     "type": "string"
                 },
                 "quantity": {
-                    "type": "integer"
+                    "type": "integer"
+                },
+                "unit_price_snapshot": {
+                    "type": "number"
+                }
+            }
+        },
+        "internal_adapters_primary_api_items.itemInfoResp": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "product_id": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "unit_price_snapshot": {
+                    "type": "number"
+                }
+            }
+        },
+        "internal_adapters_primary_api_items.listItemsResp": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_adapters_primary_api_items.itemInfoResp"
+                    }
+                }
+            }
+        },
+        "internal_adapters_primary_api_order.getOrderResp": {
+            "type": "object",
+            "properties": {
+                "address_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_adapters_primary_api_order.orderItemInfoResp"
+                    }
+                },
+                "status": {
+                    "type": "string"
+                },
+                "total_amount": {
+                    "type": "number"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_adapters_primary_api_order.listOrdersResp": {
+            "type": "object",
+            "properties": {
+                "orders": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_adapters_primary_api_order.orderInfoResp"
+                    }
+                }
+            }
+        },
+        "internal_adapters_primary_api_order.orderInfoResp": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "total_amount": {
+                    "type": "number"
+                }
+            }
+        },
+        "internal_adapters_primary_api_order.orderItemInfoResp": {
+            "type": "object",
+            "properties": {
+                "product_id": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "unit_price": {
+                    "type": "number"
+                }
+            }
+        },
+        "internal_adapters_primary_api_order.orderItemReq": {
+            "type": "object",
+            "required": [
+                "product_id"
+            ],
+            "properties": {
+                "product_id": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "maximum": 1000,
+                    "minimum": 1
+                }
+            }
+        },
+        "internal_adapters_primary_api_order.placeOrderReq": {
+            "type": "object",
+            "required": [
+                "address_id",
+                "items"
+            ],
+            "properties": {
+                "address_id": {
+                    "type": "string"
                 },
-                "unit_price_snapshot": {
-                    "type": "number"
+                "items": {
+                    "type": "array",
+                    "maxItems": 100,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/internal_adapters_primary_api_order.orderItemReq"
+                    }
                 }
             }
         },
-        "internal_adapters_primary_api_items.itemInfoResp": {
+        "internal_adapters_primary_api_order.placeOrderResp": {
             "type": "object",
             "properties": {
-                "id": {
+                "created_at": {
                     "type": "string"
                 },
-                "product_id": {
+                "id": {
                     "type": "string"
                 },
-                "quantity": {
-                    "type": "integer"
+                "status": {
+                    "type": "string"
                 },
-                "unit_price_snapshot": {
+                "total_amount": {
                     "type": "number"
                 }
             }
         },
-        "internal_adapters_primary_api_items.listItemsResp": {
+        "internal_adapters_primary_api_order.updateStatusReq": {
             "type": "object",
+            "required": [
+                "status"
+            ],
             "properties": {
-                "items": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/internal_adapters_primary_api_items.itemInfoResp"
-                    }
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "pending",
+                        "paid",
+                        "shipped",
+                        "cancelled"
+                    ]
                 }
             }
         },
-        "internal_adapters_primary_api_order.getOrderResp": {
+        "internal_adapters_primary_api_privacy.addressExport": {
             "type": "object",
             "properties": {
-                "address_id": {
+                "city": {
                     "type": "string"
                 },
-                "created_at": {
+                "country": {
                     "type": "string"
                 },
                 "id": {
                     "type": "string"
                 },
-                "items": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/internal_adapters_primary_api_order.orderItemInfoResp"
-                    }
+                "is_default": {
+                    "type": "boolean"
                 },
-                "status": {
+                "line1": {
                     "type": "string"
                 },
-                "total_amount": {
-                    "type": "number"
+                "postal_code": {
+                    "type": "string"
                 },
-                "user_id": {
+                "province": {
                     "type": "string"
                 }
             }
         },
-        "internal_adapters_primary_api_order.listOrdersResp": {
+        "internal_adapters_primary_api_privacy.exportResp": {
             "type": "object",
             "properties": {
+                "addresses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_adapters_primary_api_privacy.addressExport"
+                    }
+                },
+                "exported_at": {
+                    "type": "string"
+                },
+                "linked_identities": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_adapters_primary_api_privacy.identityExport"
+                    }
+                },
                 "orders": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/internal_adapters_primary_api_order.orderInfoResp"
+                        "$ref": "#/definitions/internal_adapters_primary_api_privacy.orderExport"
+                    }
+                },
+                "profile": {
+                    "$ref": "#/definitions/internal_adapters_primary_api_privacy.profileExport"
+                },
+                "sessions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_adapters_primary_api_privacy.sessionExport"
                     }
                 }
             }
         },
-        "internal_adapters_primary_api_order.orderInfoResp": {
+        "internal_adapters_primary_api_privacy.identityExport": {
             "type": "object",
             "properties": {
                 "created_at": {
                     "type": "string"
                 },
-                "id": {
+                "email": {
                     "type": "string"
                 },
-                "status": {
+                "provider": {
                     "type": "string"
                 },
-                "total_amount": {
-                    "type": "number"
+                "subject": {
+                    "type": "string"
                 }
             }
         },
-        "internal_adapters_primary_api_order.orderItemInfoResp": {
+        "internal_adapters_primary_api_privacy.itemExport": {
             "type": "object",
             "properties": {
+                "id": {
+                    "type": "string"
+                },
                 "product_id": {
                     "type": "string"
                 },
@@ -1730,52 +3631,71 @@ const docTemplate = `{
                 }
             }
         },
-        "internal_adapters_primary_api_order.orderItemReq": {
+        "internal_adapters_primary_api_privacy.orderExport": {
             "type": "object",
             "properties": {
-                "product_id": {
+                "address_id": {
                     "type": "string"
                 },
-                "quantity": {
-                    "type": "integer"
-                }
-            }
-        },
-        "internal_adapters_primary_api_order.placeOrderReq": {
-            "type": "object",
-            "properties": {
-                "address_id": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
                     "type": "string"
                 },
                 "items": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/internal_adapters_primary_api_order.orderItemReq"
+                        "$ref": "#/definitions/internal_adapters_primary_api_privacy.itemExport"
                     }
+                },
+                "status": {
+                    "type": "string"
+                },
+                "total_amount": {
+                    "type": "number"
                 }
             }
         },
-        "internal_adapters_primary_api_order.placeOrderResp": {
+        "internal_adapters_primary_api_privacy.profileExport": {
             "type": "object",
             "properties": {
                 "created_at": {
                     "type": "string"
                 },
-                "id": {
+                "email": {
                     "type": "string"
                 },
-                "status": {
+                "id": {
                     "type": "string"
                 },
-                "total_amount": {
-                    "type": "number"
+                "is_admin": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
                 }
             }
         },
-        "internal_adapters_primary_api_order.updateStatusReq": {
+        "internal_adapters_primary_api_privacy.sessionExport": {
             "type": "object",
             "properties": {
-                "status": {
+                "created_at": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "ip_address": {
+                    "type": "string"
+                },
+                "last_used_at": {
+                    "type": "string"
+                },
+                "user_agent": {
                     "type": "string"
                 }
             }
@@ -1784,19 +3704,23 @@ const docTemplate = `{
             "type": "object",
             "properties": {
                 "description": {
-                    "type": "string"
+                    "type": "string",
+                    "maxLength": 2000
                 },
                 "name": {
-                    "type": "string"
+                    "type": "string",
+                    "maxLength": 200
                 },
                 "price": {
                     "type": "number"
                 },
                 "sku": {
-                    "type": "string"
+                    "type": "string",
+                    "maxLength": 64
                 },
                 "stock_qty": {
-                    "type": "integer"
+                    "type": "integer",
+                    "minimum": 0
                 }
             }
         },
@@ -1824,19 +3748,23 @@ const docTemplate = `{
                     "type": "boolean"
                 },
                 "description": {
-                    "type": "string"
+                    "type": "string",
+                    "maxLength": 2000
                 },
                 "name": {
-                    "type": "string"
+                    "type": "string",
+                    "maxLength": 200
                 },
                 "price": {
                     "type": "number"
                 },
                 "sku": {
-                    "type": "string"
+                    "type": "string",
+                    "maxLength": 64
                 },
                 "stock_qty": {
-                    "type": "integer"
+                    "type": "integer",
+                    "minimum": 0
                 }
             }
         },
@@ -1932,35 +3860,86 @@ const docTemplate = `{
                 }
             }
         },
-        "internal_adapters_primary_api_user.changePasswordProfileReq": {
+        "internal_adapters_primary_api_session.listSessionsResp": {
             "type": "object",
             "properties": {
-                "current_password": {
+                "sessions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_adapters_primary_api_session.sessionInfoResp"
+                    }
+                }
+            }
+        },
+        "internal_adapters_primary_api_session.revokeSessionsResp": {
+            "type": "object",
+            "properties": {
+                "revoked": {
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_adapters_primary_api_session.sessionInfoResp": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "current": {
+                    "type": "boolean"
+                },
+                "expires_at": {
                     "type": "string"
                 },
                 "id": {
                     "type": "string"
                 },
-                "new_password": {
+                "ip_address": {
+                    "type": "string"
+                },
+                "last_used_at": {
+                    "type": "string"
+                },
+                "user_agent": {
                     "type": "string"
                 }
             }
         },
-        "internal_adapters_primary_api_user.deleteAccountReq": {
+        "internal_adapters_primary_api_user.auditEntryResp": {
             "type": "object",
             "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "actor_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "details": {
+                    "type": "string"
+                },
                 "id": {
                     "type": "string"
                 },
-                "session_id": {
+                "target_user_id": {
                     "type": "string"
                 }
             }
         },
-        "internal_adapters_primary_api_user.getUserProfileReq": {
+        "internal_adapters_primary_api_user.changePasswordProfileReq": {
             "type": "object",
+            "required": [
+                "current_password",
+                "new_password"
+            ],
             "properties": {
-                "id": {
+                "current_password": {
+                    "type": "string",
+                    "maxLength": 72
+                },
+                "new_password": {
                     "type": "string"
                 }
             }
@@ -1982,9 +3961,58 @@ const docTemplate = `{
                 }
             }
         },
+        "internal_adapters_primary_api_user.impersonateUserReq": {
+            "type": "object",
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "maxLength": 500
+                }
+            }
+        },
+        "internal_adapters_primary_api_user.impersonateUserResp": {
+            "type": "object",
+            "properties": {
+                "access_token": {
+                    "type": "string"
+                },
+                "access_token_expires_at": {
+                    "type": "string"
+                },
+                "session_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_adapters_primary_api_user.listAuditLogResp": {
+            "type": "object",
+            "properties": {
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/internal_adapters_primary_api_user.auditEntryResp"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "offset": {
+                    "type": "integer"
+                }
+            }
+        },
         "internal_adapters_primary_api_user.listUsersResp": {
             "type": "object",
             "properties": {
+                "limit": {
+                    "type": "integer"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                },
                 "users": {
                     "type": "array",
                     "items": {
@@ -1995,12 +4023,18 @@ const docTemplate = `{
         },
         "internal_adapters_primary_api_user.loginUserReq": {
             "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
             "properties": {
                 "email": {
-                    "type": "string"
+                    "type": "string",
+                    "maxLength": 254
                 },
                 "password": {
-                    "type": "string"
+                    "type": "string",
+                    "maxLength": 72
                 }
             }
         },
@@ -2035,15 +4069,18 @@ const docTemplate = `{
         },
         "internal_adapters_primary_api_user.registerUserRequest": {
             "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
             "properties": {
                 "email": {
-                    "type": "string"
-                },
-                "is_admin": {
-                    "type": "boolean"
+                    "type": "string",
+                    "maxLength": 254
                 },
                 "name": {
-                    "type": "string"
+                    "type": "string",
+                    "maxLength": 100
                 },
                 "password": {
                     "type": "string"
@@ -2060,6 +4097,9 @@ const docTemplate = `{
         },
         "internal_adapters_primary_api_user.renewAccessTokenReq": {
             "type": "object",
+            "required": [
+                "refresh_token"
+            ],
             "properties": {
                 "refresh_token": {
                     "type": "string"
@@ -2074,26 +4114,54 @@ const docTemplate = `{
                 },
                 "access_token_expires_at": {
                     "type": "string"
+                },
+                "refresh_token": {
+                    "type": "string"
+                },
+                "refresh_token_expires_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_adapters_primary_api_user.resetUserPasswordResp": {
+            "type": "object",
+            "properties": {
+                "temporary_password": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_adapters_primary_api_user.suspendUserReq": {
+            "type": "object",
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "maxLength": 500
                 }
             }
         },
         "internal_adapters_primary_api_user.updateUserProfileReq": {
             "type": "object",
+            "required": [
+                "email"
+            ],
             "properties": {
                 "email": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "string"
+                    "type": "string",
+                    "maxLength": 254
                 },
                 "name": {
-                    "type": "string"
+                    "type": "string",
+                    "maxLength": 100
                 }
             }
         },
         "internal_adapters_primary_api_user.userInfo": {
             "type": "object",
             "properties": {
+                "created_at": {
+                    "type": "string"
+                },
                 "email": {
                     "type": "string"
                 },
@@ -2105,11 +4173,20 @@ const docTemplate = `{
                 },
                 "name": {
                     "type": "string"
+                },
+                "suspended_at": {
+                    "type": "string"
                 }
             }
         }
     },
     "securityDefinitions": {
+        "ApiKeyAuth": {
+            "description": "Personal API key, also accepted as \"Authorization: ApiKey \u003ckey\u003e\".",
+            "type": "apiKey",
+            "name": "X-API-Key",
+            "in": "header"
+        },
         "BearerAuth": {
             "description": "Type \"Bearer\" followed by a space and JWT token.",
             "type": "apiKey",
@@ -5,19 +5,22 @@ import (
 	"net/http"
 
 	"github.com/frostnzx/go-ecommerce-api/internal/adapters/primary/api/auth"
+	"github.com/frostnzx/go-ecommerce-api/internal/adapters/primary/api/httpio"
 	coreaddress "github.com/frostnzx/go-ecommerce-api/internal/core/services/address"
 	"github.com/google/uuid"
 )
 
 type Handler struct {
-	svc            coreaddress.API
-	authMiddleware func(http.Handler) http.Handler
+	svc             coreaddress.API
+	authMiddleware  func(http.Handler) http.Handler
+	adminMiddleware func(http.Handler) http.Handler
 }
 
-func New(svc coreaddress.API, authMiddleware func(http.Handler) http.Handler) *Handler {
+func New(svc coreaddress.API, authMiddleware, adminMiddleware func(http.Handler) http.Handler) *Handler {
 	return &Handler{
-		svc:            svc,
-		authMiddleware: authMiddleware,
+		svc:             svc,
+		authMiddleware:  authMiddleware,
+		adminMiddleware: adminMiddleware,
 	}
 }
 
@@ -27,15 +30,18 @@ func (h *Handler) SetupRoutes(mux *http.ServeMux) {
 	mux.Handle("DELETE /addresses/{id}", h.authMiddleware(http.HandlerFunc(h.DeleteAddressHandler)))
 	mux.Handle("PUT /addresses/{id}/default", h.authMiddleware(http.HandlerFunc(h.SetDefaultAddressHandler)))
 	mux.Handle("GET /addresses/default", h.authMiddleware(http.HandlerFunc(h.GetDefaultAddressHandler)))
+
+	// Admin routes
+	mux.Handle("GET /admin/users/{id}/addresses", h.adminMiddleware(http.HandlerFunc(h.ListUserAddressesHandler)))
 }
 
 // DTOs
 type addAddressReq struct {
-	Line1      string `json:"line1"`
-	City       string `json:"city"`
-	Province   string `json:"province"`
-	PostalCode string `json:"postal_code"`
-	Country    string `json:"country"`
+	Line1      string `json:"line1" validate:"notblank,max=200"`
+	City       string `json:"city" validate:"notblank,max=100"`
+	Province   string `json:"province" validate:"max=100"`
+	PostalCode string `json:"postal_code" validate:"notblank,max=20"`
+	Country    string `json:"country" validate:"notblank,max=100"`
 }
 
 type addAddressResp struct {
@@ -73,20 +79,19 @@ type getDefaultAddressResp struct {
 // @Produce      json
 // @Param        request body addAddressReq true "Address data"
 // @Success      201 {object} addAddressResp
-// @Failure      400 {string} string "Invalid request"
-// @Failure      401 {string} string "Unauthorized"
+// @Failure      400 {object} httpio.Problem "Invalid request"
+// @Failure      401 {object} httpio.Problem "Unauthorized"
 // @Security     BearerAuth
 // @Router       /addresses [post]
 func (h *Handler) AddAddressHandler(w http.ResponseWriter, r *http.Request) {
 	claims, ok := auth.GetClaimsFromContext(r.Context())
 	if !ok {
-		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		httpio.Error(w, http.StatusUnauthorized, httpio.CodeUnauthorized, "authentication required")
 		return
 	}
 
 	var req addAddressReq
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "invalid request", http.StatusBadRequest)
+	if !httpio.DecodeJSON(w, r, &req) {
 		return
 	}
 
@@ -101,7 +106,7 @@ func (h *Handler) AddAddressHandler(w http.ResponseWriter, r *http.Request) {
 
 	res, err := h.svc.AddAddress(r.Context(), in)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		httpio.WriteError(w, err)
 		return
 	}
 
@@ -118,21 +123,48 @@ func (h *Handler) AddAddressHandler(w http.ResponseWriter, r *http.Request) {
 // @Accept       json
 // @Produce      json
 // @Success      200 {object} listAddressesResp
-// @Failure      401 {string} string "Unauthorized"
-// @Failure      500 {string} string "Internal server error"
+// @Failure      401 {object} httpio.Problem "Unauthorized"
+// @Failure      500 {object} httpio.Problem "Internal server error"
 // @Security     BearerAuth
 // @Router       /addresses [get]
 func (h *Handler) ListAddressesHandler(w http.ResponseWriter, r *http.Request) {
 	claims, ok := auth.GetClaimsFromContext(r.Context())
 	if !ok {
-		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		httpio.Error(w, http.StatusUnauthorized, httpio.CodeUnauthorized, "authentication required")
+		return
+	}
+
+	h.listAddresses(w, r, claims.ID)
+}
+
+// ListUserAddressesHandler godoc
+// @Summary      List a user's addresses (Admin)
+// @Description  Get all addresses of any user (admin only)
+// @Tags         Admin
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "User ID"
+// @Success      200 {object} listAddressesResp
+// @Failure      400 {object} httpio.Problem "Invalid request"
+// @Failure      401 {object} httpio.Problem "Unauthorized"
+// @Failure      403 {object} httpio.Problem "Forbidden"
+// @Failure      500 {object} httpio.Problem "Internal server error"
+// @Security     BearerAuth
+// @Router       /admin/users/{id}/addresses [get]
+func (h *Handler) ListUserAddressesHandler(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		httpio.InvalidField(w, "id", "invalid user id")
 		return
 	}
+	h.listAddresses(w, r, userID)
+}
 
-	in := coreaddress.ListAddressesReq{UserID: claims.ID}
+func (h *Handler) listAddresses(w http.ResponseWriter, r *http.Request, userID uuid.UUID) {
+	in := coreaddress.ListAddressesReq{UserID: userID}
 	res, err := h.svc.ListAddresses(r.Context(), in)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		httpio.WriteError(w, err)
 		return
 	}
 
@@ -163,28 +195,28 @@ func (h *Handler) ListAddressesHandler(w http.ResponseWriter, r *http.Request) {
 // @Produce      json
 // @Param        id path string true "Address ID"
 // @Success      204 {string} string "No Content"
-// @Failure      400 {string} string "Invalid request"
-// @Failure      401 {string} string "Unauthorized"
+// @Failure      400 {object} httpio.Problem "Invalid request"
+// @Failure      401 {object} httpio.Problem "Unauthorized"
 // @Security     BearerAuth
 // @Router       /addresses/{id} [delete]
 func (h *Handler) DeleteAddressHandler(w http.ResponseWriter, r *http.Request) {
 	claims, ok := auth.GetClaimsFromContext(r.Context())
 	if !ok {
-		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		httpio.Error(w, http.StatusUnauthorized, httpio.CodeUnauthorized, "authentication required")
 		return
 	}
 
 	addressIDStr := r.PathValue("id")
 	addressID, err := uuid.Parse(addressIDStr)
 	if err != nil {
-		http.Error(w, "invalid address id", http.StatusBadRequest)
+		httpio.InvalidField(w, "id", "invalid address id")
 		return
 	}
 
 	in := coreaddress.DeleteAddressReq{ID: addressID, UserID: claims.ID}
 	err = h.svc.DeleteAddress(r.Context(), in)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		httpio.WriteError(w, err)
 		return
 	}
 
@@ -199,28 +231,28 @@ func (h *Handler) DeleteAddressHandler(w http.ResponseWriter, r *http.Request) {
 // @Produce      json
 // @Param        id path string true "Address ID"
 // @Success      204 {string} string "No Content"
-// @Failure      400 {string} string "Invalid request"
-// @Failure      401 {string} string "Unauthorized"
+// @Failure      400 {object} httpio.Problem "Invalid request"
+// @Failure      401 {object} httpio.Problem "Unauthorized"
 // @Security     BearerAuth
 // @Router       /addresses/{id}/default [put]
 func (h *Handler) SetDefaultAddressHandler(w http.ResponseWriter, r *http.Request) {
 	claims, ok := auth.GetClaimsFromContext(r.Context())
 	if !ok {
-		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		httpio.Error(w, http.StatusUnauthorized, httpio.CodeUnauthorized, "authentication required")
 		return
 	}
 
 	addressIDStr := r.PathValue("id")
 	addressID, err := uuid.Parse(addressIDStr)
 	if err != nil {
-		http.Error(w, "invalid address id", http.StatusBadRequest)
+		httpio.InvalidField(w, "id", "invalid address id")
 		return
 	}
 
 	in := coreaddress.SetDefaultAddressReq{AddressID: addressID, UserID: claims.ID}
 	err = h.svc.SetDefaultAddress(r.Context(), in)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		httpio.WriteError(w, err)
 		return
 	}
 
@@ -234,21 +266,21 @@ func (h *Handler) SetDefaultAddressHandler(w http.ResponseWriter, r *http.Reques
 // @Accept       json
 // @Produce      json
 // @Success      200 {object} getDefaultAddressResp
-// @Failure      401 {string} string "Unauthorized"
-// @Failure      404 {string} string "Not found"
+// @Failure      401 {object} httpio.Problem "Unauthorized"
+// @Failure      404 {object} httpio.Problem "Not found"
 // @Security     BearerAuth
 // @Router       /addresses/default [get]
 func (h *Handler) GetDefaultAddressHandler(w http.ResponseWriter, r *http.Request) {
 	claims, ok := auth.GetClaimsFromContext(r.Context())
 	if !ok {
-		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		httpio.Error(w, http.StatusUnauthorized, httpio.CodeUnauthorized, "authentication required")
 		return
 	}
 
 	in := coreaddress.GetDefaultAddressReq{UserID: claims.ID}
 	res, err := h.svc.GetDefaultAddress(r.Context(), in)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusNotFound)
+		httpio.WriteError(w, err)
 		return
 	}
 
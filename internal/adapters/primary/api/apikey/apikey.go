@@ -0,0 +1,297 @@
+package apikey
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/frostnzx/go-ecommerce-api/internal/adapters/primary/api/auth"
+	"github.com/frostnzx/go-ecommerce-api/internal/adapters/primary/api/httpio"
+	domainapikey "github.com/frostnzx/go-ecommerce-api/internal/core/domain/apikey"
+	coreapikey "github.com/frostnzx/go-ecommerce-api/internal/core/services/apikey"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/utils"
+	"github.com/google/uuid"
+)
+
+type Handler struct {
+	svc             coreapikey.API
+	authMiddleware  func(http.Handler) http.Handler
+	adminMiddleware func(http.Handler) http.Handler
+}
+
+func New(svc coreapikey.API, authMiddleware, adminMiddleware func(http.Handler) http.Handler) *Handler {
+	return &Handler{
+		svc:             svc,
+		authMiddleware:  authMiddleware,
+		adminMiddleware: adminMiddleware,
+	}
+}
+
+func (h *Handler) SetupRoutes(mux *http.ServeMux) {
+	// Protected routes (auth required)
+	mux.Handle("POST /users/me/api-keys", h.authMiddleware(http.HandlerFunc(h.CreateMyAPIKeyHandler)))
+	mux.Handle("GET /users/me/api-keys", h.authMiddleware(http.HandlerFunc(h.ListMyAPIKeysHandler)))
+	mux.Handle("DELETE /users/me/api-keys/{id}", h.authMiddleware(http.HandlerFunc(h.RevokeMyAPIKeyHandler)))
+
+	// Admin routes (admin only), e.g. keys for service accounts
+	mux.Handle("POST /admin/users/{id}/api-keys", h.adminMiddleware(http.HandlerFunc(h.CreateUserAPIKeyHandler)))
+	mux.Handle("GET /admin/users/{id}/api-keys", h.adminMiddleware(http.HandlerFunc(h.ListUserAPIKeysHandler)))
+	mux.Handle("DELETE /admin/users/{id}/api-keys/{keyId}", h.adminMiddleware(http.HandlerFunc(h.RevokeUserAPIKeyHandler)))
+}
+
+// DTOs
+type createAPIKeyReq struct {
+	Name          string   `json:"name" validate:"notblank,max=100"`
+	Scopes        []string `json:"scopes" validate:"required,min=1,dive,oneof=read write admin"`
+	ExpiresInDays int      `json:"expires_in_days" validate:"min=0,max=365"`
+}
+type createAPIKeyResp struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	Key       string    `json:"key"` // Shown once, store it safely
+	Prefix    string    `json:"prefix"`
+	Scopes    []string  `json:"scopes"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+type apiKeyInfoResp struct {
+	ID         uuid.UUID  `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	CreatedAt  time.Time  `json:"created_at"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+}
+
+type listAPIKeysResp struct {
+	APIKeys []apiKeyInfoResp `json:"api_keys"`
+}
+
+// Handlers
+
+// CreateMyAPIKeyHandler godoc
+// @Summary      Create an API key
+// @Description  Create a named, scoped and expiring API key for the authenticated user. The key is only returned once
+// @Tags         API Keys
+// @Accept       json
+// @Produce      json
+// @Param        request body createAPIKeyReq true "Key name, scopes (read, write, admin) and lifetime"
+// @Success      201 {object} createAPIKeyResp
+// @Failure      400 {object} httpio.Problem "Invalid request"
+// @Failure      401 {object} httpio.Problem "Unauthorized"
+// @Failure      403 {object} httpio.Problem "Forbidden"
+// @Failure      500 {object} httpio.Problem "Internal server error"
+// @Security     BearerAuth
+// @Router       /users/me/api-keys [post]
+func (h *Handler) CreateMyAPIKeyHandler(w http.ResponseWriter, r *http.Request) {
+	claims, ok := sessionClaims(w, r)
+	if !ok {
+		return
+	}
+	h.createAPIKey(w, r, claims.ID, claims.ID)
+}
+
+// ListMyAPIKeysHandler godoc
+// @Summary      List my API keys
+// @Description  Get the API keys of the authenticated user, including revoked and expired ones
+// @Tags         API Keys
+// @Produce      json
+// @Success      200 {object} listAPIKeysResp
+// @Failure      401 {object} httpio.Problem "Unauthorized"
+// @Failure      500 {object} httpio.Problem "Internal server error"
+// @Security     BearerAuth
+// @Router       /users/me/api-keys [get]
+func (h *Handler) ListMyAPIKeysHandler(w http.ResponseWriter, r *http.Request) {
+	claims, ok := sessionClaims(w, r)
+	if !ok {
+		return
+	}
+	h.listAPIKeys(w, r, claims.ID)
+}
+
+// RevokeMyAPIKeyHandler godoc
+// @Summary      Revoke an API key
+// @Description  Revoke one of the authenticated user's API keys
+// @Tags         API Keys
+// @Param        id path string true "API key ID"
+// @Success      204 {string} string "No Content"
+// @Failure      400 {object} httpio.Problem "Invalid request"
+// @Failure      401 {object} httpio.Problem "Unauthorized"
+// @Failure      404 {object} httpio.Problem "Not found"
+// @Security     BearerAuth
+// @Router       /users/me/api-keys/{id} [delete]
+func (h *Handler) RevokeMyAPIKeyHandler(w http.ResponseWriter, r *http.Request) {
+	claims, ok := sessionClaims(w, r)
+	if !ok {
+		return
+	}
+	h.revokeAPIKey(w, r, claims.ID, r.PathValue("id"))
+}
+
+// CreateUserAPIKeyHandler godoc
+// @Summary      Create an API key for a user (Admin)
+// @Description  Create an API key on behalf of a user, e.g. a service account (admin only). The key and the admin who created it are written to the audit log
+// @Tags         Admin
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "User ID"
+// @Param        request body createAPIKeyReq true "Key name, scopes (read, write, admin) and lifetime"
+// @Success      201 {object} createAPIKeyResp
+// @Failure      400 {object} httpio.Problem "Invalid request"
+// @Failure      401 {object} httpio.Problem "Unauthorized"
+// @Failure      403 {object} httpio.Problem "Forbidden"
+// @Failure      500 {object} httpio.Problem "Internal server error"
+// @Security     BearerAuth
+// @Router       /admin/users/{id}/api-keys [post]
+func (h *Handler) CreateUserAPIKeyHandler(w http.ResponseWriter, r *http.Request) {
+	claims, ok := sessionClaims(w, r)
+	if !ok {
+		return
+	}
+	userID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		httpio.InvalidField(w, "id", "invalid user id")
+		return
+	}
+	h.createAPIKey(w, r, userID, claims.ID)
+}
+
+// ListUserAPIKeysHandler godoc
+// @Summary      List a user's API keys (Admin)
+// @Description  Get the API keys of any user (admin only)
+// @Tags         Admin
+// @Produce      json
+// @Param        id path string true "User ID"
+// @Success      200 {object} listAPIKeysResp
+// @Failure      400 {object} httpio.Problem "Invalid request"
+// @Failure      401 {object} httpio.Problem "Unauthorized"
+// @Failure      403 {object} httpio.Problem "Forbidden"
+// @Failure      500 {object} httpio.Problem "Internal server error"
+// @Security     BearerAuth
+// @Router       /admin/users/{id}/api-keys [get]
+func (h *Handler) ListUserAPIKeysHandler(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		httpio.InvalidField(w, "id", "invalid user id")
+		return
+	}
+	h.listAPIKeys(w, r, userID)
+}
+
+// RevokeUserAPIKeyHandler godoc
+// @Summary      Revoke a user's API key (Admin)
+// @Description  Revoke an API key of any user (admin only)
+// @Tags         Admin
+// @Param        id path string true "User ID"
+// @Param        keyId path string true "API key ID"
+// @Success      204 {string} string "No Content"
+// @Failure      400 {object} httpio.Problem "Invalid request"
+// @Failure      401 {object} httpio.Problem "Unauthorized"
+// @Failure      403 {object} httpio.Problem "Forbidden"
+// @Failure      404 {object} httpio.Problem "Not found"
+// @Security     BearerAuth
+// @Router       /admin/users/{id}/api-keys/{keyId} [delete]
+func (h *Handler) RevokeUserAPIKeyHandler(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		httpio.InvalidField(w, "id", "invalid user id")
+		return
+	}
+	h.revokeAPIKey(w, r, userID, r.PathValue("keyId"))
+}
+
+// createAPIKey creates a key for userID; createdBy is the admin when it is not the user themselves
+func (h *Handler) createAPIKey(w http.ResponseWriter, r *http.Request, userID, createdBy uuid.UUID) {
+	var req createAPIKeyReq
+	if !httpio.DecodeJSON(w, r, &req) {
+		return
+	}
+	in := coreapikey.CreateAPIKeyReq{
+		UserID:        userID,
+		Name:          req.Name,
+		Scopes:        req.Scopes,
+		ExpiresInDays: req.ExpiresInDays,
+		CreatedBy:     createdBy,
+	}
+	res, err := h.svc.CreateAPIKey(r.Context(), in)
+	if err != nil {
+		httpio.WriteError(w, err)
+		return
+	}
+	resp := createAPIKeyResp{
+		ID:        res.ID,
+		Name:      res.Name,
+		Key:       res.Key,
+		Prefix:    res.Prefix,
+		Scopes:    res.Scopes,
+		ExpiresAt: res.ExpiresAt,
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusCreated) // 201
+	json.NewEncoder(w).Encode(resp)
+}
+
+func (h *Handler) listAPIKeys(w http.ResponseWriter, r *http.Request, userID uuid.UUID) {
+	res, err := h.svc.ListAPIKeys(r.Context(), coreapikey.ListAPIKeysReq{UserID: userID})
+	if err != nil {
+		httpio.WriteError(w, err)
+		return
+	}
+	resp := toListAPIKeysResp(res.APIKeys)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(resp)
+}
+
+func (h *Handler) revokeAPIKey(w http.ResponseWriter, r *http.Request, userID uuid.UUID, rawKeyID string) {
+	keyID, err := uuid.Parse(rawKeyID)
+	if err != nil {
+		httpio.InvalidField(w, "id", "invalid api key id")
+		return
+	}
+	err = h.svc.RevokeAPIKey(r.Context(), coreapikey.RevokeAPIKeyReq{ID: keyID, UserID: userID})
+	if err != nil {
+		httpio.WriteError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// sessionClaims returns the caller's claims, refusing requests made with an API key
+// so that a leaked key cannot be used to mint further keys, and requests made while
+// impersonating so that no key outlives the impersonation
+func sessionClaims(w http.ResponseWriter, r *http.Request) (*utils.UserClaims, bool) {
+	claims, ok := auth.GetClaimsFromContext(r.Context())
+	if !ok {
+		httpio.Error(w, http.StatusUnauthorized, httpio.CodeUnauthorized, "authentication required")
+		return nil, false
+	}
+	if claims.APIKeyID != "" {
+		httpio.Error(w, http.StatusForbidden, httpio.CodeSessionRequired, "api keys cannot manage api keys, log in instead")
+		return nil, false
+	}
+	if !auth.NotImpersonating(w, claims) {
+		return nil, false
+	}
+	return claims, true
+}
+
+func toListAPIKeysResp(keys []domainapikey.APIKey) listAPIKeysResp {
+	infos := make([]apiKeyInfoResp, 0, len(keys))
+	for _, k := range keys {
+		infos = append(infos, apiKeyInfoResp{
+			ID:         k.ID,
+			Name:       k.Name,
+			Prefix:     k.Prefix,
+			Scopes:     k.Scopes.Strings(),
+			CreatedAt:  k.CreatedAt,
+			ExpiresAt:  k.ExpiresAt,
+			LastUsedAt: k.LastUsedAt,
+			RevokedAt:  k.RevokedAt,
+		})
+	}
+	return listAPIKeysResp{APIKeys: infos}
+}
@@ -0,0 +1,140 @@
+package apikey
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/frostnzx/go-ecommerce-api/internal/adapters/primary/api/auth"
+	"github.com/frostnzx/go-ecommerce-api/internal/adapters/secondary/memory"
+	domainapikey "github.com/frostnzx/go-ecommerce-api/internal/core/domain/apikey"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/audit"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/user"
+	coreapikey "github.com/frostnzx/go-ecommerce-api/internal/core/services/apikey"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/utils"
+	"github.com/google/uuid"
+)
+
+type fixture struct {
+	svc      *coreapikey.Service
+	auditLog *memory.AuditRepo
+	user     user.User
+}
+
+func newFixture(t *testing.T) fixture {
+	t.Helper()
+	s := memory.NewStore()
+	users, _ := memory.NewUserRepo(s)
+	keys, _ := memory.NewAPIKeyRepo(s)
+	auditLog, _ := memory.NewAuditRepo(s)
+	u := user.New("ada@example.com", "hash", "Ada", false)
+	if err := users.Create(context.Background(), u); err != nil {
+		t.Fatal(err)
+	}
+	return fixture{svc: coreapikey.NewService(keys, users, auditLog), auditLog: auditLog, user: u}
+}
+
+// serve routes the request with the given caller in the context
+func (f fixture) serve(caller *utils.UserClaims, method, path, body string) *httptest.ResponseRecorder {
+	withCaller := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(w, r.WithContext(auth.SetClaimsInContext(r.Context(), caller)))
+		})
+	}
+	mux := http.NewServeMux()
+	New(f.svc, withCaller, withCaller).SetupRoutes(mux)
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestManagingAPIKeysRequiresASession(t *testing.T) {
+	f := newFixture(t)
+	key, err := f.svc.CreateAPIKey(context.Background(), coreapikey.CreateAPIKeyReq{UserID: f.user.ID, Name: "CI", Scopes: []string{"write"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Even a write key must not mint, list or revoke keys, or a leaked key could outlive its revocation
+	keyCaller := &utils.UserClaims{ID: f.user.ID, Email: f.user.Email, APIKeyID: key.ID.String(), Scopes: domainapikey.Scopes{domainapikey.ScopeWrite}}
+	sessionCaller := &utils.UserClaims{ID: f.user.ID, Email: f.user.Email, SessionID: uuid.NewString()}
+
+	requests := []struct {
+		method, path, body string
+		wantSession        int
+	}{
+		{http.MethodPost, "/users/me/api-keys", `{"name":"deploy","scopes":["read"]}`, http.StatusCreated},
+		{http.MethodGet, "/users/me/api-keys", "", http.StatusOK},
+		{http.MethodDelete, "/users/me/api-keys/" + key.ID.String(), "", http.StatusNoContent},
+	}
+	for _, req := range requests {
+		rec := f.serve(keyCaller, req.method, req.path, req.body)
+		if rec.Code != http.StatusForbidden || !strings.Contains(rec.Body.String(), "session_required") {
+			t.Errorf("%s %s with an api key = %d %s, want 403 session_required", req.method, req.path, rec.Code, rec.Body)
+		}
+	}
+	for _, req := range requests {
+		if rec := f.serve(sessionCaller, req.method, req.path, req.body); rec.Code != req.wantSession {
+			t.Errorf("%s %s with a session = %d, want %d", req.method, req.path, rec.Code, req.wantSession)
+		}
+	}
+}
+
+func TestRevokeMyAPIKeyIgnoresOtherUsersKeys(t *testing.T) {
+	f := newFixture(t)
+	key, err := f.svc.CreateAPIKey(context.Background(), coreapikey.CreateAPIKeyReq{UserID: f.user.ID, Name: "CI", Scopes: []string{"read"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	mallory := &utils.UserClaims{ID: uuid.New(), Email: "mallory@example.com", SessionID: uuid.NewString()}
+	if rec := f.serve(mallory, http.MethodDelete, "/users/me/api-keys/"+key.ID.String(), ""); rec.Code != http.StatusNotFound {
+		t.Fatalf("status = %d, want 404", rec.Code)
+	}
+	if _, err := f.svc.AuthenticateAPIKey(context.Background(), coreapikey.AuthenticateAPIKeyReq{Key: key.Key}); err != nil {
+		t.Fatalf("key revoked by another user: %v", err)
+	}
+}
+
+func TestManagingAPIKeysRefusesImpersonation(t *testing.T) {
+	f := newFixture(t)
+	key, err := f.svc.CreateAPIKey(context.Background(), coreapikey.CreateAPIKeyReq{UserID: f.user.ID, Name: "CI", Scopes: []string{"read"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	impersonated := &utils.UserClaims{ID: f.user.ID, Email: f.user.Email, SessionID: uuid.NewString(), ImpersonatorID: uuid.NewString()}
+
+	for _, req := range []struct{ method, path, body string }{
+		{http.MethodPost, "/users/me/api-keys", `{"name":"backdoor","scopes":["write"]}`},
+		{http.MethodGet, "/users/me/api-keys", ""},
+		{http.MethodDelete, "/users/me/api-keys/" + key.ID.String(), ""},
+	} {
+		rec := f.serve(impersonated, req.method, req.path, req.body)
+		if rec.Code != http.StatusForbidden || !strings.Contains(rec.Body.String(), "impersonation_not_allowed") {
+			t.Errorf("%s %s while impersonating = %d %s, want 403 impersonation_not_allowed", req.method, req.path, rec.Code, rec.Body)
+		}
+	}
+	list, err := f.svc.ListAPIKeys(context.Background(), coreapikey.ListAPIKeysReq{UserID: f.user.ID})
+	if err != nil || len(list.APIKeys) != 1 || list.APIKeys[0].RevokedAt != nil {
+		t.Fatalf("keys = %+v (%v), want only the original key, still active", list, err)
+	}
+}
+
+func TestAdminCreatedAPIKeysAreAudited(t *testing.T) {
+	f := newFixture(t)
+	admin := &utils.UserClaims{ID: uuid.New(), Email: "root@example.com", IsAdmin: true, SessionID: uuid.NewString()}
+
+	rec := f.serve(admin, http.MethodPost, "/admin/users/"+f.user.ID.String()+"/api-keys", `{"name":"support","scopes":["write"]}`)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("status = %d %s, want 201", rec.Code, rec.Body)
+	}
+	entries, err := f.auditLog.List(context.Background(), f.user.ID, 10, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].ActorID != admin.ID || entries[0].Action != audit.ActionCreateAPIKey {
+		t.Fatalf("audit log = %+v, want the key recorded against the admin", entries)
+	}
+}
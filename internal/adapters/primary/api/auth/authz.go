@@ -0,0 +1,50 @@
+package auth
+
+import (
+	"net/http"
+
+	"github.com/frostnzx/go-ecommerce-api/internal/adapters/primary/api/httpio"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/utils"
+	"github.com/google/uuid"
+)
+
+// TargetUser resolves the user a /users/... request acts on and checks the caller may act on it.
+// The literal "me" (or a route without {id}) means the caller; any other {id} must be the caller's
+// own ID unless the caller is an admin. On failure the error response is written and ok is false.
+func TargetUser(w http.ResponseWriter, r *http.Request) (claims *utils.UserClaims, id uuid.UUID, ok bool) {
+	claims, ok = GetClaimsFromContext(r.Context())
+	if !ok {
+		httpio.Error(w, http.StatusUnauthorized, httpio.CodeUnauthorized, "authentication required")
+		return nil, uuid.Nil, false
+	}
+
+	raw := r.PathValue("id")
+	if raw == "" || raw == "me" {
+		return claims, claims.ID, true
+	}
+	id, err := uuid.Parse(raw)
+	if err != nil {
+		httpio.InvalidField(w, "id", "invalid user id")
+		return nil, uuid.Nil, false
+	}
+	if !CanActOn(claims, id) {
+		httpio.Error(w, http.StatusForbidden, httpio.CodeForbidden, "not allowed to act on this user")
+		return nil, uuid.Nil, false
+	}
+	return claims, id, true
+}
+
+// NotImpersonating refuses requests made with an impersonation token, so support staff
+// acting as a customer cannot take the account over. On failure the error response is written.
+func NotImpersonating(w http.ResponseWriter, claims *utils.UserClaims) bool {
+	if claims.ImpersonatorID != "" {
+		httpio.Error(w, http.StatusForbidden, httpio.CodeImpersonating, "not allowed while impersonating a user")
+		return false
+	}
+	return true
+}
+
+// CanActOn reports whether the caller may read or change the given user's resources
+func CanActOn(claims *utils.UserClaims, userID uuid.UUID) bool {
+	return claims.ID == userID || claims.IsAdmin
+}
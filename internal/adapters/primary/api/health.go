@@ -0,0 +1,121 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// HealthCheck is one dependency /readyz verifies before the instance takes traffic
+type HealthCheck struct {
+	Name  string
+	Check func(ctx context.Context) error
+}
+
+const (
+	healthOK          = "ok"
+	healthUnavailable = "unavailable"
+)
+
+// HealthStatus is the body of /healthz and /readyz
+type HealthStatus struct {
+	Status string                 `json:"status" example:"ok"`
+	Checks map[string]CheckResult `json:"checks,omitempty"`
+}
+
+// CheckResult is the outcome of a single readiness check
+type CheckResult struct {
+	Status     string  `json:"status" example:"ok"`
+	DurationMS float64 `json:"duration_ms" example:"1.7"`
+	Error      string  `json:"error,omitempty"`
+}
+
+// livenessHandler godoc
+// @Summary      Liveness probe
+// @Description  Reports that the process is up and serving; it checks no dependencies
+// @Tags         Health
+// @Produce      json
+// @Success      200 {object} api.HealthStatus
+// @Router       /healthz [get]
+func livenessHandler(w http.ResponseWriter, r *http.Request) {
+	writeHealth(w, http.StatusOK, HealthStatus{Status: healthOK})
+}
+
+// readinessHandler godoc
+// @Summary      Readiness probe
+// @Description  Runs every dependency check concurrently, each under its own timeout, and reports the per-check results.
+// @Description  Fails with 503 when any check fails or once the server has started shutting down.
+// @Tags         Health
+// @Produce      json
+// @Success      200 {object} api.HealthStatus
+// @Failure      503 {object} api.HealthStatus
+// @Router       /readyz [get]
+func readinessHandler(checks []HealthCheck, timeout time.Duration, shuttingDown *atomic.Bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		status := HealthStatus{Status: healthOK, Checks: make(map[string]CheckResult, len(checks))}
+		if shuttingDown.Load() {
+			status.Status = healthUnavailable
+			status.Checks["shutdown"] = CheckResult{Status: healthUnavailable, Error: "server is shutting down"}
+			writeHealth(w, http.StatusServiceUnavailable, status)
+			return
+		}
+
+		var (
+			mu sync.Mutex
+			wg sync.WaitGroup
+		)
+		for _, c := range checks {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				res := runCheck(r.Context(), c, timeout)
+				mu.Lock()
+				status.Checks[c.Name] = res
+				mu.Unlock()
+			}()
+		}
+		wg.Wait()
+
+		code := http.StatusOK
+		for _, res := range status.Checks {
+			if res.Status != healthOK {
+				status.Status = healthUnavailable
+				code = http.StatusServiceUnavailable
+			}
+		}
+		writeHealth(w, code, status)
+	}
+}
+
+// runCheck runs c under timeout. A check that ignores its context is abandoned
+// when the timeout passes rather than holding up the probe.
+func runCheck(ctx context.Context, c HealthCheck, timeout time.Duration) CheckResult {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	start := time.Now()
+	done := make(chan error, 1)
+	go func() { done <- c.Check(ctx) }()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+	res := CheckResult{Status: healthOK, DurationMS: float64(time.Since(start).Microseconds()) / 1000}
+	if err != nil {
+		res.Status = healthUnavailable
+		res.Error = err.Error()
+	}
+	return res
+}
+
+func writeHealth(w http.ResponseWriter, code int, status HealthStatus) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(status)
+}
@@ -0,0 +1,130 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/frostnzx/go-ecommerce-api/internal/config"
+)
+
+func probe(t *testing.T, h http.Handler, path string) (int, HealthStatus) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+	var body HealthStatus
+	if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+		t.Fatalf("decoding %s response: %v", path, err)
+	}
+	return rec.Code, body
+}
+
+func ok(context.Context) error { return nil }
+
+func TestLivenessChecksNothing(t *testing.T) {
+	code, body := probe(t, http.HandlerFunc(livenessHandler), "/healthz")
+	if code != http.StatusOK || body.Status != healthOK {
+		t.Errorf("GET /healthz = %d %q, want 200 ok", code, body.Status)
+	}
+}
+
+func TestReadinessReportsEveryCheck(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	checks := []HealthCheck{
+		{Name: "database", Check: ok},
+		{Name: "workers", Check: func(context.Context) error { return errors.New("session-purge failing") }},
+		{Name: "slow", Check: func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		}},
+		{Name: "stuck", Check: func(context.Context) error {
+			<-release // ignores its context
+			return nil
+		}},
+	}
+	h := readinessHandler(checks, 20*time.Millisecond, &atomic.Bool{})
+
+	start := time.Now()
+	code, body := probe(t, h, "/readyz")
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("probe took %v, want the timeout to bound it", elapsed)
+	}
+	if code != http.StatusServiceUnavailable || body.Status != healthUnavailable {
+		t.Errorf("GET /readyz = %d %q, want 503 unavailable", code, body.Status)
+	}
+	want := map[string]string{"database": healthOK, "workers": healthUnavailable, "slow": healthUnavailable, "stuck": healthUnavailable}
+	for name, status := range want {
+		res, found := body.Checks[name]
+		if !found {
+			t.Errorf("check %q missing from response", name)
+			continue
+		}
+		if res.Status != status {
+			t.Errorf("check %q = %q, want %q", name, res.Status, status)
+		}
+		if status != healthOK && res.Error == "" {
+			t.Errorf("check %q failed without an error message", name)
+		}
+	}
+}
+
+func TestReadinessPassesWhenAllChecksPass(t *testing.T) {
+	h := readinessHandler([]HealthCheck{{Name: "database", Check: ok}}, time.Second, &atomic.Bool{})
+	code, body := probe(t, h, "/readyz")
+	if code != http.StatusOK || body.Status != healthOK || body.Checks["database"].Status != healthOK {
+		t.Errorf("GET /readyz = %d %+v, want 200 with database ok", code, body)
+	}
+}
+
+func TestReadinessFailsWhileShuttingDown(t *testing.T) {
+	cfg := config.Default().Server
+	cfg.DrainDelay = config.Duration{Duration: 200 * time.Millisecond}
+	app := NewApp(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, cfg, slog.New(slog.DiscardHandler), nil, nil, HealthCheck{Name: "database", Check: ok})
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	app.server.Addr = ln.Addr().String()
+	ln.Close()
+	url := "http://" + app.server.Addr + "/readyz"
+
+	ctx, cancel := context.WithCancel(context.Background())
+	runErr := make(chan error, 1)
+	go func() { runErr <- app.Run(ctx) }()
+
+	status := func() int {
+		resp, err := http.Get(url)
+		if err != nil {
+			return 0
+		}
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+	var code int
+	for i := 0; i < 100 && code == 0; i++ {
+		if code = status(); code == 0 {
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+	if code != http.StatusOK {
+		t.Fatalf("GET /readyz = %d before shutdown, want 200", code)
+	}
+
+	cancel()
+	time.Sleep(20 * time.Millisecond)
+	if code := status(); code != http.StatusServiceUnavailable {
+		t.Errorf("GET /readyz = %d during the drain delay, want 503", code)
+	}
+	if err := <-runErr; err != nil {
+		t.Errorf("Run = %v, want a clean shutdown", err)
+	}
+}
@@ -0,0 +1,113 @@
+package httpio
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+)
+
+type clientIPKey struct{}
+
+// ClientIPMiddleware resolves the client IP once per request. The Forwarded and
+// X-Forwarded-For headers are only read when the connection comes from a trusted
+// proxy, and then from the right: each trusted proxy appends the address it saw,
+// so the first untrusted hop is the client and anything left of it may be forged.
+func ClientIPMiddleware(trusted []netip.Prefix) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := context.WithValue(r.Context(), clientIPKey{}, resolveClientIP(r, trusted))
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// ClientIP returns the IP address resolved by ClientIPMiddleware, or the address
+// of the connection that sent the request when the middleware did not run
+func ClientIP(r *http.Request) string {
+	if ip, ok := r.Context().Value(clientIPKey{}).(string); ok {
+		return ip
+	}
+	return remoteIP(r)
+}
+
+func remoteIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+func resolveClientIP(r *http.Request, trusted []netip.Prefix) string {
+	peer, ok := parseHop(remoteIP(r))
+	if !ok || !isTrusted(peer, trusted) {
+		return remoteIP(r)
+	}
+	client := peer
+	hops := forwardedFor(r.Header)
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop, ok := parseHop(hops[i])
+		if !ok {
+			// An obfuscated or malformed hop hides everything left of it
+			break
+		}
+		client = hop
+		if !isTrusted(hop, trusted) {
+			break
+		}
+	}
+	return client.String()
+}
+
+func isTrusted(addr netip.Addr, trusted []netip.Prefix) bool {
+	for _, p := range trusted {
+		if p.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// forwardedFor lists the client addresses recorded by proxies, oldest first.
+// The standard Forwarded header wins over X-Forwarded-For when both are present.
+func forwardedFor(h http.Header) []string {
+	var hops []string
+	if values := h.Values("Forwarded"); len(values) > 0 {
+		for _, v := range values {
+			for _, element := range strings.Split(v, ",") {
+				for _, pair := range strings.Split(element, ";") {
+					key, value, _ := strings.Cut(strings.TrimSpace(pair), "=")
+					if strings.EqualFold(key, "for") {
+						hops = append(hops, strings.Trim(value, `"`))
+					}
+				}
+			}
+		}
+		return hops
+	}
+	for _, v := range h.Values("X-Forwarded-For") {
+		for _, hop := range strings.Split(v, ",") {
+			hops = append(hops, strings.TrimSpace(hop))
+		}
+	}
+	return hops
+}
+
+// parseHop accepts "192.0.2.1", "192.0.2.1:4711", "2001:db8::1" and "[2001:db8::1]:4711"
+func parseHop(hop string) (netip.Addr, bool) {
+	if addr, err := netip.ParseAddr(hop); err == nil {
+		return addr.Unmap(), true
+	}
+	if host, _, err := net.SplitHostPort(hop); err == nil {
+		hop = host
+	} else {
+		hop = strings.TrimSuffix(strings.TrimPrefix(hop, "["), "]")
+	}
+	addr, err := netip.ParseAddr(hop)
+	if err != nil {
+		return netip.Addr{}, false
+	}
+	return addr.Unmap(), true
+}
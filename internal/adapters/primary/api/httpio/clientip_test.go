@@ -0,0 +1,44 @@
+package httpio
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+)
+
+func TestClientIPTrustsForwardingHeadersOnlyFromProxies(t *testing.T) {
+	trusted := []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8"), netip.MustParsePrefix("2001:db8:ffff::/48")}
+	tests := []struct {
+		name       string
+		remoteAddr string
+		headers    map[string]string
+		want       string
+	}{
+		{"direct client", "198.51.100.7:1234", nil, "198.51.100.7"},
+		{"spoofed header from an untrusted peer", "198.51.100.7:1234", map[string]string{"X-Forwarded-For": "203.0.113.1"}, "198.51.100.7"},
+		{"trusted proxy", "10.0.0.2:1234", map[string]string{"X-Forwarded-For": "203.0.113.1"}, "203.0.113.1"},
+		{"forged hops left of the client are ignored", "10.0.0.2:1234", map[string]string{"X-Forwarded-For": "192.0.2.99, 203.0.113.1, 10.0.0.3"}, "203.0.113.1"},
+		{"trusted proxy without the header", "10.0.0.2:1234", nil, "10.0.0.2"},
+		{"malformed hop", "10.0.0.2:1234", map[string]string{"X-Forwarded-For": "203.0.113.1, garbage"}, "10.0.0.2"},
+		{"forwarded header", "10.0.0.2:1234", map[string]string{
+			"Forwarded":       `for=192.0.2.99, for="[2001:db8::17]:4711";proto=https, For=10.0.0.3`,
+			"X-Forwarded-For": "192.0.2.50",
+		}, "2001:db8::17"},
+		{"ipv6 proxy", "[2001:db8:ffff::1]:443", map[string]string{"X-Forwarded-For": "203.0.113.1:5555"}, "203.0.113.1"},
+	}
+	for _, tt := range tests {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.RemoteAddr = tt.remoteAddr
+		for k, v := range tt.headers {
+			r.Header.Set(k, v)
+		}
+		var got string
+		ClientIPMiddleware(trusted)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			got = ClientIP(r)
+		})).ServeHTTP(httptest.NewRecorder(), r)
+		if got != tt.want {
+			t.Errorf("%s: ClientIP = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
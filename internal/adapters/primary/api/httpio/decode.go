@@ -0,0 +1,83 @@
+package httpio
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/frostnzx/go-ecommerce-api/internal/core/validation"
+)
+
+// MaxBodyBytes caps the size of JSON request bodies
+const MaxBodyBytes = 1 << 20
+
+// DecodeJSON reads the request body into dst and validates it. Unknown fields,
+// trailing data and bodies over MaxBodyBytes are rejected. On failure the problem
+// is written to w and false is returned.
+func DecodeJSON(w http.ResponseWriter, r *http.Request, dst any) bool {
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, MaxBodyBytes))
+	dec.DisallowUnknownFields()
+	err := dec.Decode(dst)
+	if err == nil {
+		if _, extra := dec.Token(); extra != io.EOF {
+			err = errors.New("request body must contain a single JSON value")
+		}
+	}
+	if err != nil {
+		WriteProblem(w, decodeProblem(err))
+		return false
+	}
+	if err := validation.Struct(dst); err != nil {
+		WriteError(w, err)
+		return false
+	}
+	return true
+}
+
+func decodeProblem(err error) *Problem {
+	var (
+		syntaxErr *json.SyntaxError
+		typeErr   *json.UnmarshalTypeError
+		maxErr    *http.MaxBytesError
+	)
+	switch {
+	case errors.As(err, &maxErr):
+		return NewProblem(http.StatusRequestEntityTooLarge, "request_too_large", fmt.Sprintf("request body must not exceed %d bytes", maxErr.Limit))
+	case errors.As(err, &syntaxErr):
+		return NewProblem(http.StatusBadRequest, CodeInvalidRequest, fmt.Sprintf("malformed JSON at offset %d", syntaxErr.Offset))
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		return NewProblem(http.StatusBadRequest, CodeInvalidRequest, "malformed JSON")
+	case errors.Is(err, io.EOF):
+		return NewProblem(http.StatusBadRequest, CodeInvalidRequest, "request body must not be empty")
+	case errors.As(err, &typeErr):
+		field := typeErr.Field
+		if field == "" {
+			field = "body"
+		}
+		return Invalid(FieldError{Field: field, Code: "invalid_type", Message: "must be a " + jsonType(typeErr.Type.Kind().String())})
+	case strings.HasPrefix(err.Error(), "json: unknown field "):
+		// encoding/json has no typed error for unknown fields
+		field := strings.Trim(strings.TrimPrefix(err.Error(), "json: unknown field "), `"`)
+		return Invalid(FieldError{Field: field, Code: "unknown_field", Message: "is not a recognised field"})
+	default:
+		return NewProblem(http.StatusBadRequest, CodeInvalidRequest, err.Error())
+	}
+}
+
+func jsonType(kind string) string {
+	switch {
+	case strings.HasPrefix(kind, "int"), strings.HasPrefix(kind, "uint"), strings.HasPrefix(kind, "float"):
+		return "number"
+	case kind == "bool":
+		return "boolean"
+	case kind == "slice", kind == "array":
+		return "array"
+	case kind == "struct", kind == "map":
+		return "object"
+	default:
+		return kind
+	}
+}
@@ -0,0 +1,78 @@
+package httpio
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type decodeTarget struct {
+	Name     string `json:"name" validate:"notblank,max=10"`
+	Password string `json:"password" validate:"required,password"`
+	Quantity int    `json:"quantity" validate:"min=1"`
+}
+
+func decode(t *testing.T, body string) (bool, *httptest.ResponseRecorder, Problem) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	var dst decodeTarget
+	ok := DecodeJSON(rec, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body)), &dst)
+	var p Problem
+	if !ok {
+		if err := json.NewDecoder(rec.Body).Decode(&p); err != nil {
+			t.Fatalf("decoding problem: %v", err)
+		}
+	}
+	return ok, rec, p
+}
+
+func TestDecodeJSONAcceptsValidBody(t *testing.T) {
+	if ok, rec, p := decode(t, `{"name":"widget","password":"passw0rd!","quantity":2}`); !ok {
+		t.Fatalf("status %d: %+v", rec.Code, p)
+	}
+}
+
+func TestDecodeJSONRejectsBadBodies(t *testing.T) {
+	tests := []struct {
+		name, body string
+		status     int
+		code       string
+		fieldCode  string
+	}{
+		{"empty", ``, http.StatusBadRequest, CodeInvalidRequest, ""},
+		{"malformed", `{"name":`, http.StatusBadRequest, CodeInvalidRequest, ""},
+		{"trailing data", `{"name":"a","password":"passw0rd!","quantity":1} {}`, http.StatusBadRequest, CodeInvalidRequest, ""},
+		{"unknown field", `{"name":"a","password":"passw0rd!","quantity":1,"is_admin":true}`, http.StatusBadRequest, CodeValidationFailed, "unknown_field"},
+		{"wrong type", `{"name":"a","password":"passw0rd!","quantity":"2"}`, http.StatusBadRequest, CodeValidationFailed, "invalid_type"},
+		{"too large", `{"name":"` + strings.Repeat("a", MaxBodyBytes) + `"}`, http.StatusRequestEntityTooLarge, "request_too_large", ""},
+	}
+	for _, tt := range tests {
+		ok, rec, p := decode(t, tt.body)
+		if ok {
+			t.Errorf("%s: accepted", tt.name)
+			continue
+		}
+		if rec.Code != tt.status || p.Code != tt.code {
+			t.Errorf("%s: got %d %q, want %d %q", tt.name, rec.Code, p.Code, tt.status, tt.code)
+		}
+		if tt.fieldCode != "" && (len(p.Errors) != 1 || p.Errors[0].Code != tt.fieldCode) {
+			t.Errorf("%s: errors = %+v, want one %q", tt.name, p.Errors, tt.fieldCode)
+		}
+	}
+}
+
+func TestDecodeJSONReportsEveryViolation(t *testing.T) {
+	_, _, p := decode(t, `{"name":"  ","password":"short","quantity":0}`)
+	got := map[string]string{}
+	for _, fe := range p.Errors {
+		got[fe.Field] = fe.Code
+	}
+	want := map[string]string{"name": "required", "password": "weak_password", "quantity": "out_of_range"}
+	for field, code := range want {
+		if got[field] != code {
+			t.Errorf("field %s: code %q, want %q (all errors: %+v)", field, got[field], code, p.Errors)
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package httpio
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/frostnzx/go-ecommerce-api/internal/core/services/address"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/services/apikey"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/services/idempotency"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/services/items"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/services/order"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/services/privacy"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/services/product"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/services/session"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/services/user"
+	"github.com/frostnzx/go-ecommerce-api/internal/ports"
+)
+
+// errorMapping translates one domain sentinel error. Entries with a field are
+// reported as validation problems with code as the field-level code.
+type errorMapping struct {
+	err    error
+	status int
+	code   string
+	field  string
+}
+
+// errorTable lists every sentinel error the services return to handlers.
+// Codes are part of the API contract: add new ones freely, never rename them.
+var errorTable = []errorMapping{
+	// ports
+	{err: ports.ErrUserNotFound, status: http.StatusNotFound, code: "user_not_found"},
+	{err: ports.ErrAPIKeyNotFound, status: http.StatusNotFound, code: "api_key_not_found"},
+	{err: ports.ErrRefreshTokenConsumed, status: http.StatusUnauthorized, code: "refresh_token_reused"},
+
+	// user
+	{err: user.ErrInvalidCredentials, status: http.StatusUnauthorized, code: "invalid_credentials"},
+	{err: user.ErrTooManyAttempts, status: http.StatusTooManyRequests, code: "too_many_attempts"},
+	{err: user.ErrAccountSuspended, status: http.StatusForbidden, code: "account_suspended"},
+	{err: user.ErrIncorrectPassword, status: http.StatusForbidden, code: "incorrect_password"},
+	{err: user.ErrInvalidRefreshToken, status: http.StatusUnauthorized, code: "invalid_refresh_token"},
+	{err: user.ErrRefreshTokenReused, status: http.StatusUnauthorized, code: "refresh_token_reused"},
+	{err: user.ErrSessionRevoked, status: http.StatusUnauthorized, code: "session_revoked"},
+	{err: user.ErrSessionExpired, status: http.StatusUnauthorized, code: "session_expired"},
+	{err: user.ErrCannotSuspendSelf, status: http.StatusForbidden, code: "cannot_suspend_self"},
+	{err: user.ErrCannotImpersonateAdmin, status: http.StatusForbidden, code: "cannot_impersonate_admin"},
+	{err: user.ErrReasonRequired, status: http.StatusBadRequest, code: "required", field: "reason"},
+	{err: user.ErrUnknownProvider, status: http.StatusNotFound, code: "unknown_provider"},
+	{err: user.ErrOIDCLoginNotAvailable, status: http.StatusNotFound, code: "social_login_unavailable"},
+	{err: user.ErrInvalidOIDCState, status: http.StatusBadRequest, code: "invalid_login_state"},
+	{err: user.ErrOIDCLoginFailed, status: http.StatusUnauthorized, code: "social_login_failed"},
+	{err: user.ErrOIDCEmailNotVerified, status: http.StatusForbidden, code: "email_not_verified"},
+	{err: user.ErrEmailTaken, status: http.StatusConflict, code: "email_taken", field: "email"},
+
+	// session
+	{err: session.ErrSessionNotActive, status: http.StatusUnauthorized, code: "session_not_active"},
+	{err: session.ErrSessionNotFound, status: http.StatusNotFound, code: "session_not_found"},
+
+	// apikey
+	{err: apikey.ErrInvalidAPIKey, status: http.StatusUnauthorized, code: "invalid_api_key"},
+	{err: apikey.ErrAccountSuspended, status: http.StatusForbidden, code: "account_suspended"},
+	{err: apikey.ErrAdminScopeDenied, status: http.StatusForbidden, code: "admin_scope_denied"},
+	{err: apikey.ErrInvalidName, status: http.StatusBadRequest, code: "required", field: "name"},
+	{err: apikey.ErrInvalidScopes, status: http.StatusBadRequest, code: "invalid", field: "scopes"},
+	{err: apikey.ErrInvalidExpiration, status: http.StatusBadRequest, code: "out_of_range", field: "expires_in_days"},
+
+	// idempotency
+	{err: idempotency.ErrKeyReused, status: http.StatusUnprocessableEntity, code: "idempotency_key_reused"},
+	{err: idempotency.ErrRequestInProgress, status: http.StatusConflict, code: "idempotency_key_in_use"},
+
+	// privacy
+	{err: privacy.ErrAlreadyErased, status: http.StatusConflict, code: "already_erased"},
+
+	// address
+	{err: address.ErrAddressNotFound, status: http.StatusNotFound, code: "address_not_found"},
+	{err: address.ErrNotAddressOwner, status: http.StatusForbidden, code: "not_address_owner"},
+	{err: address.ErrAddressInUse, status: http.StatusConflict, code: "address_in_use"},
+
+	// product
+	{err: product.ErrProductNotFound, status: http.StatusNotFound, code: "product_not_found"},
+	{err: product.ErrNegativeStock, status: http.StatusConflict, code: "insufficient_stock"},
+	{err: product.ErrDuplicateSKU, status: http.StatusBadRequest, code: "invalid", field: "products"},
+	{err: product.ErrSKUTaken, status: http.StatusConflict, code: "sku_taken", field: "sku"},
+	{err: product.ErrProductInUse, status: http.StatusConflict, code: "product_in_use"},
+
+	// order
+	{err: order.ErrOrderNotFound, status: http.StatusNotFound, code: "order_not_found"},
+	{err: order.ErrNotOrderOwner, status: http.StatusForbidden, code: "not_order_owner"},
+	{err: order.ErrInvalidStatus, status: http.StatusBadRequest, code: "invalid", field: "status"},
+	{err: order.ErrCannotCancelOrder, status: http.StatusConflict, code: "order_not_cancellable"},
+	{err: order.ErrInsufficientStock, status: http.StatusConflict, code: "insufficient_stock"},
+	{err: order.ErrProductNotFound, status: http.StatusUnprocessableEntity, code: "product_not_found", field: "items.product_id"},
+	{err: order.ErrAddressNotFound, status: http.StatusUnprocessableEntity, code: "address_not_found", field: "address_id"},
+
+	// items
+	{err: items.ErrOrderNotFound, status: http.StatusNotFound, code: "order_not_found"},
+	{err: items.ErrNotOrderOwner, status: http.StatusForbidden, code: "not_order_owner"},
+	{err: items.ErrItemNotFound, status: http.StatusNotFound, code: "item_not_found"},
+	{err: items.ErrProductNotFound, status: http.StatusUnprocessableEntity, code: "product_not_found", field: "product_id"},
+}
+
+// lookupError finds the problem for a known domain error. The detail is the
+// sentinel's own message so wrapped internals are never echoed back.
+func lookupError(err error) (*Problem, bool) {
+	for _, m := range errorTable {
+		if !errors.Is(err, m.err) {
+			continue
+		}
+		if m.field == "" {
+			return NewProblem(m.status, m.code, m.err.Error()), true
+		}
+		p := Invalid(FieldError{Field: m.field, Code: m.code, Message: m.err.Error()})
+		p.Status = m.status
+		if m.status != http.StatusBadRequest {
+			p.Code = m.code
+		}
+		p.Detail = m.err.Error()
+		return p, true
+	}
+	return nil, false
+}
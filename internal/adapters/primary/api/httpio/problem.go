@@ -0,0 +1,126 @@
+package httpio
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/frostnzx/go-ecommerce-api/internal/core/validation"
+)
+
+// Stable codes for problems raised by the HTTP layer itself; domain errors get theirs from the error table
+const (
+	CodeInvalidRequest   = "invalid_request"
+	CodeValidationFailed = "validation_failed"
+	CodeUnauthorized     = "unauthorized"
+	CodeForbidden        = "forbidden"
+	CodeSessionRequired  = "session_required"
+	CodeImpersonating    = "impersonation_not_allowed"
+	CodeRateLimited      = "rate_limited"
+	CodeInternal         = "internal_error"
+)
+
+// Problem is an RFC 7807 problem details object. Code is a stable, machine-readable
+// identifier clients can switch on; Detail is meant for humans and may change.
+type Problem struct {
+	Type   string       `json:"type"`
+	Title  string       `json:"title"`
+	Status int          `json:"status"`
+	Detail string       `json:"detail,omitempty"`
+	Code   string       `json:"code"`
+	Errors []FieldError `json:"errors,omitempty"`
+}
+
+// FieldError describes one invalid field of a request
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// NewProblem returns a problem that can be passed around as an error and written with WriteError
+func NewProblem(status int, code, detail string) *Problem {
+	return &Problem{Status: status, Code: code, Detail: detail}
+}
+
+// Invalid returns a validation problem listing the given fields
+func Invalid(fields ...FieldError) *Problem {
+	return &Problem{
+		Status: http.StatusBadRequest,
+		Code:   CodeValidationFailed,
+		Detail: "the request has invalid fields",
+		Errors: fields,
+	}
+}
+
+func (p *Problem) Error() string {
+	return p.Detail
+}
+
+// Error writes a problem response, in the spirit of http.Error
+func Error(w http.ResponseWriter, status int, code, detail string) {
+	WriteProblem(w, NewProblem(status, code, detail))
+}
+
+// InvalidField writes a validation problem for a single malformed field, path or query parameter
+func InvalidField(w http.ResponseWriter, field, detail string) {
+	WriteProblem(w, Invalid(FieldError{Field: field, Code: "invalid", Message: detail}))
+}
+
+// ErrorRecorder is implemented by response writers that want the error behind a
+// problem response, such as the access logger
+type ErrorRecorder interface {
+	RecordError(err error)
+}
+
+// WriteError translates err into a problem response. Problems are written as they are,
+// known domain errors get their status and code from the error table, and anything else
+// is logged and reported as a bare 500 so internal details never reach the client.
+func WriteError(w http.ResponseWriter, err error) {
+	rec, recorded := w.(ErrorRecorder)
+	if recorded {
+		rec.RecordError(err)
+	}
+	var p *Problem
+	if errors.As(err, &p) {
+		WriteProblem(w, p)
+		return
+	}
+	var verr *validation.Error
+	if errors.As(err, &verr) {
+		WriteProblem(w, fromViolations(verr.Violations))
+		return
+	}
+	if p, ok := lookupError(err); ok {
+		WriteProblem(w, p)
+		return
+	}
+	if !recorded {
+		slog.Error("internal error", "error", err)
+	}
+	Error(w, http.StatusInternalServerError, CodeInternal, "internal server error")
+}
+
+func fromViolations(vs []validation.Violation) *Problem {
+	fields := make([]FieldError, 0, len(vs))
+	for _, v := range vs {
+		fields = append(fields, FieldError{Field: v.Field, Code: v.Code, Message: v.Message})
+	}
+	return Invalid(fields...)
+}
+
+// WriteProblem writes p as application/problem+json
+func WriteProblem(w http.ResponseWriter, p *Problem) {
+	resp := *p
+	if resp.Type == "" {
+		resp.Type = "about:blank"
+	}
+	if resp.Title == "" {
+		resp.Title = http.StatusText(resp.Status)
+	}
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(resp.Status)
+	json.NewEncoder(w).Encode(resp)
+}
@@ -0,0 +1,97 @@
+package httpio
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/frostnzx/go-ecommerce-api/internal/core/services/address"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/services/order"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/services/user"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/validation"
+	"github.com/frostnzx/go-ecommerce-api/internal/ports"
+)
+
+func writeErr(t *testing.T, err error) (*httptest.ResponseRecorder, Problem) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	WriteError(rec, err)
+	if ct := rec.Header().Get("Content-Type"); ct != "application/problem+json" {
+		t.Fatalf("Content-Type = %q, want application/problem+json", ct)
+	}
+	var p Problem
+	if err := json.NewDecoder(rec.Body).Decode(&p); err != nil {
+		t.Fatalf("decoding problem: %v", err)
+	}
+	return rec, p
+}
+
+func TestWriteErrorMapsWrappedSentinels(t *testing.T) {
+	tests := []struct {
+		err    error
+		status int
+		code   string
+	}{
+		{fmt.Errorf("loading user: %w", ports.ErrUserNotFound), http.StatusNotFound, "user_not_found"},
+		{order.ErrNotOrderOwner, http.StatusForbidden, "not_order_owner"},
+		{order.ErrCannotCancelOrder, http.StatusConflict, "order_not_cancellable"},
+	}
+	for _, tt := range tests {
+		rec, p := writeErr(t, tt.err)
+		if rec.Code != tt.status || p.Status != tt.status || p.Code != tt.code {
+			t.Errorf("%v: got %d/%d %q, want %d %q", tt.err, rec.Code, p.Status, p.Code, tt.status, tt.code)
+		}
+		if p.Type != "about:blank" || p.Title != http.StatusText(tt.status) {
+			t.Errorf("%v: type %q title %q", tt.err, p.Type, p.Title)
+		}
+	}
+}
+
+func TestWriteErrorReportsFieldErrors(t *testing.T) {
+	_, p := writeErr(t, user.ErrReasonRequired)
+	if p.Status != http.StatusBadRequest || p.Code != CodeValidationFailed {
+		t.Fatalf("got %d %q, want 400 %q", p.Status, p.Code, CodeValidationFailed)
+	}
+	if len(p.Errors) != 1 || p.Errors[0].Field != "reason" || p.Errors[0].Code != "required" {
+		t.Errorf("errors = %+v, want one required error on reason", p.Errors)
+	}
+}
+
+func TestWriteErrorReportsAllViolations(t *testing.T) {
+	err := validation.Struct(address.AddAddressReq{Province: "Bangkok"})
+	_, p := writeErr(t, fmt.Errorf("adding address: %w", err))
+	if p.Status != http.StatusBadRequest || p.Code != CodeValidationFailed {
+		t.Fatalf("got %d %q, want 400 %q", p.Status, p.Code, CodeValidationFailed)
+	}
+	got := map[string]string{}
+	for _, fe := range p.Errors {
+		got[fe.Field] = fe.Code
+	}
+	for _, field := range []string{"user_id", "line1", "city", "postal_code", "country"} {
+		if got[field] != "required" {
+			t.Errorf("field %s: code %q, want required (all errors: %+v)", field, got[field], p.Errors)
+		}
+	}
+}
+
+func TestWriteErrorHidesInternalErrors(t *testing.T) {
+	rec, p := writeErr(t, errors.New("pq: connection refused to 10.0.0.5"))
+	if rec.Code != http.StatusInternalServerError || p.Code != CodeInternal {
+		t.Fatalf("got %d %q, want 500 %q", rec.Code, p.Code, CodeInternal)
+	}
+	if strings.Contains(p.Detail, "pq") {
+		t.Errorf("detail leaks the internal error: %q", p.Detail)
+	}
+}
+
+func TestWriteErrorPassesProblemsThrough(t *testing.T) {
+	want := Invalid(FieldError{Field: "limit", Code: "invalid", Message: "limit must be a non-negative integer"})
+	_, p := writeErr(t, fmt.Errorf("reading page: %w", want))
+	if p.Status != http.StatusBadRequest || len(p.Errors) != 1 || p.Errors[0].Field != "limit" {
+		t.Errorf("got %+v, want the wrapped problem", p)
+	}
+}
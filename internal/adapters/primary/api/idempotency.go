@@ -0,0 +1,126 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/frostnzx/go-ecommerce-api/internal/adapters/primary/api/auth"
+	"github.com/frostnzx/go-ecommerce-api/internal/adapters/primary/api/httpio"
+	domain "github.com/frostnzx/go-ecommerce-api/internal/core/domain/idempotency"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/services/idempotency"
+	"github.com/frostnzx/go-ecommerce-api/internal/logging"
+)
+
+const (
+	idempotencyKeyHeader     = "Idempotency-Key"
+	idempotentReplayedHeader = "Idempotent-Replayed"
+)
+
+// GetIdempotencyMiddlewareFunc makes a mutating route safe to retry. A request
+// carrying an Idempotency-Key runs once per user and key; retries of the same
+// request get the stored response back, while reusing the key for a different
+// request is rejected. Requests without the header are passed through. It must
+// run after the auth middleware, since keys are scoped to the caller.
+func GetIdempotencyMiddlewareFunc(idempotencyAPI idempotency.API) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get(idempotencyKeyHeader)
+			claims, ok := auth.GetClaimsFromContext(r.Context())
+			if key == "" || !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if !domain.ValidKey(key) {
+				httpio.Error(w, http.StatusBadRequest, httpio.CodeInvalidRequest, "Idempotency-Key must be 1 to 255 printable ASCII characters")
+				return
+			}
+
+			body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, httpio.MaxBodyBytes))
+			if err != nil {
+				var tooLarge *http.MaxBytesError
+				if errors.As(err, &tooLarge) {
+					httpio.Error(w, http.StatusRequestEntityTooLarge, httpio.CodeInvalidRequest, "request body is too large")
+					return
+				}
+				httpio.Error(w, http.StatusBadRequest, httpio.CodeInvalidRequest, "error reading request body")
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			res, err := idempotencyAPI.BeginRequest(r.Context(), idempotency.BeginRequestReq{
+				UserID:      claims.ID,
+				Key:         key,
+				Fingerprint: domain.Fingerprint(r.Method, r.URL.Path, body),
+			})
+			if err != nil {
+				httpio.WriteError(w, err)
+				return
+			}
+			if res.Replay != nil {
+				if res.Replay.ContentType != "" {
+					w.Header().Set("Content-Type", res.Replay.ContentType)
+				}
+				w.Header().Set(idempotentReplayedHeader, "true")
+				w.WriteHeader(res.Replay.StatusCode)
+				w.Write(res.Replay.Body)
+				return
+			}
+
+			rec := &responseCapture{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(rec, r)
+
+			// Finish the bookkeeping even if the client has gone away
+			ctx := context.WithoutCancel(r.Context())
+			if rec.status >= http.StatusInternalServerError {
+				err = idempotencyAPI.ReleaseKey(ctx, idempotency.ReleaseKeyReq{UserID: claims.ID, Key: key})
+			} else {
+				err = idempotencyAPI.CompleteRequest(ctx, idempotency.CompleteRequestReq{
+					UserID:      claims.ID,
+					Key:         key,
+					StatusCode:  rec.status,
+					ContentType: rec.Header().Get("Content-Type"),
+					Body:        rec.body.Bytes(),
+				})
+			}
+			if err != nil {
+				logging.FromContext(r.Context()).Warn("error storing idempotent response", "error", err)
+			}
+		})
+	}
+}
+
+// responseCapture keeps a copy of the response so it can be replayed
+type responseCapture struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+	body        bytes.Buffer
+}
+
+func (c *responseCapture) WriteHeader(status int) {
+	if !c.wroteHeader {
+		c.status = status
+		c.wroteHeader = true
+	}
+	c.ResponseWriter.WriteHeader(status)
+}
+
+func (c *responseCapture) Write(b []byte) (int, error) {
+	c.wroteHeader = true
+	c.body.Write(b)
+	return c.ResponseWriter.Write(b)
+}
+
+// RecordError implements httpio.ErrorRecorder for the recorders further out
+func (c *responseCapture) RecordError(err error) {
+	if inner, ok := c.ResponseWriter.(httpio.ErrorRecorder); ok {
+		inner.RecordError(err)
+	}
+}
+
+func (c *responseCapture) Unwrap() http.ResponseWriter {
+	return c.ResponseWriter
+}
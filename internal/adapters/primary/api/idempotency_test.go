@@ -0,0 +1,199 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/frostnzx/go-ecommerce-api/internal/adapters/primary/api/auth"
+	domain "github.com/frostnzx/go-ecommerce-api/internal/core/domain/idempotency"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/services/idempotency"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/utils"
+	"github.com/frostnzx/go-ecommerce-api/internal/ports"
+	"github.com/google/uuid"
+)
+
+// memoryIdempotencyRepo mirrors the semantics of the postgres repository
+type memoryIdempotencyRepo struct {
+	mu      sync.Mutex
+	records map[string]domain.Record
+}
+
+func recordKey(userID uuid.UUID, key string) string { return userID.String() + "|" + key }
+
+func (m *memoryIdempotencyRepo) CreateKey(ctx context.Context, r domain.Record, staleBefore time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if old, ok := m.records[recordKey(r.UserID, r.Key)]; ok {
+		expired := !old.ExpiresAt.After(r.CreatedAt)
+		abandoned := !old.Completed() && old.CreatedAt.Before(staleBefore)
+		if !expired && !abandoned {
+			return ports.ErrIdempotencyKeyExists
+		}
+	}
+	m.records[recordKey(r.UserID, r.Key)] = r
+	return nil
+}
+
+func (m *memoryIdempotencyRepo) GetKey(ctx context.Context, userID uuid.UUID, key string) (*domain.Record, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	r, ok := m.records[recordKey(userID, key)]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	return &r, nil
+}
+
+func (m *memoryIdempotencyRepo) CompleteKey(ctx context.Context, r domain.Record) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	old, ok := m.records[recordKey(r.UserID, r.Key)]
+	if ok && !old.Completed() {
+		old.StatusCode, old.ContentType, old.Body = r.StatusCode, r.ContentType, r.Body
+		m.records[recordKey(r.UserID, r.Key)] = old
+	}
+	return nil
+}
+
+func (m *memoryIdempotencyRepo) DeleteKey(ctx context.Context, userID uuid.UUID, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if r, ok := m.records[recordKey(userID, key)]; ok && !r.Completed() {
+		delete(m.records, recordKey(userID, key))
+	}
+	return nil
+}
+
+func (m *memoryIdempotencyRepo) DeleteExpiredKeys(ctx context.Context, before time.Time) (int64, error) {
+	return 0, nil
+}
+
+// idempotentOrders serves POST /orders behind the idempotency middleware as the given user,
+// numbering each order it actually places
+func idempotentOrders(userID uuid.UUID, handler http.HandlerFunc) http.Handler {
+	svc := idempotency.NewService(&memoryIdempotencyRepo{records: map[string]domain.Record{}}, time.Hour)
+	h := GetIdempotencyMiddlewareFunc(svc)(handler)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := auth.SetClaimsInContext(r.Context(), &utils.UserClaims{ID: userID})
+		h.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+func placeOrder(h http.Handler, key, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(body))
+	if key != "" {
+		req.Header.Set(idempotencyKeyHeader, key)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestIdempotencyReplaysTheOriginalResponse(t *testing.T) {
+	var placed atomic.Int32
+	h := idempotentOrders(uuid.New(), func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, `{"order":%d}`, placed.Add(1))
+	})
+
+	first := placeOrder(h, "k1", `{"items":[1]}`)
+	retry := placeOrder(h, "k1", `{"items":[1]}`)
+	if placed.Load() != 1 {
+		t.Fatalf("placed %d orders, want 1", placed.Load())
+	}
+	if retry.Code != http.StatusCreated || retry.Body.String() != first.Body.String() {
+		t.Errorf("retry = %d %s, want the original 201 %s", retry.Code, retry.Body, first.Body)
+	}
+	if retry.Header().Get(idempotentReplayedHeader) != "true" || retry.Header().Get("Content-Type") != "application/json" {
+		t.Errorf("retry headers = %v", retry.Header())
+	}
+	if first.Header().Get(idempotentReplayedHeader) != "" {
+		t.Error("the original response was marked as replayed")
+	}
+
+	if rec := placeOrder(h, "k2", `{"items":[1]}`); rec.Body.String() != `{"order":2}` {
+		t.Errorf("new key = %s, want a second order", rec.Body)
+	}
+	placeOrder(h, "", `{"items":[1]}`)
+	placeOrder(h, "", `{"items":[1]}`)
+	if placed.Load() != 4 {
+		t.Errorf("placed %d orders, want requests without a key to always run", placed.Load())
+	}
+}
+
+func TestIdempotencyRejectsKeyReuse(t *testing.T) {
+	h := idempotentOrders(uuid.New(), func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	})
+	placeOrder(h, "k1", `{"items":[1]}`)
+	rec := placeOrder(h, "k1", `{"items":[2]}`)
+	if rec.Code != http.StatusUnprocessableEntity || !strings.Contains(rec.Body.String(), "idempotency_key_reused") {
+		t.Errorf("different body = %d %s, want 422 idempotency_key_reused", rec.Code, rec.Body)
+	}
+	if rec := placeOrder(h, strings.Repeat("x", 256), `{}`); rec.Code != http.StatusBadRequest {
+		t.Errorf("overlong key = %d, want 400", rec.Code)
+	}
+}
+
+func TestIdempotencyReleasesKeyAfterServerError(t *testing.T) {
+	var calls atomic.Int32
+	h := idempotentOrders(uuid.New(), func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	})
+	placeOrder(h, "k1", `{}`)
+	if rec := placeOrder(h, "k1", `{}`); rec.Code != http.StatusCreated || calls.Load() != 2 {
+		t.Errorf("retry after 500 = %d after %d calls, want the request to run again", rec.Code, calls.Load())
+	}
+}
+
+func TestIdempotencyRejectsConcurrentDuplicate(t *testing.T) {
+	started, release := make(chan struct{}), make(chan struct{})
+	h := idempotentOrders(uuid.New(), func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+		w.WriteHeader(http.StatusCreated)
+	})
+	done := make(chan *httptest.ResponseRecorder)
+	go func() { done <- placeOrder(h, "k1", `{}`) }()
+	<-started
+
+	rec := placeOrder(h, "k1", `{}`)
+	if rec.Code != http.StatusConflict || !strings.Contains(rec.Body.String(), "idempotency_key_in_use") {
+		t.Errorf("duplicate in flight = %d %s, want 409 idempotency_key_in_use", rec.Code, rec.Body)
+	}
+	close(release)
+	if rec := <-done; rec.Code != http.StatusCreated {
+		t.Errorf("original = %d, want 201", rec.Code)
+	}
+}
+
+func TestIdempotencyKeysAreScopedToTheUser(t *testing.T) {
+	var placed atomic.Int32
+	svc := idempotency.NewService(&memoryIdempotencyRepo{records: map[string]domain.Record{}}, time.Hour)
+	mw := GetIdempotencyMiddlewareFunc(svc)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		placed.Add(1)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	for _, user := range []uuid.UUID{uuid.New(), uuid.New()} {
+		req := httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(`{}`))
+		req.Header.Set(idempotencyKeyHeader, "shared")
+		req = req.WithContext(auth.SetClaimsInContext(req.Context(), &utils.UserClaims{ID: user}))
+		mw.ServeHTTP(httptest.NewRecorder(), req)
+	}
+	if placed.Load() != 2 {
+		t.Errorf("placed %d orders, want one per user", placed.Load())
+	}
+}
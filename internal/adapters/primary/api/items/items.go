@@ -5,6 +5,7 @@ import (
 	"net/http"
 
 	"github.com/frostnzx/go-ecommerce-api/internal/adapters/primary/api/auth"
+	"github.com/frostnzx/go-ecommerce-api/internal/adapters/primary/api/httpio"
 	coreitems "github.com/frostnzx/go-ecommerce-api/internal/core/services/items"
 	"github.com/google/uuid"
 )
@@ -32,8 +33,8 @@ func (h *Handler) SetupRoutes(mux *http.ServeMux) {
 
 // DTOs
 type addItemReq struct {
-	ProductID string `json:"product_id"`
-	Quantity  int    `json:"quantity"`
+	ProductID string `json:"product_id" validate:"required,uuid"`
+	Quantity  int    `json:"quantity" validate:"min=1,max=1000"`
 }
 
 type addItemResp struct {
@@ -74,33 +75,33 @@ type getItemResp struct {
 // @Param        orderId path string true "Order ID"
 // @Param        request body addItemReq true "Item data"
 // @Success      201 {object} addItemResp
-// @Failure      400 {string} string "Invalid request"
-// @Failure      401 {string} string "Unauthorized"
+// @Failure      400 {object} httpio.Problem "Invalid request"
+// @Failure      401 {object} httpio.Problem "Unauthorized"
+// @Failure      403 {object} httpio.Problem "Not the order owner"
 // @Security     BearerAuth
 // @Router       /orders/{orderId}/items [post]
 func (h *Handler) AddItemHandler(w http.ResponseWriter, r *http.Request) {
-	_, ok := auth.GetClaimsFromContext(r.Context())
+	claims, ok := auth.GetClaimsFromContext(r.Context())
 	if !ok {
-		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		httpio.Error(w, http.StatusUnauthorized, httpio.CodeUnauthorized, "authentication required")
 		return
 	}
 
 	orderIDStr := r.PathValue("orderId")
 	orderID, err := uuid.Parse(orderIDStr)
 	if err != nil {
-		http.Error(w, "invalid order id", http.StatusBadRequest)
+		httpio.InvalidField(w, "orderId", "invalid order id")
 		return
 	}
 
 	var req addItemReq
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "invalid request", http.StatusBadRequest)
+	if !httpio.DecodeJSON(w, r, &req) {
 		return
 	}
 
 	productID, err := uuid.Parse(req.ProductID)
 	if err != nil {
-		http.Error(w, "invalid product id", http.StatusBadRequest)
+		httpio.InvalidField(w, "product_id", "invalid product id")
 		return
 	}
 
@@ -108,11 +109,12 @@ func (h *Handler) AddItemHandler(w http.ResponseWriter, r *http.Request) {
 		OrderID:   orderID,
 		ProductID: productID,
 		Quantity:  req.Quantity,
+		UserID:    claims.ID,
 	}
 
 	res, err := h.svc.AddItem(r.Context(), in)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		httpio.WriteError(w, err)
 		return
 	}
 
@@ -138,25 +140,41 @@ func (h *Handler) AddItemHandler(w http.ResponseWriter, r *http.Request) {
 // @Param        orderId path string true "Order ID"
 // @Param        id path string true "Item ID"
 // @Success      200 {object} getItemResp
-// @Failure      400 {string} string "Invalid request"
-// @Failure      404 {string} string "Not found"
+// @Failure      400 {object} httpio.Problem "Invalid request"
+// @Failure      401 {object} httpio.Problem "Unauthorized"
+// @Failure      403 {object} httpio.Problem "Not the order owner"
+// @Failure      404 {object} httpio.Problem "Not found"
 // @Security     BearerAuth
 // @Router       /orders/{orderId}/items/{id} [get]
 func (h *Handler) GetItemHandler(w http.ResponseWriter, r *http.Request) {
+	claims, ok := auth.GetClaimsFromContext(r.Context())
+	if !ok {
+		httpio.Error(w, http.StatusUnauthorized, httpio.CodeUnauthorized, "authentication required")
+		return
+	}
+
+	orderID, err := uuid.Parse(r.PathValue("orderId"))
+	if err != nil {
+		httpio.InvalidField(w, "orderId", "invalid order id")
+		return
+	}
+
 	itemIDStr := r.PathValue("id")
 	itemID, err := uuid.Parse(itemIDStr)
 	if err != nil {
-		http.Error(w, "invalid item id", http.StatusBadRequest)
+		httpio.InvalidField(w, "id", "invalid item id")
 		return
 	}
 
 	in := coreitems.GetItemReq{
-		ID: itemID,
+		ID:      itemID,
+		OrderID: orderID,
+		UserID:  claims.ID,
 	}
 
 	res, err := h.svc.GetItem(r.Context(), in)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusNotFound)
+		httpio.WriteError(w, err)
 		return
 	}
 
@@ -181,21 +199,21 @@ func (h *Handler) GetItemHandler(w http.ResponseWriter, r *http.Request) {
 // @Produce      json
 // @Param        orderId path string true "Order ID"
 // @Success      200 {object} listItemsResp
-// @Failure      400 {string} string "Invalid request"
-// @Failure      401 {string} string "Unauthorized"
+// @Failure      400 {object} httpio.Problem "Invalid request"
+// @Failure      401 {object} httpio.Problem "Unauthorized"
 // @Security     BearerAuth
 // @Router       /orders/{orderId}/items [get]
 func (h *Handler) ListItemsByOrderHandler(w http.ResponseWriter, r *http.Request) {
 	claims, ok := auth.GetClaimsFromContext(r.Context())
 	if !ok {
-		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		httpio.Error(w, http.StatusUnauthorized, httpio.CodeUnauthorized, "authentication required")
 		return
 	}
 
 	orderIDStr := r.PathValue("orderId")
 	orderID, err := uuid.Parse(orderIDStr)
 	if err != nil {
-		http.Error(w, "invalid order id", http.StatusBadRequest)
+		httpio.InvalidField(w, "orderId", "invalid order id")
 		return
 	}
 
@@ -206,7 +224,7 @@ func (h *Handler) ListItemsByOrderHandler(w http.ResponseWriter, r *http.Request
 
 	res, err := h.svc.ListItemsByOrder(r.Context(), in)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		httpio.WriteError(w, err)
 		return
 	}
 
@@ -234,14 +252,14 @@ func (h *Handler) ListItemsByOrderHandler(w http.ResponseWriter, r *http.Request
 // @Accept       json
 // @Produce      json
 // @Success      200 {object} listItemsResp
-// @Failure      401 {string} string "Unauthorized"
-// @Failure      500 {string} string "Internal server error"
+// @Failure      401 {object} httpio.Problem "Unauthorized"
+// @Failure      500 {object} httpio.Problem "Internal server error"
 // @Security     BearerAuth
 // @Router       /items [get]
 func (h *Handler) ListItemsByUserHandler(w http.ResponseWriter, r *http.Request) {
 	claims, ok := auth.GetClaimsFromContext(r.Context())
 	if !ok {
-		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		httpio.Error(w, http.StatusUnauthorized, httpio.CodeUnauthorized, "authentication required")
 		return
 	}
 
@@ -251,7 +269,7 @@ func (h *Handler) ListItemsByUserHandler(w http.ResponseWriter, r *http.Request)
 
 	res, err := h.svc.ListItemsByUser(r.Context(), in)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		httpio.WriteError(w, err)
 		return
 	}
 
@@ -281,28 +299,28 @@ func (h *Handler) ListItemsByUserHandler(w http.ResponseWriter, r *http.Request)
 // @Param        orderId path string true "Order ID"
 // @Param        id path string true "Item ID"
 // @Success      204 {string} string "No Content"
-// @Failure      400 {string} string "Invalid request"
-// @Failure      401 {string} string "Unauthorized"
+// @Failure      400 {object} httpio.Problem "Invalid request"
+// @Failure      401 {object} httpio.Problem "Unauthorized"
 // @Security     BearerAuth
 // @Router       /orders/{orderId}/items/{id} [delete]
 func (h *Handler) DeleteItemHandler(w http.ResponseWriter, r *http.Request) {
 	claims, ok := auth.GetClaimsFromContext(r.Context())
 	if !ok {
-		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		httpio.Error(w, http.StatusUnauthorized, httpio.CodeUnauthorized, "authentication required")
 		return
 	}
 
 	orderIDStr := r.PathValue("orderId")
 	orderID, err := uuid.Parse(orderIDStr)
 	if err != nil {
-		http.Error(w, "invalid order id", http.StatusBadRequest)
+		httpio.InvalidField(w, "orderId", "invalid order id")
 		return
 	}
 
 	itemIDStr := r.PathValue("id")
 	itemID, err := uuid.Parse(itemIDStr)
 	if err != nil {
-		http.Error(w, "invalid item id", http.StatusBadRequest)
+		httpio.InvalidField(w, "id", "invalid item id")
 		return
 	}
 
@@ -314,7 +332,7 @@ func (h *Handler) DeleteItemHandler(w http.ResponseWriter, r *http.Request) {
 
 	err = h.svc.DeleteItem(r.Context(), in)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		httpio.WriteError(w, err)
 		return
 	}
 
@@ -0,0 +1,24 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/frostnzx/go-ecommerce-api/internal/core/utils"
+)
+
+// GetJWKSHandlerFunc godoc
+// @Summary      JSON Web Key Set
+// @Description  Public keys used to verify access tokens, identified by the token's kid header
+// @Tags         Auth
+// @Produce      json
+// @Success      200 {object} utils.JWKSet
+// @Router       /.well-known/jwks.json [get]
+func GetJWKSHandlerFunc(tokenMaker *utils.JWTMaker) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "public, max-age=300")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(tokenMaker.JWKS())
+	}
+}
@@ -0,0 +1,145 @@
+package api
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/frostnzx/go-ecommerce-api/internal/adapters/primary/api/httpio"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/utils"
+	"github.com/frostnzx/go-ecommerce-api/internal/logging"
+	"github.com/google/uuid"
+)
+
+const requestIDHeader = "X-Request-ID"
+
+// maxRequestIDLength caps caller-supplied request IDs so they cannot bloat the logs
+const maxRequestIDLength = 128
+
+// RequestIDMiddleware reuses a well-formed X-Request-ID from the caller or assigns
+// a new one, echoes it in the response and attaches a logger carrying it to the context
+func RequestIDMiddleware(logger *slog.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			id := r.Header.Get(requestIDHeader)
+			if !validRequestID(id) {
+				id = uuid.NewString()
+			}
+			w.Header().Set(requestIDHeader, id)
+			ctx := logging.WithContext(r.Context(), logger.With("request_id", id))
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for _, c := range id {
+		ok := c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-' || c == '_' || c == '.'
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+type accessEntryKey struct{}
+
+// accessEntry collects what handlers learn about a request for its access log line
+type accessEntry struct {
+	userID         string
+	impersonatorID string
+}
+
+// AccessLogMiddleware writes one log line per request with its status, size,
+// latency and the authenticated user. Errors behind 5xx responses are logged at
+// error level; everything else is logged at info.
+func AccessLogMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		entry := &accessEntry{}
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		ctx := context.WithValue(r.Context(), accessEntryKey{}, entry)
+
+		next.ServeHTTP(rec, r.WithContext(ctx))
+
+		attrs := []any{
+			"method", r.Method,
+			"path", r.URL.Path,
+			"status", rec.status,
+			"bytes", rec.bytes,
+			"duration_ms", float64(time.Since(start).Microseconds()) / 1000,
+			"remote_addr", r.RemoteAddr,
+			"user_agent", r.UserAgent(),
+		}
+		if entry.userID != "" {
+			attrs = append(attrs, "user_id", entry.userID)
+		}
+		if entry.impersonatorID != "" {
+			attrs = append(attrs, "impersonator_id", entry.impersonatorID)
+		}
+		level := slog.LevelInfo
+		if rec.err != nil {
+			attrs = append(attrs, "error", rec.err.Error())
+		}
+		if rec.status >= http.StatusInternalServerError {
+			level = slog.LevelError
+		}
+		logging.FromContext(r.Context()).Log(r.Context(), level, "request", attrs...)
+	})
+}
+
+// withUser records the authenticated user, and the admin impersonating them if any,
+// for the access log and for every logger taken from the returned context
+func withUser(ctx context.Context, claims *utils.UserClaims) context.Context {
+	if entry, ok := ctx.Value(accessEntryKey{}).(*accessEntry); ok {
+		entry.userID = claims.ID.String()
+		entry.impersonatorID = claims.ImpersonatorID
+	}
+	args := []any{"user_id", claims.ID.String()}
+	if claims.ImpersonatorID != "" {
+		args = append(args, "impersonator_id", claims.ImpersonatorID)
+	}
+	return logging.With(ctx, args...)
+}
+
+// statusRecorder captures the status, body size and any error reported through
+// httpio.WriteError for the access log
+type statusRecorder struct {
+	http.ResponseWriter
+	status      int
+	bytes       int
+	wroteHeader bool
+	err         error
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	if !r.wroteHeader {
+		r.status = status
+		r.wroteHeader = true
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *statusRecorder) Write(b []byte) (int, error) {
+	r.wroteHeader = true
+	n, err := r.ResponseWriter.Write(b)
+	r.bytes += n
+	return n, err
+}
+
+// RecordError implements httpio.ErrorRecorder, passing the error on to any recorder it wraps
+func (r *statusRecorder) RecordError(err error) {
+	r.err = err
+	if inner, ok := r.ResponseWriter.(httpio.ErrorRecorder); ok {
+		inner.RecordError(err)
+	}
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer
+func (r *statusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
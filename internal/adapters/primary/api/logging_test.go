@@ -0,0 +1,109 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/frostnzx/go-ecommerce-api/internal/adapters/primary/api/httpio"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/utils"
+	"github.com/frostnzx/go-ecommerce-api/internal/logging"
+	"github.com/google/uuid"
+)
+
+// serveLogged runs h behind the request ID and access log middleware and returns the decoded log lines
+func serveLogged(t *testing.T, h http.Handler, req *http.Request) (*httptest.ResponseRecorder, []map[string]any) {
+	t.Helper()
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+	rec := httptest.NewRecorder()
+	RequestIDMiddleware(logger)(AccessLogMiddleware(h)).ServeHTTP(rec, req)
+
+	var lines []map[string]any
+	dec := json.NewDecoder(&buf)
+	for dec.More() {
+		var line map[string]any
+		if err := dec.Decode(&line); err != nil {
+			t.Fatalf("decoding log line: %v", err)
+		}
+		lines = append(lines, line)
+	}
+	return rec, lines
+}
+
+func TestRequestIDIsPropagatedOrAssigned(t *testing.T) {
+	var seen string
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logging.FromContext(r.Context()).Info("from handler")
+		seen = w.Header().Get(requestIDHeader)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/products", nil)
+	req.Header.Set(requestIDHeader, "abc-123")
+	rec, lines := serveLogged(t, h, req)
+	if got := rec.Header().Get(requestIDHeader); got != "abc-123" || seen != "abc-123" {
+		t.Errorf("request ID = %q, want the caller's abc-123", got)
+	}
+	for _, line := range lines {
+		if line["request_id"] != "abc-123" {
+			t.Errorf("log line %v is missing the request ID", line)
+		}
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/products", nil)
+	req.Header.Set(requestIDHeader, "bad id\nwith newline")
+	rec, _ = serveLogged(t, h, req)
+	if _, err := uuid.Parse(rec.Header().Get(requestIDHeader)); err != nil {
+		t.Errorf("malformed request ID was not replaced: %q", rec.Header().Get(requestIDHeader))
+	}
+}
+
+func TestAccessLogRecordsUserStatusAndError(t *testing.T) {
+	userID := uuid.New()
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		withUser(r.Context(), &utils.UserClaims{ID: userID})
+		httpio.WriteError(w, errors.New("pq: connection refused"))
+	})
+	_, lines := serveLogged(t, h, httptest.NewRequest(http.MethodGet, "/orders", nil))
+	if len(lines) != 1 {
+		t.Fatalf("got %d log lines, want 1", len(lines))
+	}
+	line := lines[0]
+	if line["level"] != "ERROR" || line["status"] != float64(http.StatusInternalServerError) {
+		t.Errorf("level %v status %v, want an ERROR line for the 500", line["level"], line["status"])
+	}
+	if line["user_id"] != userID.String() || line["path"] != "/orders" || line["method"] != http.MethodGet {
+		t.Errorf("unexpected access log line %v", line)
+	}
+	if line["error"] != "pq: connection refused" {
+		t.Errorf("error = %v, want the internal error", line["error"])
+	}
+	if _, ok := line["duration_ms"]; !ok {
+		t.Error("missing duration_ms")
+	}
+	if _, ok := line["impersonator_id"]; ok {
+		t.Error("impersonator_id logged for the user's own request")
+	}
+}
+
+func TestAccessLogRecordsImpersonator(t *testing.T) {
+	userID, adminID := uuid.New(), uuid.New()
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := withUser(r.Context(), &utils.UserClaims{ID: userID, ImpersonatorID: adminID.String()})
+		logging.FromContext(ctx).Info("from handler")
+		w.WriteHeader(http.StatusNoContent)
+	})
+	_, lines := serveLogged(t, h, httptest.NewRequest(http.MethodPost, "/orders", nil))
+	if len(lines) != 2 {
+		t.Fatalf("got %d log lines, want the handler's and the access log's", len(lines))
+	}
+	for _, line := range lines {
+		if line["user_id"] != userID.String() || line["impersonator_id"] != adminID.String() {
+			t.Errorf("log line %v does not name both the user and the impersonating admin", line)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package api
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"time"
+
+	"github.com/frostnzx/go-ecommerce-api/internal/adapters/primary/api/httpio"
+	"github.com/frostnzx/go-ecommerce-api/internal/metrics"
+)
+
+// unmatchedRoute labels requests no pattern matched, keeping arbitrary paths out of the label values
+const unmatchedRoute = "unmatched"
+
+// MetricsMiddleware counts and times requests per ServeMux route pattern
+func MetricsMiddleware(mux *http.ServeMux, m *metrics.Metrics) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			route := unmatchedRoute
+			if _, pattern := mux.Handler(r); pattern != "" {
+				route = pattern
+			}
+			start := time.Now()
+			rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(rec, r)
+			m.ObserveHTTP(r.Method, route, rec.status, time.Since(start))
+		})
+	}
+}
+
+// metricsHandler serves the metrics, requiring "Authorization: Bearer <token>" when a token is configured
+func metricsHandler(m *metrics.Metrics, token string) http.Handler {
+	h := m.Handler()
+	if token == "" {
+		return h
+	}
+	want := []byte("Bearer " + token)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), want) != 1 {
+			httpio.Error(w, http.StatusUnauthorized, httpio.CodeUnauthorized, "a valid metrics token is required")
+			return
+		}
+		h.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,62 @@
+package api
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/frostnzx/go-ecommerce-api/internal/metrics"
+)
+
+func scrape(t *testing.T, h http.Handler, authorization string) (int, string) {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	body, _ := io.ReadAll(rec.Body)
+	return rec.Code, string(body)
+}
+
+func TestMetricsLabelRequestsByRoutePattern(t *testing.T) {
+	m := metrics.New()
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /products/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	h := MetricsMiddleware(mux, m)(mux)
+	for _, path := range []string{"/products/1", "/products/2", "/no/such/route"} {
+		h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	_, body := scrape(t, m.Handler(), "")
+	for _, want := range []string{
+		`http_requests_total{method="GET",route="GET /products/{id}",status="404"} 2`,
+		`http_requests_total{method="GET",route="unmatched",status="404"} 1`,
+		`http_request_duration_seconds_count{method="GET",route="GET /products/{id}"} 2`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("metrics output is missing %s", want)
+		}
+	}
+	if strings.Contains(body, "/products/1") {
+		t.Error("raw paths leaked into the route label")
+	}
+}
+
+func TestMetricsEndpointRequiresConfiguredToken(t *testing.T) {
+	h := metricsHandler(metrics.New(), "s3cret")
+	if code, _ := scrape(t, h, ""); code != http.StatusUnauthorized {
+		t.Errorf("without token: status = %d, want %d", code, http.StatusUnauthorized)
+	}
+	if code, _ := scrape(t, h, "Bearer wrong"); code != http.StatusUnauthorized {
+		t.Errorf("wrong token: status = %d, want %d", code, http.StatusUnauthorized)
+	}
+	if code, body := scrape(t, h, "Bearer s3cret"); code != http.StatusOK || !strings.Contains(body, "go_goroutines") {
+		t.Errorf("with token: status = %d", code)
+	}
+}
@@ -6,50 +6,152 @@ import (
 	"strings"
 
 	"github.com/frostnzx/go-ecommerce-api/internal/adapters/primary/api/auth"
+	"github.com/frostnzx/go-ecommerce-api/internal/adapters/primary/api/httpio"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/services/apikey"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/services/session"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/services/user"
 	"github.com/frostnzx/go-ecommerce-api/internal/core/utils"
+	"github.com/google/uuid"
 )
 
-func GetAuthMiddlewareFunc(tokenMaker *utils.JWTMaker) func(http.Handler) http.Handler {
+var (
+	errInvalidToken = httpio.NewProblem(http.StatusUnauthorized, "invalid_token", "missing, invalid or expired access token")
+	errScopeDenied  = httpio.NewProblem(http.StatusForbidden, "insufficient_scope", "api key scope does not allow this request")
+	errNotAdmin     = httpio.NewProblem(http.StatusForbidden, "admin_required", "user is not an admin")
+)
+
+func GetAuthMiddlewareFunc(tokenMaker *utils.JWTMaker, sessionAPI session.API, apiKeyAPI apikey.API) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			// read the authorization header
-			// verify the token
-			claims, err := verifyClaimsFromAuthHeader(r, tokenMaker)
+			// read the authorization or API key header
+			// verify the token and the session behind it, or the API key and its scopes
+			claims, err := authenticate(r, tokenMaker, sessionAPI, apiKeyAPI)
 			if err != nil {
-				http.Error(w, fmt.Sprintf("error verifying token: %v", err), http.StatusUnauthorized)
+				httpio.WriteError(w, err)
 				return
 			}
 
 			// pass the payload/claims down the context
-			ctx := auth.SetClaimsInContext(r.Context(), claims)
+			ctx := auth.SetClaimsInContext(withUser(r.Context(), claims), claims)
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
 }
 
-func GetAdminMiddlewareFunc(tokenMaker *utils.JWTMaker) func(http.Handler) http.Handler {
+func GetAdminMiddlewareFunc(tokenMaker *utils.JWTMaker, sessionAPI session.API, apiKeyAPI apikey.API) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			// read the authorization header
-			// verify the token
-			claims, err := verifyClaimsFromAuthHeader(r, tokenMaker)
+			// read the authorization or API key header
+			// verify the token and the session behind it, or the API key and its scopes
+			claims, err := authenticate(r, tokenMaker, sessionAPI, apiKeyAPI)
 			if err != nil {
-				http.Error(w, fmt.Sprintf("error verifying token: %v", err), http.StatusUnauthorized)
+				httpio.WriteError(w, err)
 				return
 			}
 
 			if !claims.IsAdmin {
-				http.Error(w, "user is not an admin", http.StatusForbidden)
+				httpio.WriteError(w, errNotAdmin)
 				return
 			}
 
 			// pass the payload/claims down the context
-			ctx := auth.SetClaimsInContext(r.Context(), claims)
+			ctx := auth.SetClaimsInContext(withUser(r.Context(), claims), claims)
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
 }
 
+// GetImpersonationAuditMiddlewareFunc records every request that may change state and is
+// made with an impersonation token in the audit log, before it is handled. It must run
+// behind the auth middleware, which puts the claims in the context.
+func GetImpersonationAuditMiddlewareFunc(userAPI user.API) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			claims, ok := auth.GetClaimsFromContext(r.Context())
+			if !ok || claims.ImpersonatorID == "" || isReadOnly(r.Method) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			adminID, err := uuid.Parse(claims.ImpersonatorID)
+			if err != nil {
+				httpio.WriteError(w, errInvalidToken)
+				return
+			}
+			// No change without a trail: if the entry cannot be written the request is refused
+			err = userAPI.RecordImpersonatedRequest(r.Context(), user.RecordImpersonatedRequestReq{
+				AdminID:   adminID,
+				UserID:    claims.ID,
+				SessionID: claims.SessionID,
+				Request:   r.Method + " " + r.URL.Path,
+			})
+			if err != nil {
+				httpio.WriteError(w, err)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func isReadOnly(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+}
+
+// authenticate verifies the bearer token and checks that its session has not been
+// logged out or revoked. Requests carrying an API key are authenticated with the
+// key instead. Errors are written with httpio.WriteError.
+func authenticate(r *http.Request, tokenMaker *utils.JWTMaker, sessionAPI session.API, apiKeyAPI apikey.API) (*utils.UserClaims, error) {
+	if key, ok := apiKeyFromRequest(r); ok {
+		return authenticateAPIKey(r, apiKeyAPI, key)
+	}
+
+	claims, err := verifyClaimsFromAuthHeader(r, tokenMaker)
+	if err != nil {
+		return nil, errInvalidToken
+	}
+
+	if err := sessionAPI.ValidateSession(r.Context(), claims.SessionID); err != nil {
+		return nil, fmt.Errorf("error verifying session: %w", err)
+	}
+	return claims, nil
+}
+
+// authenticateAPIKey turns a valid API key into claims for its owner, limited to the key's scopes
+func authenticateAPIKey(r *http.Request, apiKeyAPI apikey.API, key string) (*utils.UserClaims, error) {
+	res, ok := verifiedAPIKeyFromContext(r.Context(), key)
+	if !ok {
+		var err error
+		res, err = apiKeyAPI.AuthenticateAPIKey(r.Context(), apikey.AuthenticateAPIKeyReq{Key: key})
+		if err != nil {
+			return nil, fmt.Errorf("error verifying api key: %w", err)
+		}
+	}
+	if !res.Scopes.Allows(r.Method) {
+		return nil, errScopeDenied
+	}
+
+	claims := &utils.UserClaims{
+		ID:       res.UserID,
+		Email:    res.Email,
+		IsAdmin:  res.IsAdmin,
+		APIKeyID: res.KeyID.String(),
+		Scopes:   res.Scopes,
+	}
+	return claims, nil
+}
+
+// apiKeyFromRequest reads a key sent as "X-API-Key: <key>" or "Authorization: ApiKey <key>"
+func apiKeyFromRequest(r *http.Request) (string, bool) {
+	if key := r.Header.Get("X-API-Key"); key != "" {
+		return key, true
+	}
+	fields := strings.Fields(r.Header.Get("Authorization"))
+	if len(fields) == 2 && fields[0] == "ApiKey" {
+		return fields[1], true
+	}
+	return "", false
+}
+
 func verifyClaimsFromAuthHeader(r *http.Request, tokenMaker *utils.JWTMaker) (*utils.UserClaims, error) {
 	authHeader := r.Header.Get("Authorization")
 	if authHeader == "" {
@@ -62,7 +164,7 @@ func verifyClaimsFromAuthHeader(r *http.Request, tokenMaker *utils.JWTMaker) (*u
 	}
 
 	tokenStr := fields[1]
-	claims, err := tokenMaker.VerifyToken(tokenStr)
+	claims, err := tokenMaker.VerifyToken(tokenStr, utils.AccessToken)
 	if err != nil {
 		return nil, fmt.Errorf("invalid token: %w", err)
 	}
@@ -0,0 +1,237 @@
+package api
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/frostnzx/go-ecommerce-api/internal/adapters/secondary/memory"
+	domainsession "github.com/frostnzx/go-ecommerce-api/internal/core/domain/session"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/user"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/services/apikey"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/services/session"
+	coreuser "github.com/frostnzx/go-ecommerce-api/internal/core/services/user"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/utils"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+func newTestTokenMaker(t *testing.T) *utils.JWTMaker {
+	t.Helper()
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	maker, err := utils.NewJWTMaker("test", []utils.SigningKey{{ID: "test", Method: jwt.SigningMethodEdDSA, Private: priv, Public: priv.Public()}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return maker
+}
+
+// authFixture is a user with one live session and an access token for it
+type authFixture struct {
+	store    *memory.Store
+	maker    *utils.JWTMaker
+	sessions *session.Service
+	user     user.User
+	session  domainsession.Session
+	token    string
+}
+
+func newAuthFixture(t *testing.T) authFixture {
+	t.Helper()
+	ctx := context.Background()
+	store := memory.NewStore()
+	users, _ := memory.NewUserRepo(store)
+	sessionRepo, _ := memory.NewSessionRepo(store)
+
+	u := user.New("ada@example.com", "hash", "Ada", false)
+	if err := users.Create(ctx, u); err != nil {
+		t.Fatal(err)
+	}
+	s := domainsession.New(uuid.NewString(), u.ID, u.Email, false, time.Now().Add(time.Hour))
+	if _, err := sessionRepo.CreateSession(ctx, &s); err != nil {
+		t.Fatal(err)
+	}
+	maker := newTestTokenMaker(t)
+	token, _, err := maker.CreateToken(s.ID, u.ID, u.Email, false, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return authFixture{store: store, maker: maker, sessions: session.NewService(sessionRepo), user: u, session: s, token: token}
+}
+
+func (f authFixture) bearer() http.Header {
+	return http.Header{"Authorization": {"Bearer " + f.token}}
+}
+
+func serveAuthenticated(h http.Handler, method string, header http.Header) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, "/users/me", nil)
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+var noContent = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusNoContent) })
+
+func TestAuthMiddlewareRejectsRevokedSession(t *testing.T) {
+	f := newAuthFixture(t)
+	h := GetAuthMiddlewareFunc(f.maker, f.sessions, nil)(noContent)
+
+	if rec := serveAuthenticated(h, http.MethodGet, f.bearer()); rec.Code != http.StatusNoContent {
+		t.Fatalf("live session = %d, want it allowed", rec.Code)
+	}
+	if err := f.sessions.RevokeSession(context.Background(), f.session.ID); err != nil {
+		t.Fatal(err)
+	}
+	// The token itself is still valid; only the session check can catch this
+	if rec := serveAuthenticated(h, http.MethodGet, f.bearer()); rec.Code != http.StatusUnauthorized {
+		t.Fatalf("revoked session = %d, want 401", rec.Code)
+	}
+}
+
+func TestAuthMiddlewareRejectsMissingOrForeignTokens(t *testing.T) {
+	f := newAuthFixture(t)
+	h := GetAuthMiddlewareFunc(f.maker, f.sessions, nil)(noContent)
+
+	foreign, _, err := newTestTokenMaker(t).CreateToken(f.session.ID, f.user.ID, f.user.Email, false, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, header := range map[string]http.Header{
+		"missing":         nil,
+		"not bearer":      {"Authorization": {"Basic " + f.token}},
+		"other signer":    {"Authorization": {"Bearer " + foreign}},
+		"unknown session": {"Authorization": {"Bearer " + mustToken(t, f.maker, uuid.NewString(), f.user)}},
+	} {
+		if rec := serveAuthenticated(h, http.MethodGet, header); rec.Code != http.StatusUnauthorized {
+			t.Errorf("%s: status = %d, want 401", name, rec.Code)
+		}
+	}
+}
+
+func TestAuthMiddlewareRejectsRefreshTokens(t *testing.T) {
+	f := newAuthFixture(t)
+	h := GetAuthMiddlewareFunc(f.maker, f.sessions, nil)(noContent)
+
+	// Signed by us for a live session, but only good for POST /auth/refresh
+	refresh, _, err := f.maker.CreateRefreshToken(f.session.ID, f.user.ID, f.user.Email, false, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rec := serveAuthenticated(h, http.MethodGet, http.Header{"Authorization": {"Bearer " + refresh}}); rec.Code != http.StatusUnauthorized {
+		t.Fatalf("refresh token as bearer = %d, want 401", rec.Code)
+	}
+}
+
+func TestAuthMiddlewareEnforcesAPIKeyScopes(t *testing.T) {
+	f := newAuthFixture(t)
+	users, _ := memory.NewUserRepo(f.store)
+	keyRepo, _ := memory.NewAPIKeyRepo(f.store)
+	auditLog, _ := memory.NewAuditRepo(f.store)
+	keys := apikey.NewService(keyRepo, users, auditLog)
+	h := GetAuthMiddlewareFunc(f.maker, f.sessions, keys)(noContent)
+
+	read, err := keys.CreateAPIKey(context.Background(), apikey.CreateAPIKeyReq{UserID: f.user.ID, Name: "read", Scopes: []string{"read"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	write, err := keys.CreateAPIKey(context.Background(), apikey.CreateAPIKeyReq{UserID: f.user.ID, Name: "write", Scopes: []string{"write"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		method string
+		header http.Header
+		want   int
+	}{
+		{"read key GET", http.MethodGet, http.Header{"X-Api-Key": {read.Key}}, http.StatusNoContent},
+		{"read key HEAD", http.MethodHead, http.Header{"Authorization": {"ApiKey " + read.Key}}, http.StatusNoContent},
+		{"read key POST", http.MethodPost, http.Header{"X-Api-Key": {read.Key}}, http.StatusForbidden},
+		{"read key DELETE", http.MethodDelete, http.Header{"X-Api-Key": {read.Key}}, http.StatusForbidden},
+		{"write key POST", http.MethodPost, http.Header{"X-Api-Key": {write.Key}}, http.StatusNoContent},
+		{"write key GET", http.MethodGet, http.Header{"X-Api-Key": {write.Key}}, http.StatusNoContent},
+		{"unknown key", http.MethodGet, http.Header{"X-Api-Key": {read.Key + "x"}}, http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		if rec := serveAuthenticated(h, tt.method, tt.header); rec.Code != tt.want {
+			t.Errorf("%s: status = %d, want %d", tt.name, rec.Code, tt.want)
+		}
+	}
+
+	if err := keys.RevokeAPIKey(context.Background(), apikey.RevokeAPIKeyReq{ID: write.ID, UserID: f.user.ID}); err != nil {
+		t.Fatal(err)
+	}
+	if rec := serveAuthenticated(h, http.MethodPost, http.Header{"X-Api-Key": {write.Key}}); rec.Code != http.StatusUnauthorized {
+		t.Fatalf("revoked key: status = %d, want 401", rec.Code)
+	}
+}
+
+// auditRecorder collects the impersonated requests written to the audit log
+type auditRecorder struct {
+	coreuser.API
+	recorded []coreuser.RecordImpersonatedRequestReq
+	err      error
+}
+
+func (a *auditRecorder) RecordImpersonatedRequest(_ context.Context, req coreuser.RecordImpersonatedRequestReq) error {
+	if a.err != nil {
+		return a.err
+	}
+	a.recorded = append(a.recorded, req)
+	return nil
+}
+
+func TestImpersonatedChangesAreAudited(t *testing.T) {
+	f := newAuthFixture(t)
+	adminID := uuid.New()
+	impersonation, _, err := f.maker.CreateImpersonationToken(f.session.ID, f.user.ID, f.user.Email, adminID, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	impersonating := http.Header{"Authorization": {"Bearer " + impersonation}}
+	audited := func(a *auditRecorder) http.Handler {
+		return GetAuthMiddlewareFunc(f.maker, f.sessions, nil)(GetImpersonationAuditMiddlewareFunc(a)(noContent))
+	}
+
+	a := &auditRecorder{}
+	serveAuthenticated(audited(a), http.MethodGet, impersonating)
+	serveAuthenticated(audited(a), http.MethodPost, f.bearer())
+	if len(a.recorded) != 0 {
+		t.Fatalf("recorded %+v, want reads and the user's own requests left out", a.recorded)
+	}
+
+	if rec := serveAuthenticated(audited(a), http.MethodPut, impersonating); rec.Code != http.StatusNoContent {
+		t.Fatalf("impersonated PUT = %d, want it allowed", rec.Code)
+	}
+	want := coreuser.RecordImpersonatedRequestReq{AdminID: adminID, UserID: f.user.ID, SessionID: f.session.ID, Request: "PUT /users/me"}
+	if len(a.recorded) != 1 || a.recorded[0] != want {
+		t.Fatalf("recorded %+v, want %+v", a.recorded, want)
+	}
+
+	// Without a trail the change is not made
+	failing := &auditRecorder{err: errors.New("connection refused")}
+	if rec := serveAuthenticated(audited(failing), http.MethodDelete, impersonating); rec.Code != http.StatusInternalServerError {
+		t.Fatalf("impersonated DELETE with the audit log down = %d, want 500", rec.Code)
+	}
+}
+
+func mustToken(t *testing.T, maker *utils.JWTMaker, sessionID string, u user.User) string {
+	t.Helper()
+	token, _, err := maker.CreateToken(sessionID, u.ID, u.Email, u.IsAdmin, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return token
+}
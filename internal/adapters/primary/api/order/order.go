@@ -5,44 +5,48 @@ import (
 	"net/http"
 
 	"github.com/frostnzx/go-ecommerce-api/internal/adapters/primary/api/auth"
+	"github.com/frostnzx/go-ecommerce-api/internal/adapters/primary/api/httpio"
 	coreorder "github.com/frostnzx/go-ecommerce-api/internal/core/services/order"
 	"github.com/google/uuid"
 )
 
 type Handler struct {
-	svc             coreorder.API
-	authMiddleware  func(http.Handler) http.Handler
-	adminMiddleware func(http.Handler) http.Handler
+	svc                   coreorder.API
+	authMiddleware        func(http.Handler) http.Handler
+	adminMiddleware       func(http.Handler) http.Handler
+	idempotencyMiddleware func(http.Handler) http.Handler
 }
 
-func New(svc coreorder.API, authMiddleware, adminMiddleware func(http.Handler) http.Handler) *Handler {
+func New(svc coreorder.API, authMiddleware, adminMiddleware, idempotencyMiddleware func(http.Handler) http.Handler) *Handler {
 	return &Handler{
-		svc:             svc,
-		authMiddleware:  authMiddleware,
-		adminMiddleware: adminMiddleware,
+		svc:                   svc,
+		authMiddleware:        authMiddleware,
+		adminMiddleware:       adminMiddleware,
+		idempotencyMiddleware: idempotencyMiddleware,
 	}
 }
 
 func (h *Handler) SetupRoutes(mux *http.ServeMux) {
 	// Protected routes (auth required)
-	mux.Handle("POST /orders", h.authMiddleware(http.HandlerFunc(h.PlaceOrderHandler)))
+	mux.Handle("POST /orders", h.authMiddleware(h.idempotencyMiddleware(http.HandlerFunc(h.PlaceOrderHandler))))
 	mux.Handle("GET /orders", h.authMiddleware(http.HandlerFunc(h.ListOrdersHandler)))
 	mux.Handle("GET /orders/{id}", h.authMiddleware(http.HandlerFunc(h.GetOrderHandler)))
-	mux.Handle("POST /orders/{id}/cancel", h.authMiddleware(http.HandlerFunc(h.CancelOrderHandler)))
+	mux.Handle("POST /orders/{id}/cancel", h.authMiddleware(h.idempotencyMiddleware(http.HandlerFunc(h.CancelOrderHandler))))
 
 	// Admin routes
 	mux.Handle("PUT /admin/orders/{id}/status", h.adminMiddleware(http.HandlerFunc(h.UpdateOrderStatusHandler)))
+	mux.Handle("GET /admin/users/{id}/orders", h.adminMiddleware(http.HandlerFunc(h.ListUserOrdersHandler)))
 }
 
 // DTOs
 type orderItemReq struct {
-	ProductID string `json:"product_id"`
-	Quantity  int    `json:"quantity"`
+	ProductID string `json:"product_id" validate:"required,uuid"`
+	Quantity  int    `json:"quantity" validate:"min=1,max=1000"`
 }
 
 type placeOrderReq struct {
-	AddressID string         `json:"address_id"`
-	Items     []orderItemReq `json:"items"`
+	AddressID string         `json:"address_id" validate:"required,uuid"`
+	Items     []orderItemReq `json:"items" validate:"required,min=1,max=100,dive"`
 }
 
 type placeOrderResp struct {
@@ -80,39 +84,41 @@ type getOrderResp struct {
 }
 
 type updateStatusReq struct {
-	Status string `json:"status"`
+	Status string `json:"status" validate:"required,oneof=pending paid shipped cancelled"`
 }
 
 // Handlers
 
 // PlaceOrderHandler godoc
 // @Summary      Place a new order
-// @Description  Create a new order with items
+// @Description  Create a new order with items. Send an Idempotency-Key to retry safely: a repeated request returns the original response instead of placing a second order
 // @Tags         Orders
 // @Accept       json
 // @Produce      json
+// @Param        Idempotency-Key header string false "Unique key for this order, e.g. a UUID"
 // @Param        request body placeOrderReq true "Order data"
 // @Success      201 {object} placeOrderResp
-// @Failure      400 {string} string "Invalid request"
-// @Failure      401 {string} string "Unauthorized"
+// @Failure      400 {object} httpio.Problem "Invalid request"
+// @Failure      401 {object} httpio.Problem "Unauthorized"
+// @Failure      409 {object} httpio.Problem "A request with this idempotency key is still in progress"
+// @Failure      422 {object} httpio.Problem "Idempotency key already used for a different request"
 // @Security     BearerAuth
 // @Router       /orders [post]
 func (h *Handler) PlaceOrderHandler(w http.ResponseWriter, r *http.Request) {
 	claims, ok := auth.GetClaimsFromContext(r.Context())
 	if !ok {
-		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		httpio.Error(w, http.StatusUnauthorized, httpio.CodeUnauthorized, "authentication required")
 		return
 	}
 
 	var req placeOrderReq
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "invalid request", http.StatusBadRequest)
+	if !httpio.DecodeJSON(w, r, &req) {
 		return
 	}
 
 	addressID, err := uuid.Parse(req.AddressID)
 	if err != nil {
-		http.Error(w, "invalid address id", http.StatusBadRequest)
+		httpio.InvalidField(w, "address_id", "invalid address id")
 		return
 	}
 
@@ -120,7 +126,7 @@ func (h *Handler) PlaceOrderHandler(w http.ResponseWriter, r *http.Request) {
 	for _, item := range req.Items {
 		productID, err := uuid.Parse(item.ProductID)
 		if err != nil {
-			http.Error(w, "invalid product id", http.StatusBadRequest)
+			httpio.InvalidField(w, "items.product_id", "invalid product id")
 			return
 		}
 		items = append(items, coreorder.OrderItemReq{
@@ -137,7 +143,7 @@ func (h *Handler) PlaceOrderHandler(w http.ResponseWriter, r *http.Request) {
 
 	res, err := h.svc.PlaceOrder(r.Context(), in)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		httpio.WriteError(w, err)
 		return
 	}
 
@@ -160,24 +166,51 @@ func (h *Handler) PlaceOrderHandler(w http.ResponseWriter, r *http.Request) {
 // @Accept       json
 // @Produce      json
 // @Success      200 {object} listOrdersResp
-// @Failure      401 {string} string "Unauthorized"
-// @Failure      500 {string} string "Internal server error"
+// @Failure      401 {object} httpio.Problem "Unauthorized"
+// @Failure      500 {object} httpio.Problem "Internal server error"
 // @Security     BearerAuth
 // @Router       /orders [get]
 func (h *Handler) ListOrdersHandler(w http.ResponseWriter, r *http.Request) {
 	claims, ok := auth.GetClaimsFromContext(r.Context())
 	if !ok {
-		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		httpio.Error(w, http.StatusUnauthorized, httpio.CodeUnauthorized, "authentication required")
 		return
 	}
 
+	h.listOrders(w, r, claims.ID)
+}
+
+// ListUserOrdersHandler godoc
+// @Summary      List a user's orders (Admin)
+// @Description  Get all orders of any user (admin only)
+// @Tags         Admin
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "User ID"
+// @Success      200 {object} listOrdersResp
+// @Failure      400 {object} httpio.Problem "Invalid request"
+// @Failure      401 {object} httpio.Problem "Unauthorized"
+// @Failure      403 {object} httpio.Problem "Forbidden"
+// @Failure      500 {object} httpio.Problem "Internal server error"
+// @Security     BearerAuth
+// @Router       /admin/users/{id}/orders [get]
+func (h *Handler) ListUserOrdersHandler(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		httpio.InvalidField(w, "id", "invalid user id")
+		return
+	}
+	h.listOrders(w, r, userID)
+}
+
+func (h *Handler) listOrders(w http.ResponseWriter, r *http.Request, userID uuid.UUID) {
 	in := coreorder.ListOrdersReq{
-		UserID: claims.ID,
+		UserID: userID,
 	}
 
 	res, err := h.svc.ListOrders(r.Context(), in)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		httpio.WriteError(w, err)
 		return
 	}
 
@@ -206,22 +239,22 @@ func (h *Handler) ListOrdersHandler(w http.ResponseWriter, r *http.Request) {
 // @Produce      json
 // @Param        id path string true "Order ID"
 // @Success      200 {object} getOrderResp
-// @Failure      400 {string} string "Invalid request"
-// @Failure      401 {string} string "Unauthorized"
-// @Failure      404 {string} string "Not found"
+// @Failure      400 {object} httpio.Problem "Invalid request"
+// @Failure      401 {object} httpio.Problem "Unauthorized"
+// @Failure      404 {object} httpio.Problem "Not found"
 // @Security     BearerAuth
 // @Router       /orders/{id} [get]
 func (h *Handler) GetOrderHandler(w http.ResponseWriter, r *http.Request) {
 	claims, ok := auth.GetClaimsFromContext(r.Context())
 	if !ok {
-		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		httpio.Error(w, http.StatusUnauthorized, httpio.CodeUnauthorized, "authentication required")
 		return
 	}
 
 	orderIDStr := r.PathValue("id")
 	orderID, err := uuid.Parse(orderIDStr)
 	if err != nil {
-		http.Error(w, "invalid order id", http.StatusBadRequest)
+		httpio.InvalidField(w, "id", "invalid order id")
 		return
 	}
 
@@ -232,7 +265,7 @@ func (h *Handler) GetOrderHandler(w http.ResponseWriter, r *http.Request) {
 
 	res, err := h.svc.GetOrder(r.Context(), in)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusNotFound)
+		httpio.WriteError(w, err)
 		return
 	}
 
@@ -266,23 +299,26 @@ func (h *Handler) GetOrderHandler(w http.ResponseWriter, r *http.Request) {
 // @Tags         Orders
 // @Accept       json
 // @Produce      json
+// @Param        Idempotency-Key header string false "Unique key for this cancellation, e.g. a UUID"
 // @Param        id path string true "Order ID"
 // @Success      204 {string} string "No Content"
-// @Failure      400 {string} string "Invalid request"
-// @Failure      401 {string} string "Unauthorized"
+// @Failure      400 {object} httpio.Problem "Invalid request"
+// @Failure      401 {object} httpio.Problem "Unauthorized"
+// @Failure      409 {object} httpio.Problem "A request with this idempotency key is still in progress"
+// @Failure      422 {object} httpio.Problem "Idempotency key already used for a different request"
 // @Security     BearerAuth
 // @Router       /orders/{id}/cancel [post]
 func (h *Handler) CancelOrderHandler(w http.ResponseWriter, r *http.Request) {
 	claims, ok := auth.GetClaimsFromContext(r.Context())
 	if !ok {
-		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		httpio.Error(w, http.StatusUnauthorized, httpio.CodeUnauthorized, "authentication required")
 		return
 	}
 
 	orderIDStr := r.PathValue("id")
 	orderID, err := uuid.Parse(orderIDStr)
 	if err != nil {
-		http.Error(w, "invalid order id", http.StatusBadRequest)
+		httpio.InvalidField(w, "id", "invalid order id")
 		return
 	}
 
@@ -293,7 +329,7 @@ func (h *Handler) CancelOrderHandler(w http.ResponseWriter, r *http.Request) {
 
 	err = h.svc.CancelOrder(r.Context(), in)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		httpio.WriteError(w, err)
 		return
 	}
 
@@ -309,22 +345,21 @@ func (h *Handler) CancelOrderHandler(w http.ResponseWriter, r *http.Request) {
 // @Param        id path string true "Order ID"
 // @Param        request body updateStatusReq true "New status"
 // @Success      204 {string} string "No Content"
-// @Failure      400 {string} string "Invalid request"
-// @Failure      401 {string} string "Unauthorized"
-// @Failure      403 {string} string "Forbidden"
+// @Failure      400 {object} httpio.Problem "Invalid request"
+// @Failure      401 {object} httpio.Problem "Unauthorized"
+// @Failure      403 {object} httpio.Problem "Forbidden"
 // @Security     BearerAuth
 // @Router       /admin/orders/{id}/status [put]
 func (h *Handler) UpdateOrderStatusHandler(w http.ResponseWriter, r *http.Request) {
 	orderIDStr := r.PathValue("id")
 	orderID, err := uuid.Parse(orderIDStr)
 	if err != nil {
-		http.Error(w, "invalid order id", http.StatusBadRequest)
+		httpio.InvalidField(w, "id", "invalid order id")
 		return
 	}
 
 	var req updateStatusReq
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "invalid request", http.StatusBadRequest)
+	if !httpio.DecodeJSON(w, r, &req) {
 		return
 	}
 
@@ -335,7 +370,7 @@ func (h *Handler) UpdateOrderStatusHandler(w http.ResponseWriter, r *http.Reques
 
 	err = h.svc.UpdateOrderStatus(r.Context(), in)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		httpio.WriteError(w, err)
 		return
 	}
 
@@ -0,0 +1,251 @@
+package privacy
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/frostnzx/go-ecommerce-api/internal/adapters/primary/api/auth"
+	"github.com/frostnzx/go-ecommerce-api/internal/adapters/primary/api/httpio"
+	coreprivacy "github.com/frostnzx/go-ecommerce-api/internal/core/services/privacy"
+	"github.com/google/uuid"
+)
+
+type Handler struct {
+	svc            coreprivacy.API
+	authMiddleware func(http.Handler) http.Handler
+}
+
+func New(svc coreprivacy.API, authMiddleware func(http.Handler) http.Handler) *Handler {
+	return &Handler{
+		svc:            svc,
+		authMiddleware: authMiddleware,
+	}
+}
+
+func (h *Handler) SetupRoutes(mux *http.ServeMux) {
+	// Protected routes (auth required)
+	mux.Handle("GET /users/me/export", h.authMiddleware(http.HandlerFunc(h.ExportMyDataHandler)))
+	mux.Handle("DELETE /users/me", h.authMiddleware(http.HandlerFunc(h.EraseMyAccountHandler)))
+	mux.Handle("DELETE /users/{id}", h.authMiddleware(http.HandlerFunc(h.EraseAccountHandler)))
+}
+
+// DTOs
+type exportResp struct {
+	ExportedAt time.Time        `json:"exported_at"`
+	Profile    profileExport    `json:"profile"`
+	Addresses  []addressExport  `json:"addresses"`
+	Orders     []orderExport    `json:"orders"`
+	Sessions   []sessionExport  `json:"sessions"`
+	Identities []identityExport `json:"linked_identities"`
+}
+
+type profileExport struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	Email     string    `json:"email"`
+	IsAdmin   bool      `json:"is_admin"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type addressExport struct {
+	ID         uuid.UUID `json:"id"`
+	Line1      string    `json:"line1"`
+	City       string    `json:"city"`
+	Province   string    `json:"province"`
+	PostalCode string    `json:"postal_code"`
+	Country    string    `json:"country"`
+	IsDefault  bool      `json:"is_default"`
+}
+
+type orderExport struct {
+	ID          uuid.UUID    `json:"id"`
+	AddressID   uuid.UUID    `json:"address_id"`
+	Status      string       `json:"status"`
+	TotalAmount float64      `json:"total_amount"`
+	CreatedAt   time.Time    `json:"created_at"`
+	Items       []itemExport `json:"items"`
+}
+
+type itemExport struct {
+	ID        uuid.UUID `json:"id"`
+	ProductID uuid.UUID `json:"product_id"`
+	Quantity  int       `json:"quantity"`
+	UnitPrice float64   `json:"unit_price"`
+}
+
+type sessionExport struct {
+	ID         string    `json:"id"`
+	UserAgent  string    `json:"user_agent"`
+	IPAddress  string    `json:"ip_address"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastUsedAt time.Time `json:"last_used_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+}
+
+type identityExport struct {
+	Provider  string    `json:"provider"`
+	Subject   string    `json:"subject"`
+	Email     string    `json:"email"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Handlers
+
+// ExportMyDataHandler godoc
+// @Summary      Export my data
+// @Description  Download a JSON archive of everything stored about the authenticated user: profile, addresses, orders with items, sessions and linked identities
+// @Tags         Privacy
+// @Produce      json
+// @Success      200 {object} exportResp
+// @Failure      401 {object} httpio.Problem "Unauthorized"
+// @Failure      500 {object} httpio.Problem "Internal server error"
+// @Security     BearerAuth
+// @Router       /users/me/export [get]
+func (h *Handler) ExportMyDataHandler(w http.ResponseWriter, r *http.Request) {
+	claims, ok := auth.GetClaimsFromContext(r.Context())
+	if !ok {
+		httpio.Error(w, http.StatusUnauthorized, httpio.CodeUnauthorized, "authentication required")
+		return
+	}
+
+	res, err := h.svc.ExportData(r.Context(), coreprivacy.ExportDataReq{UserID: claims.ID})
+	if err != nil {
+		httpio.WriteError(w, err)
+		return
+	}
+
+	resp := toExportResp(res)
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Disposition", `attachment; filename="export-`+claims.ID.String()+`.json"`)
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.Encode(resp)
+}
+
+// EraseMyAccountHandler godoc
+// @Summary      Erase my account
+// @Description  Anonymise the authenticated user's personal data and log out everywhere. Orders are kept, without personal details, for accounting
+// @Tags         Privacy
+// @Success      204 {string} string "No Content"
+// @Failure      401 {object} httpio.Problem "Unauthorized"
+// @Failure      403 {object} httpio.Problem "Forbidden"
+// @Failure      500 {object} httpio.Problem "Internal server error"
+// @Security     BearerAuth
+// @Router       /users/me [delete]
+func (h *Handler) EraseMyAccountHandler(w http.ResponseWriter, r *http.Request) {
+	claims, ok := auth.GetClaimsFromContext(r.Context())
+	if !ok {
+		httpio.Error(w, http.StatusUnauthorized, httpio.CodeUnauthorized, "authentication required")
+		return
+	}
+	h.eraseAccount(w, r, claims.ID)
+}
+
+// EraseAccountHandler godoc
+// @Summary      Delete account
+// @Description  Erase a user account (anonymise personal data, keep order history). Users can only erase their own account, admins any account
+// @Tags         Users
+// @Param        id path string true "User ID"
+// @Success      204 {string} string "No Content"
+// @Failure      400 {object} httpio.Problem "Invalid request"
+// @Failure      401 {object} httpio.Problem "Unauthorized"
+// @Failure      403 {object} httpio.Problem "Forbidden"
+// @Failure      409 {object} httpio.Problem "Already erased"
+// @Failure      500 {object} httpio.Problem "Internal server error"
+// @Security     BearerAuth
+// @Router       /users/{id} [delete]
+func (h *Handler) EraseAccountHandler(w http.ResponseWriter, r *http.Request) {
+	_, userID, ok := auth.TargetUser(w, r)
+	if !ok {
+		return
+	}
+	h.eraseAccount(w, r, userID)
+}
+
+func (h *Handler) eraseAccount(w http.ResponseWriter, r *http.Request, userID uuid.UUID) {
+	claims, _ := auth.GetClaimsFromContext(r.Context())
+	// Erasure cannot be undone, so it needs a logged in user rather than an API key
+	if claims.APIKeyID != "" {
+		httpio.Error(w, http.StatusForbidden, httpio.CodeSessionRequired, "api keys cannot erase accounts, log in instead")
+		return
+	}
+	if !auth.NotImpersonating(w, claims) {
+		return
+	}
+
+	err := h.svc.EraseAccount(r.Context(), coreprivacy.EraseAccountReq{UserID: userID})
+	if err != nil {
+		httpio.WriteError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent) // 204
+}
+
+func toExportResp(res *coreprivacy.ExportDataResp) exportResp {
+	resp := exportResp{
+		ExportedAt: res.ExportedAt,
+		Profile: profileExport{
+			ID:        res.Profile.ID,
+			Name:      res.Profile.Name,
+			Email:     res.Profile.Email,
+			IsAdmin:   res.Profile.IsAdmin,
+			CreatedAt: res.Profile.CreatedAt,
+		},
+		Addresses:  make([]addressExport, 0, len(res.Addresses)),
+		Orders:     make([]orderExport, 0, len(res.Orders)),
+		Sessions:   make([]sessionExport, 0, len(res.Sessions)),
+		Identities: make([]identityExport, 0, len(res.Identities)),
+	}
+	for _, a := range res.Addresses {
+		resp.Addresses = append(resp.Addresses, addressExport{
+			ID:         a.ID,
+			Line1:      a.Line1,
+			City:       a.City,
+			Province:   a.Province,
+			PostalCode: a.PostalCode,
+			Country:    a.Country,
+			IsDefault:  a.IsDefault,
+		})
+	}
+	for _, o := range res.Orders {
+		oe := orderExport{
+			ID:          o.Order.ID,
+			AddressID:   o.Order.AddressID,
+			Status:      string(o.Order.Status),
+			TotalAmount: o.Order.TotalAmount,
+			CreatedAt:   o.Order.CreatedAt,
+			Items:       make([]itemExport, 0, len(o.Items)),
+		}
+		for _, it := range o.Items {
+			oe.Items = append(oe.Items, itemExport{
+				ID:        it.ID,
+				ProductID: it.ProductID,
+				Quantity:  it.Quantity,
+				UnitPrice: it.UnitPriceSnapshot,
+			})
+		}
+		resp.Orders = append(resp.Orders, oe)
+	}
+	for _, s := range res.Sessions {
+		resp.Sessions = append(resp.Sessions, sessionExport{
+			ID:         s.ID,
+			UserAgent:  s.UserAgent,
+			IPAddress:  s.IPAddress,
+			CreatedAt:  s.CreatedAt,
+			LastUsedAt: s.LastUsedAt,
+			ExpiresAt:  s.ExpiresAt,
+		})
+	}
+	for _, i := range res.Identities {
+		resp.Identities = append(resp.Identities, identityExport{
+			Provider:  i.Provider,
+			Subject:   i.Subject,
+			Email:     i.Email,
+			CreatedAt: i.CreatedAt,
+		})
+	}
+	return resp
+}
@@ -0,0 +1,64 @@
+package privacy
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/frostnzx/go-ecommerce-api/internal/adapters/primary/api/auth"
+	coreprivacy "github.com/frostnzx/go-ecommerce-api/internal/core/services/privacy"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/utils"
+	"github.com/google/uuid"
+)
+
+// fakePrivacyAPI records which account was erased
+type fakePrivacyAPI struct {
+	coreprivacy.API
+	erased uuid.UUID
+}
+
+func (f *fakePrivacyAPI) EraseAccount(_ context.Context, req coreprivacy.EraseAccountReq) error {
+	f.erased = req.UserID
+	return nil
+}
+
+func serveAs(svc coreprivacy.API, caller *utils.UserClaims, method, path string) *httptest.ResponseRecorder {
+	withCaller := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(w, r.WithContext(auth.SetClaimsInContext(r.Context(), caller)))
+		})
+	}
+	mux := http.NewServeMux()
+	New(svc, withCaller).SetupRoutes(mux)
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(method, path, nil))
+	return rec
+}
+
+func TestEraseAccountNeedsTheUsersOwnSession(t *testing.T) {
+	userID := uuid.New()
+	tests := []struct {
+		name   string
+		caller *utils.UserClaims
+		want   int
+		code   string
+	}{
+		{"own session", &utils.UserClaims{ID: userID, SessionID: "s"}, http.StatusNoContent, ""},
+		{"api key", &utils.UserClaims{ID: userID, APIKeyID: uuid.NewString()}, http.StatusForbidden, "session_required"},
+		{"impersonation", &utils.UserClaims{ID: userID, SessionID: "s", ImpersonatorID: uuid.NewString()}, http.StatusForbidden, "impersonation_not_allowed"},
+	}
+	for _, tt := range tests {
+		for _, path := range []string{"/users/me", "/users/" + userID.String()} {
+			svc := &fakePrivacyAPI{}
+			rec := serveAs(svc, tt.caller, http.MethodDelete, path)
+			if rec.Code != tt.want || !strings.Contains(rec.Body.String(), tt.code) {
+				t.Errorf("%s: DELETE %s = %d %s, want %d %s", tt.name, path, rec.Code, rec.Body, tt.want, tt.code)
+			}
+			if erased := svc.erased == userID; erased != (tt.want == http.StatusNoContent) {
+				t.Errorf("%s: DELETE %s erased = %v", tt.name, path, erased)
+			}
+		}
+	}
+}
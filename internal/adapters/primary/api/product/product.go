@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"net/http"
 
+	"github.com/frostnzx/go-ecommerce-api/internal/adapters/primary/api/httpio"
 	coreproduct "github.com/frostnzx/go-ecommerce-api/internal/core/services/product"
 	"github.com/google/uuid"
 )
@@ -35,11 +36,11 @@ func (h *Handler) SetupRoutes(mux *http.ServeMux) {
 
 // DTOs
 type addProductReq struct {
-	SKU         string  `json:"sku"`
-	Name        string  `json:"name"`
-	Description string  `json:"description"`
-	Price       float64 `json:"price"`
-	StockQty    int     `json:"stock_qty"`
+	SKU         string  `json:"sku" validate:"notblank,max=64"`
+	Name        string  `json:"name" validate:"notblank,max=200"`
+	Description string  `json:"description" validate:"max=2000"`
+	Price       float64 `json:"price" validate:"gt=0"`
+	StockQty    int     `json:"stock_qty" validate:"min=0"`
 }
 
 type addProductResp struct {
@@ -75,11 +76,11 @@ type listProductsResp struct {
 }
 
 type editProductReq struct {
-	SKU         string  `json:"sku"`
-	Name        string  `json:"name"`
-	Description string  `json:"description"`
-	Price       float64 `json:"price"`
-	StockQty    int     `json:"stock_qty"`
+	SKU         string  `json:"sku" validate:"notblank,max=64"`
+	Name        string  `json:"name" validate:"notblank,max=200"`
+	Description string  `json:"description" validate:"max=2000"`
+	Price       float64 `json:"price" validate:"gt=0"`
+	StockQty    int     `json:"stock_qty" validate:"min=0"`
 	Active      bool    `json:"active"`
 }
 
@@ -103,15 +104,14 @@ type editProductResp struct {
 // @Produce      json
 // @Param        request body addProductReq true "Product data"
 // @Success      201 {object} addProductResp
-// @Failure      400 {string} string "Invalid request"
-// @Failure      401 {string} string "Unauthorized"
-// @Failure      403 {string} string "Forbidden"
+// @Failure      400 {object} httpio.Problem "Invalid request"
+// @Failure      401 {object} httpio.Problem "Unauthorized"
+// @Failure      403 {object} httpio.Problem "Forbidden"
 // @Security     BearerAuth
 // @Router       /admin/products [post]
 func (h *Handler) AddProductHandler(w http.ResponseWriter, r *http.Request) {
 	var req addProductReq
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "invalid request", http.StatusBadRequest)
+	if !httpio.DecodeJSON(w, r, &req) {
 		return
 	}
 
@@ -125,7 +125,7 @@ func (h *Handler) AddProductHandler(w http.ResponseWriter, r *http.Request) {
 
 	res, err := h.svc.AddProduct(r.Context(), in)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		httpio.WriteError(w, err)
 		return
 	}
 
@@ -149,14 +149,15 @@ func (h *Handler) AddProductHandler(w http.ResponseWriter, r *http.Request) {
 // @Produce      json
 // @Param        id path string true "Product ID"
 // @Success      200 {object} getProductResp
-// @Failure      400 {string} string "Invalid request"
-// @Failure      404 {string} string "Not found"
+// @Failure      400 {object} httpio.Problem "Invalid request"
+// @Failure      404 {object} httpio.Problem "Not found"
+// @Failure      429 {object} httpio.Problem "Rate limited"
 // @Router       /products/{id} [get]
 func (h *Handler) GetProductHandler(w http.ResponseWriter, r *http.Request) {
 	idStr := r.PathValue("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		http.Error(w, "invalid product id", http.StatusBadRequest)
+		httpio.InvalidField(w, "id", "invalid product id")
 		return
 	}
 
@@ -166,7 +167,7 @@ func (h *Handler) GetProductHandler(w http.ResponseWriter, r *http.Request) {
 
 	res, err := h.svc.GetProduct(r.Context(), in)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusNotFound)
+		httpio.WriteError(w, err)
 		return
 	}
 
@@ -193,12 +194,13 @@ func (h *Handler) GetProductHandler(w http.ResponseWriter, r *http.Request) {
 // @Accept       json
 // @Produce      json
 // @Success      200 {object} listProductsResp
-// @Failure      500 {string} string "Internal server error"
+// @Failure      429 {object} httpio.Problem "Rate limited"
+// @Failure      500 {object} httpio.Problem "Internal server error"
 // @Router       /products [get]
 func (h *Handler) ListProductsHandler(w http.ResponseWriter, r *http.Request) {
 	res, err := h.svc.ListProducts(r.Context())
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		httpio.WriteError(w, err)
 		return
 	}
 
@@ -231,22 +233,21 @@ func (h *Handler) ListProductsHandler(w http.ResponseWriter, r *http.Request) {
 // @Param        id path string true "Product ID"
 // @Param        request body editProductReq true "Updated product data"
 // @Success      200 {object} editProductResp
-// @Failure      400 {string} string "Invalid request"
-// @Failure      401 {string} string "Unauthorized"
-// @Failure      403 {string} string "Forbidden"
+// @Failure      400 {object} httpio.Problem "Invalid request"
+// @Failure      401 {object} httpio.Problem "Unauthorized"
+// @Failure      403 {object} httpio.Problem "Forbidden"
 // @Security     BearerAuth
 // @Router       /admin/products/{id} [put]
 func (h *Handler) EditProductHandler(w http.ResponseWriter, r *http.Request) {
 	idStr := r.PathValue("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		http.Error(w, "invalid product id", http.StatusBadRequest)
+		httpio.InvalidField(w, "id", "invalid product id")
 		return
 	}
 
 	var req editProductReq
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "invalid request", http.StatusBadRequest)
+	if !httpio.DecodeJSON(w, r, &req) {
 		return
 	}
 
@@ -262,7 +263,7 @@ func (h *Handler) EditProductHandler(w http.ResponseWriter, r *http.Request) {
 
 	res, err := h.svc.EditProduct(r.Context(), in)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		httpio.WriteError(w, err)
 		return
 	}
 
@@ -289,17 +290,17 @@ func (h *Handler) EditProductHandler(w http.ResponseWriter, r *http.Request) {
 // @Produce      json
 // @Param        id path string true "Product ID"
 // @Success      204 {string} string "No Content"
-// @Failure      400 {string} string "Invalid request"
-// @Failure      401 {string} string "Unauthorized"
-// @Failure      403 {string} string "Forbidden"
-// @Failure      404 {string} string "Not found"
+// @Failure      400 {object} httpio.Problem "Invalid request"
+// @Failure      401 {object} httpio.Problem "Unauthorized"
+// @Failure      403 {object} httpio.Problem "Forbidden"
+// @Failure      404 {object} httpio.Problem "Not found"
 // @Security     BearerAuth
 // @Router       /admin/products/{id} [delete]
 func (h *Handler) DeleteProductHandler(w http.ResponseWriter, r *http.Request) {
 	idStr := r.PathValue("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		http.Error(w, "invalid product id", http.StatusBadRequest)
+		httpio.InvalidField(w, "id", "invalid product id")
 		return
 	}
 
@@ -309,7 +310,7 @@ func (h *Handler) DeleteProductHandler(w http.ResponseWriter, r *http.Request) {
 
 	err = h.svc.DeleteProduct(r.Context(), in)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusNotFound)
+		httpio.WriteError(w, err)
 		return
 	}
 
@@ -0,0 +1,135 @@
+package api
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/frostnzx/go-ecommerce-api/internal/adapters/primary/api/httpio"
+	"github.com/frostnzx/go-ecommerce-api/internal/config"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/services/apikey"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/utils"
+	"github.com/frostnzx/go-ecommerce-api/internal/logging"
+	"github.com/frostnzx/go-ecommerce-api/internal/ratelimit"
+)
+
+// defaultPolicy names the policy of routes without one of their own
+const defaultPolicy = "default"
+
+// rateLimitPolicy is a configured policy ready to be applied
+type rateLimitPolicy struct {
+	name   string
+	limit  ratelimit.Limit
+	byUser bool
+}
+
+func newRateLimitPolicy(name string, p config.RateLimitPolicy) *rateLimitPolicy {
+	if p.Requests == 0 {
+		return nil
+	}
+	return &rateLimitPolicy{
+		name:   name,
+		limit:  ratelimit.Limit{Requests: p.Requests, Period: p.Period.Duration, Burst: p.Burst},
+		byUser: p.Key == "user",
+	}
+}
+
+// RateLimitMiddleware counts each request against the policy of its ServeMux
+// route, or the default policy, with one token bucket per policy and caller.
+// Callers are told their budget through the RateLimit-* headers and get a 429
+// with Retry-After once it is spent. When the store fails the request is let
+// through rather than taking the API down with it.
+func RateLimitMiddleware(mux *http.ServeMux, cfg config.RateLimitConfig, store ratelimit.Store, tokenMaker *utils.JWTMaker, apiKeyAPI apikey.API) func(http.Handler) http.Handler {
+	fallback := newRateLimitPolicy(defaultPolicy, cfg.Default)
+	routes := make(map[string]*rateLimitPolicy, len(cfg.Routes))
+	for route, p := range cfg.Routes {
+		routes[route] = newRateLimitPolicy(route, p)
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			policy := fallback
+			if _, pattern := mux.Handler(r); pattern != "" {
+				if p, ok := routes[pattern]; ok {
+					policy = p
+				}
+			}
+			if policy == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			subject, r := rateLimitSubject(r, policy.byUser, tokenMaker, apiKeyAPI)
+			key := policy.name + "|" + subject
+			res, err := store.Allow(r.Context(), key, policy.limit)
+			if err != nil {
+				logging.FromContext(r.Context()).Warn("rate limit store failed, allowing request", "policy", policy.name, "error", err)
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			h := w.Header()
+			h.Set("RateLimit-Policy", strconv.Itoa(policy.limit.Capacity())+";w="+strconv.Itoa(ceilSeconds(policy.limit.Period)))
+			h.Set("RateLimit-Limit", strconv.Itoa(res.Limit))
+			h.Set("RateLimit-Remaining", strconv.Itoa(res.Remaining))
+			h.Set("RateLimit-Reset", strconv.Itoa(ceilSeconds(res.Reset)))
+			if !res.Allowed {
+				retry := ceilSeconds(res.RetryAfter)
+				h.Set("Retry-After", strconv.Itoa(retry))
+				httpio.Error(w, http.StatusTooManyRequests, httpio.CodeRateLimited, "too many requests, retry in "+strconv.Itoa(retry)+"s")
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// rateLimitSubject identifies the caller: by user policies count the user behind
+// a valid access token or a verified API key, and everyone else, including
+// callers with an unknown key, is counted by client IP so that made-up keys
+// cannot buy fresh budgets. The token is only checked for its signature here;
+// the auth middleware still verifies the session. A verified key is passed on in
+// the returned request so the auth middleware does not look it up again.
+func rateLimitSubject(r *http.Request, byUser bool, tokenMaker *utils.JWTMaker, apiKeyAPI apikey.API) (string, *http.Request) {
+	if byUser {
+		if key, ok := apiKeyFromRequest(r); ok {
+			if apiKeyAPI != nil {
+				res, err := apiKeyAPI.AuthenticateAPIKey(r.Context(), apikey.AuthenticateAPIKeyReq{Key: key})
+				if err == nil {
+					ctx := context.WithValue(r.Context(), verifiedAPIKeyKey{}, verifiedAPIKey{key: key, res: res})
+					return "key:" + res.KeyID.String(), r.WithContext(ctx)
+				}
+			}
+			return "ip:" + httpio.ClientIP(r), r
+		}
+		if tokenMaker != nil {
+			if claims, err := verifyClaimsFromAuthHeader(r, tokenMaker); err == nil {
+				return "user:" + claims.ID.String(), r
+			}
+		}
+	}
+	return "ip:" + httpio.ClientIP(r), r
+}
+
+type verifiedAPIKeyKey struct{}
+
+// verifiedAPIKey is an API key the rate limiter has already authenticated
+type verifiedAPIKey struct {
+	key string
+	res *apikey.AuthenticateAPIKeyResp
+}
+
+// verifiedAPIKeyFromContext returns the result for key if the rate limiter verified it
+func verifiedAPIKeyFromContext(ctx context.Context, key string) (*apikey.AuthenticateAPIKeyResp, bool) {
+	v, ok := ctx.Value(verifiedAPIKeyKey{}).(verifiedAPIKey)
+	if !ok || v.key != key {
+		return nil, false
+	}
+	return v.res, true
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
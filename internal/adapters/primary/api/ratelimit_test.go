@@ -0,0 +1,237 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/frostnzx/go-ecommerce-api/internal/adapters/primary/api/httpio"
+	"github.com/frostnzx/go-ecommerce-api/internal/adapters/secondary/memory"
+	"github.com/frostnzx/go-ecommerce-api/internal/config"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/user"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/services/apikey"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/utils"
+	"github.com/frostnzx/go-ecommerce-api/internal/ratelimit"
+	"github.com/google/uuid"
+)
+
+func rateLimitedMux(t *testing.T, store ratelimit.Store, tokenMaker *utils.JWTMaker, apiKeyAPI apikey.API) http.Handler {
+	t.Helper()
+	mux := http.NewServeMux()
+	for _, pattern := range []string{"POST /auth/login", "GET /orders", "GET /healthz"} {
+		mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusNoContent) })
+	}
+	cfg := config.RateLimitConfig{
+		Enabled: true,
+		Default: config.RateLimitPolicy{Requests: 2, Period: config.Duration{Duration: time.Minute}, Key: "user"},
+		Routes: map[string]config.RateLimitPolicy{
+			"POST /auth/login": {Requests: 1, Period: config.Duration{Duration: time.Minute}, Key: "ip"},
+			"GET /healthz":     {},
+		},
+	}
+	return RateLimitMiddleware(mux, cfg, store, tokenMaker, apiKeyAPI)(mux)
+}
+
+func send(h http.Handler, method, path, remoteAddr string, header http.Header) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, nil)
+	req.RemoteAddr = remoteAddr
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestRateLimitPerRoutePolicyByIP(t *testing.T) {
+	h := rateLimitedMux(t, ratelimit.NewMemoryStore(), nil, nil)
+
+	rec := send(h, http.MethodPost, "/auth/login", "10.0.0.1:5000", nil)
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("first login = %d, want it allowed", rec.Code)
+	}
+	for header, want := range map[string]string{"RateLimit-Limit": "1", "RateLimit-Remaining": "0", "RateLimit-Reset": "60", "RateLimit-Policy": "1;w=60"} {
+		if got := rec.Header().Get(header); got != want {
+			t.Errorf("%s = %q, want %q", header, got, want)
+		}
+	}
+
+	rec = send(h, http.MethodPost, "/auth/login", "10.0.0.1:5001", nil)
+	if rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("second login from the same IP = %d, want 429", rec.Code)
+	}
+	if got := rec.Header().Get("Retry-After"); got != "60" {
+		t.Errorf("Retry-After = %q, want 60", got)
+	}
+	var p httpio.Problem
+	if err := json.NewDecoder(rec.Body).Decode(&p); err != nil || p.Code != httpio.CodeRateLimited {
+		t.Errorf("problem = %+v (%v), want code %s", p, err, httpio.CodeRateLimited)
+	}
+
+	if rec := send(h, http.MethodPost, "/auth/login", "10.0.0.2:5000", nil); rec.Code != http.StatusNoContent {
+		t.Errorf("login from another IP = %d, want it allowed", rec.Code)
+	}
+	if rec := send(h, http.MethodGet, "/orders", "10.0.0.1:5000", nil); rec.Code != http.StatusNoContent {
+		t.Errorf("another route = %d, want its own budget", rec.Code)
+	}
+}
+
+func TestRateLimitByIPBehindTrustedProxy(t *testing.T) {
+	trusted := []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")}
+	h := httpio.ClientIPMiddleware(trusted)(rateLimitedMux(t, ratelimit.NewMemoryStore(), nil, nil))
+	forwarded := func(ip string) http.Header { return http.Header{"X-Forwarded-For": {ip}} }
+
+	if rec := send(h, http.MethodPost, "/auth/login", "10.0.0.1:5000", forwarded("203.0.113.1")); rec.Code != http.StatusNoContent {
+		t.Fatalf("first client = %d, want it allowed", rec.Code)
+	}
+	if rec := send(h, http.MethodPost, "/auth/login", "10.0.0.1:5000", forwarded("203.0.113.2")); rec.Code != http.StatusNoContent {
+		t.Errorf("second client behind the same proxy = %d, want its own budget", rec.Code)
+	}
+	if rec := send(h, http.MethodPost, "/auth/login", "10.0.0.1:5000", forwarded("203.0.113.1")); rec.Code != http.StatusTooManyRequests {
+		t.Errorf("first client again = %d, want 429", rec.Code)
+	}
+	// A direct client cannot pick a fresh bucket by making up the header
+	if rec := send(h, http.MethodPost, "/auth/login", "198.51.100.1:5000", forwarded("203.0.113.3")); rec.Code != http.StatusNoContent {
+		t.Fatalf("direct client = %d, want it allowed", rec.Code)
+	}
+	if rec := send(h, http.MethodPost, "/auth/login", "198.51.100.1:5000", forwarded("203.0.113.4")); rec.Code != http.StatusTooManyRequests {
+		t.Errorf("direct client with a spoofed header = %d, want 429", rec.Code)
+	}
+}
+
+func TestRateLimitExemptRoute(t *testing.T) {
+	h := rateLimitedMux(t, ratelimit.NewMemoryStore(), nil, nil)
+	for range 5 {
+		rec := send(h, http.MethodGet, "/healthz", "10.0.0.1:5000", nil)
+		if rec.Code != http.StatusNoContent || rec.Header().Get("RateLimit-Limit") != "" {
+			t.Fatalf("GET /healthz = %d with RateLimit-Limit %q, want it unlimited", rec.Code, rec.Header().Get("RateLimit-Limit"))
+		}
+	}
+}
+
+func TestRateLimitDefaultPolicyByCaller(t *testing.T) {
+	maker := newTestTokenMaker(t)
+	alice, _, _ := maker.CreateToken(uuid.NewString(), uuid.New(), "alice@example.com", false, time.Minute)
+	bob, _, _ := maker.CreateToken(uuid.NewString(), uuid.New(), "bob@example.com", false, time.Minute)
+	keys, owner := newTestAPIKeys(t)
+	key := mustAPIKey(t, keys, owner)
+	h := rateLimitedMux(t, ratelimit.NewMemoryStore(), maker, keys)
+
+	// Everyone behind one NAT address: each user still gets their own budget
+	const addr = "192.0.2.1:4000"
+	asAlice := http.Header{"Authorization": {"Bearer " + alice}}
+	for range 2 {
+		if rec := send(h, http.MethodGet, "/orders", addr, asAlice); rec.Code != http.StatusNoContent {
+			t.Fatalf("alice = %d, want allowed", rec.Code)
+		}
+	}
+	if rec := send(h, http.MethodGet, "/orders", addr, asAlice); rec.Code != http.StatusTooManyRequests {
+		t.Errorf("alice's third request = %d, want 429", rec.Code)
+	}
+	if rec := send(h, http.MethodGet, "/orders", addr, http.Header{"Authorization": {"Bearer " + bob}}); rec.Code != http.StatusNoContent {
+		t.Errorf("bob = %d, want his own budget", rec.Code)
+	}
+	if rec := send(h, http.MethodGet, "/orders", addr, http.Header{"X-Api-Key": {key}}); rec.Code != http.StatusNoContent {
+		t.Errorf("api key = %d, want its own budget", rec.Code)
+	}
+	if rec := send(h, http.MethodGet, "/orders", addr, nil); rec.Code != http.StatusNoContent {
+		t.Errorf("anonymous = %d, want counted by IP", rec.Code)
+	}
+}
+
+func TestRateLimitCountsUnknownAPIKeysByIP(t *testing.T) {
+	keys, owner := newTestAPIKeys(t)
+	key := mustAPIKey(t, keys, owner)
+	h := rateLimitedMux(t, ratelimit.NewMemoryStore(), nil, keys)
+
+	// Made-up keys must not each buy a fresh budget
+	const addr = "192.0.2.1:4000"
+	for i := range 2 {
+		if rec := send(h, http.MethodGet, "/orders", addr, http.Header{"X-Api-Key": {"ecom_" + uuid.NewString()}}); rec.Code != http.StatusNoContent {
+			t.Fatalf("random key %d = %d, want allowed", i, rec.Code)
+		}
+	}
+	if rec := send(h, http.MethodGet, "/orders", addr, http.Header{"Authorization": {"ApiKey ecom_" + uuid.NewString()}}); rec.Code != http.StatusTooManyRequests {
+		t.Errorf("third random key from the same IP = %d, want 429", rec.Code)
+	}
+	if rec := send(h, http.MethodGet, "/orders", addr, nil); rec.Code != http.StatusTooManyRequests {
+		t.Errorf("anonymous after random keys = %d, want 429 from the shared IP budget", rec.Code)
+	}
+	if rec := send(h, http.MethodGet, "/orders", addr, http.Header{"X-Api-Key": {key}}); rec.Code != http.StatusNoContent {
+		t.Errorf("valid key from the same IP = %d, want its own budget", rec.Code)
+	}
+}
+
+func TestRateLimitPassesVerifiedAPIKeyToAuth(t *testing.T) {
+	keys, owner := newTestAPIKeys(t)
+	key := mustAPIKey(t, keys, owner)
+	counted := &countingAPIKeys{API: keys}
+
+	mux := http.NewServeMux()
+	mux.Handle("GET /orders", GetAuthMiddlewareFunc(newTestTokenMaker(t), nil, counted)(noContent))
+	cfg := config.RateLimitConfig{
+		Enabled: true,
+		Default: config.RateLimitPolicy{Requests: 5, Period: config.Duration{Duration: time.Minute}, Key: "user"},
+	}
+	h := RateLimitMiddleware(mux, cfg, ratelimit.NewMemoryStore(), nil, counted)(mux)
+
+	if rec := send(h, http.MethodGet, "/orders", "192.0.2.1:4000", http.Header{"X-Api-Key": {key}}); rec.Code != http.StatusNoContent {
+		t.Fatalf("status = %d, want 204", rec.Code)
+	}
+	if counted.calls != 1 {
+		t.Fatalf("key looked up %d times, want once", counted.calls)
+	}
+}
+
+// countingAPIKeys counts how often keys are looked up
+type countingAPIKeys struct {
+	apikey.API
+	calls int
+}
+
+func (c *countingAPIKeys) AuthenticateAPIKey(ctx context.Context, req apikey.AuthenticateAPIKeyReq) (*apikey.AuthenticateAPIKeyResp, error) {
+	c.calls++
+	return c.API.AuthenticateAPIKey(ctx, req)
+}
+
+func newTestAPIKeys(t *testing.T) (*apikey.Service, uuid.UUID) {
+	t.Helper()
+	s := memory.NewStore()
+	users, _ := memory.NewUserRepo(s)
+	keyRepo, _ := memory.NewAPIKeyRepo(s)
+	auditLog, _ := memory.NewAuditRepo(s)
+	u := user.New("ci@example.com", "hash", "CI", false)
+	if err := users.Create(context.Background(), u); err != nil {
+		t.Fatal(err)
+	}
+	return apikey.NewService(keyRepo, users, auditLog), u.ID
+}
+
+func mustAPIKey(t *testing.T, keys *apikey.Service, owner uuid.UUID) string {
+	t.Helper()
+	res, err := keys.CreateAPIKey(context.Background(), apikey.CreateAPIKeyReq{UserID: owner, Name: "CI", Scopes: []string{"read"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return res.Key
+}
+
+type failingStore struct{}
+
+func (failingStore) Allow(context.Context, string, ratelimit.Limit) (ratelimit.Result, error) {
+	return ratelimit.Result{}, errors.New("store unavailable")
+}
+
+func TestRateLimitFailsOpen(t *testing.T) {
+	h := rateLimitedMux(t, failingStore{}, nil, nil)
+	for range 3 {
+		if rec := send(h, http.MethodPost, "/auth/login", "10.0.0.1:5000", nil); rec.Code != http.StatusNoContent {
+			t.Fatalf("login with a failing store = %d, want it allowed", rec.Code)
+		}
+	}
+}
@@ -1,78 +1,198 @@
 package api
 
 import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"log/slog"
 	"net/http"
-	"os"
+	"sync/atomic"
+	"time"
 
+	"github.com/frostnzx/go-ecommerce-api/internal/config"
 	"github.com/frostnzx/go-ecommerce-api/internal/core/services/address"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/services/apikey"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/services/idempotency"
 	"github.com/frostnzx/go-ecommerce-api/internal/core/services/items"
 	"github.com/frostnzx/go-ecommerce-api/internal/core/services/order"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/services/privacy"
 	"github.com/frostnzx/go-ecommerce-api/internal/core/services/product"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/services/session"
 	"github.com/frostnzx/go-ecommerce-api/internal/core/services/user"
 	"github.com/frostnzx/go-ecommerce-api/internal/core/utils"
+	"github.com/frostnzx/go-ecommerce-api/internal/metrics"
+	"github.com/frostnzx/go-ecommerce-api/internal/ratelimit"
 
 	addresshandler "github.com/frostnzx/go-ecommerce-api/internal/adapters/primary/api/address"
+	apikeyhandler "github.com/frostnzx/go-ecommerce-api/internal/adapters/primary/api/apikey"
+	"github.com/frostnzx/go-ecommerce-api/internal/adapters/primary/api/httpio"
 	itemshandler "github.com/frostnzx/go-ecommerce-api/internal/adapters/primary/api/items"
 	orderhandler "github.com/frostnzx/go-ecommerce-api/internal/adapters/primary/api/order"
+	privacyhandler "github.com/frostnzx/go-ecommerce-api/internal/adapters/primary/api/privacy"
 	producthandler "github.com/frostnzx/go-ecommerce-api/internal/adapters/primary/api/product"
+	sessionhandler "github.com/frostnzx/go-ecommerce-api/internal/adapters/primary/api/session"
 	userhandler "github.com/frostnzx/go-ecommerce-api/internal/adapters/primary/api/user"
 
 	httpSwagger "github.com/swaggo/http-swagger/v2"
 )
 
 type App struct {
-	server     *http.Server
-	userAPI    user.API
-	orderAPI   order.API
-	addressAPI address.API
-	productAPI product.API
-	itemsAPI   items.API
+	server *http.Server
+	cfg    config.ServerConfig
+	// shuttingDown fails /readyz from the moment shutdown begins so load balancers stop routing here
+	shuttingDown   *atomic.Bool
+	logger         *slog.Logger
+	userAPI        user.API
+	sessionAPI     session.API
+	orderAPI       order.API
+	addressAPI     address.API
+	productAPI     product.API
+	itemsAPI       items.API
+	apiKeyAPI      apikey.API
+	privacyAPI     privacy.API
+	idempotencyAPI idempotency.API
 }
 
-func NewApp(userAPI user.API, orderAPI order.API, addressAPI address.API, productAPI product.API, itemsAPI items.API, addr string) *App {
+func NewApp(userAPI user.API, sessionAPI session.API, orderAPI order.API, addressAPI address.API, productAPI product.API, itemsAPI items.API, apiKeyAPI apikey.API, privacyAPI privacy.API, idempotencyAPI idempotency.API, tokenMaker *utils.JWTMaker, cfg config.ServerConfig, logger *slog.Logger, m *metrics.Metrics, limits ratelimit.Store, checks ...HealthCheck) *App {
+	if logger == nil {
+		logger = slog.Default()
+	}
 	mux := http.NewServeMux()
 
+	// Liveness and readiness probes
+	shuttingDown := &atomic.Bool{}
+	mux.HandleFunc("GET /healthz", livenessHandler)
+	mux.HandleFunc("GET /readyz", readinessHandler(checks, cfg.ReadinessTimeout.Duration, shuttingDown))
+
 	// Swagger documentation route
 	mux.Handle("GET /swagger/", httpSwagger.Handler(
 		httpSwagger.URL("/swagger/doc.json"),
 	))
 
-	// Create JWT maker and auth middleware
-	secretKey := os.Getenv("JWT_SECRET")
-	tokenMaker := utils.NewJWTMaker(secretKey)
-	authMiddleware := GetAuthMiddlewareFunc(tokenMaker)
-	adminMiddleware := GetAdminMiddlewareFunc(tokenMaker)
+	// Prometheus metrics
+	if m != nil {
+		mux.Handle("GET /metrics", metricsHandler(m, cfg.MetricsToken))
+	}
+
+	// Public keys for verifying our tokens
+	mux.HandleFunc("GET /.well-known/jwks.json", GetJWKSHandlerFunc(tokenMaker))
+
+	// Create auth middleware
+	authenticated := GetAuthMiddlewareFunc(tokenMaker, sessionAPI, apiKeyAPI)
+	auditImpersonation := GetImpersonationAuditMiddlewareFunc(userAPI)
+	authMiddleware := func(next http.Handler) http.Handler {
+		return authenticated(auditImpersonation(next))
+	}
+	adminMiddleware := GetAdminMiddlewareFunc(tokenMaker, sessionAPI, apiKeyAPI)
+	idempotencyMiddleware := GetIdempotencyMiddlewareFunc(idempotencyAPI)
 
 	// Compose handlers with core services and middleware
 	uHandler := userhandler.New(userAPI, authMiddleware, adminMiddleware)
 	uHandler.SetupRoutes(mux)
 
-	oHandler := orderhandler.New(orderAPI, authMiddleware, adminMiddleware)
+	sHandler := sessionhandler.New(sessionAPI, authMiddleware, adminMiddleware)
+	sHandler.SetupRoutes(mux)
+
+	oHandler := orderhandler.New(orderAPI, authMiddleware, adminMiddleware, idempotencyMiddleware)
 	oHandler.SetupRoutes(mux)
 
 	pHandler := producthandler.New(productAPI, authMiddleware, adminMiddleware)
 	pHandler.SetupRoutes(mux)
 
-	aHandler := addresshandler.New(addressAPI, authMiddleware)
+	aHandler := addresshandler.New(addressAPI, authMiddleware, adminMiddleware)
 	aHandler.SetupRoutes(mux)
 
 	iHandler := itemshandler.New(itemsAPI, authMiddleware)
 	iHandler.SetupRoutes(mux)
 
+	kHandler := apikeyhandler.New(apiKeyAPI, authMiddleware, adminMiddleware)
+	kHandler.SetupRoutes(mux)
+
+	gHandler := privacyhandler.New(privacyAPI, authMiddleware)
+	gHandler.SetupRoutes(mux)
+
+	var handler http.Handler = mux
+	if cfg.RateLimit.Enabled {
+		if limits == nil {
+			limits = ratelimit.NewMemoryStore()
+		}
+		handler = RateLimitMiddleware(mux, cfg.RateLimit, limits, tokenMaker, apiKeyAPI)(handler)
+	}
+	if m != nil {
+		handler = MetricsMiddleware(mux, m)(handler)
+	}
+	handler = AccessLogMiddleware(handler)
+	handler = TracingMiddleware(mux)(handler)
+	// Resolved before the rate limiter and handlers read it; the proxies were validated with the config
+	trustedProxies, _ := cfg.TrustedProxyPrefixes()
+	handler = httpio.ClientIPMiddleware(trustedProxies)(handler)
+	handler = RequestIDMiddleware(logger)(handler)
+
 	srv := &http.Server{
-		Addr:    addr,
-		Handler: mux,
+		Addr:              cfg.Addr(),
+		Handler:           handler,
+		ReadTimeout:       cfg.ReadTimeout.Duration,
+		ReadHeaderTimeout: cfg.ReadHeaderTimeout.Duration,
+		WriteTimeout:      cfg.WriteTimeout.Duration,
+		IdleTimeout:       cfg.IdleTimeout.Duration,
+		MaxHeaderBytes:    cfg.MaxHeaderBytes,
+		TLSConfig:         &tls.Config{MinVersion: tls.VersionTLS12},
+		ErrorLog:          slog.NewLogLogger(logger.Handler(), slog.LevelWarn),
 	}
 	return &App{
-		server:     srv,
-		userAPI:    userAPI,
-		orderAPI:   orderAPI,
-		addressAPI: addressAPI,
-		productAPI: productAPI,
-		itemsAPI:   itemsAPI,
+		server:         srv,
+		cfg:            cfg,
+		shuttingDown:   shuttingDown,
+		logger:         logger,
+		userAPI:        userAPI,
+		sessionAPI:     sessionAPI,
+		orderAPI:       orderAPI,
+		addressAPI:     addressAPI,
+		productAPI:     productAPI,
+		itemsAPI:       itemsAPI,
+		apiKeyAPI:      apiKeyAPI,
+		privacyAPI:     privacyAPI,
+		idempotencyAPI: idempotencyAPI,
 	}
 }
 
-func (a *App) Run() error {
-	return a.server.ListenAndServe()
+// Run serves until ctx is cancelled, then stops accepting connections and gives
+// in-flight requests up to the configured shutdown timeout to finish
+func (a *App) Run(ctx context.Context) error {
+	errCh := make(chan error, 1)
+	go func() {
+		if a.cfg.TLS() {
+			a.logger.Info("starting server", "addr", a.server.Addr, "tls", true)
+			errCh <- a.server.ListenAndServeTLS(a.cfg.TLSCertFile, a.cfg.TLSKeyFile)
+		} else {
+			a.logger.Info("starting server", "addr", a.server.Addr, "tls", false)
+			errCh <- a.server.ListenAndServe()
+		}
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+	}
+
+	// Report not ready first and give load balancers the drain delay to notice
+	// before the listener closes
+	a.shuttingDown.Store(true)
+	if a.cfg.DrainDelay.Duration > 0 {
+		a.logger.Info("draining before shutdown", "delay", a.cfg.DrainDelay.Duration.String())
+		time.Sleep(a.cfg.DrainDelay.Duration)
+	}
+
+	a.logger.Info("shutting down server", "drain_timeout", a.cfg.ShutdownTimeout.Duration.String())
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), a.cfg.ShutdownTimeout.Duration)
+	defer cancel()
+	if err := a.server.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("error shutting down server: %w", err)
+	}
+	if err := <-errCh; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
 }
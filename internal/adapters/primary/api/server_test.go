@@ -0,0 +1,62 @@
+package api
+
+import (
+	"context"
+	"log/slog"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/frostnzx/go-ecommerce-api/internal/config"
+)
+
+func TestRunDrainsInFlightRequestsOnShutdown(t *testing.T) {
+	cfg := config.Default().Server
+	cfg.ShutdownTimeout = config.Duration{Duration: 5 * time.Second}
+	app := NewApp(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, cfg, slog.New(slog.DiscardHandler), nil, nil)
+
+	started := make(chan struct{})
+	app.server.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		time.Sleep(50 * time.Millisecond)
+		w.WriteHeader(http.StatusNoContent)
+	})
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	app.server.Addr = ln.Addr().String()
+	ln.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	runErr := make(chan error, 1)
+	go func() { runErr <- app.Run(ctx) }()
+
+	respCh := make(chan *http.Response, 1)
+	go func() {
+		var resp *http.Response
+		var err error
+		for i := 0; i < 100; i++ {
+			if resp, err = http.Get("http://" + app.server.Addr); err == nil {
+				break
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		respCh <- resp
+	}()
+
+	<-started
+	cancel()
+	resp := <-respCh
+	if resp == nil {
+		t.Fatal("request failed")
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNoContent {
+		t.Errorf("status = %d, want the in-flight request to complete", resp.StatusCode)
+	}
+	if err := <-runErr; err != nil {
+		t.Errorf("Run = %v, want a clean shutdown", err)
+	}
+}
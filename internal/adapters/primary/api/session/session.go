@@ -0,0 +1,237 @@
+package session
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/frostnzx/go-ecommerce-api/internal/adapters/primary/api/auth"
+	"github.com/frostnzx/go-ecommerce-api/internal/adapters/primary/api/httpio"
+	domainsession "github.com/frostnzx/go-ecommerce-api/internal/core/domain/session"
+	coresession "github.com/frostnzx/go-ecommerce-api/internal/core/services/session"
+	"github.com/google/uuid"
+)
+
+type Handler struct {
+	svc             coresession.API
+	authMiddleware  func(http.Handler) http.Handler
+	adminMiddleware func(http.Handler) http.Handler
+}
+
+func New(svc coresession.API, authMiddleware, adminMiddleware func(http.Handler) http.Handler) *Handler {
+	return &Handler{
+		svc:             svc,
+		authMiddleware:  authMiddleware,
+		adminMiddleware: adminMiddleware,
+	}
+}
+
+func (h *Handler) SetupRoutes(mux *http.ServeMux) {
+	// Protected routes (auth required)
+	mux.Handle("GET /users/me/sessions", h.authMiddleware(http.HandlerFunc(h.ListMySessionsHandler)))
+	mux.Handle("DELETE /users/me/sessions", h.authMiddleware(http.HandlerFunc(h.RevokeOtherSessionsHandler)))
+	mux.Handle("DELETE /users/me/sessions/{id}", h.authMiddleware(http.HandlerFunc(h.RevokeMySessionHandler)))
+
+	// Admin routes (admin only)
+	mux.Handle("GET /admin/users/{id}/sessions", h.adminMiddleware(http.HandlerFunc(h.ListUserSessionsHandler)))
+	mux.Handle("DELETE /admin/users/{id}/sessions", h.adminMiddleware(http.HandlerFunc(h.RevokeUserSessionsHandler)))
+}
+
+// DTOs
+type sessionInfoResp struct {
+	ID         string    `json:"id"`
+	UserAgent  string    `json:"user_agent"`
+	IPAddress  string    `json:"ip_address"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastUsedAt time.Time `json:"last_used_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	Current    bool      `json:"current"`
+}
+
+type listSessionsResp struct {
+	Sessions []sessionInfoResp `json:"sessions"`
+}
+
+type revokeSessionsResp struct {
+	Revoked int `json:"revoked"`
+}
+
+// Handlers
+
+// ListMySessionsHandler godoc
+// @Summary      List my sessions
+// @Description  Get the active sessions (logged in devices) of the authenticated user
+// @Tags         Sessions
+// @Accept       json
+// @Produce      json
+// @Success      200 {object} listSessionsResp
+// @Failure      401 {object} httpio.Problem "Unauthorized"
+// @Failure      500 {object} httpio.Problem "Internal server error"
+// @Security     BearerAuth
+// @Router       /users/me/sessions [get]
+func (h *Handler) ListMySessionsHandler(w http.ResponseWriter, r *http.Request) {
+	claims, ok := auth.GetClaimsFromContext(r.Context())
+	if !ok {
+		httpio.Error(w, http.StatusUnauthorized, httpio.CodeUnauthorized, "authentication required")
+		return
+	}
+
+	sessions, err := h.svc.ListUserSessions(r.Context(), claims.ID)
+	if err != nil {
+		httpio.WriteError(w, err)
+		return
+	}
+
+	resp := toListSessionsResp(sessions, claims.SessionID)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// RevokeMySessionHandler godoc
+// @Summary      Revoke one of my sessions
+// @Description  Log out a single session of the authenticated user
+// @Tags         Sessions
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "Session ID"
+// @Success      204 {string} string "No Content"
+// @Failure      401 {object} httpio.Problem "Unauthorized"
+// @Failure      403 {object} httpio.Problem "Not allowed while impersonating"
+// @Failure      404 {object} httpio.Problem "Not found"
+// @Security     BearerAuth
+// @Router       /users/me/sessions/{id} [delete]
+func (h *Handler) RevokeMySessionHandler(w http.ResponseWriter, r *http.Request) {
+	claims, ok := auth.GetClaimsFromContext(r.Context())
+	if !ok {
+		httpio.Error(w, http.StatusUnauthorized, httpio.CodeUnauthorized, "authentication required")
+		return
+	}
+	if !auth.NotImpersonating(w, claims) {
+		return
+	}
+
+	err := h.svc.RevokeUserSession(r.Context(), claims.ID, r.PathValue("id"))
+	if err != nil {
+		httpio.WriteError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RevokeOtherSessionsHandler godoc
+// @Summary      Log out everywhere else
+// @Description  Revoke every session of the authenticated user except the current one
+// @Tags         Sessions
+// @Accept       json
+// @Produce      json
+// @Success      200 {object} revokeSessionsResp
+// @Failure      401 {object} httpio.Problem "Unauthorized"
+// @Failure      403 {object} httpio.Problem "Not allowed while impersonating"
+// @Failure      500 {object} httpio.Problem "Internal server error"
+// @Security     BearerAuth
+// @Router       /users/me/sessions [delete]
+func (h *Handler) RevokeOtherSessionsHandler(w http.ResponseWriter, r *http.Request) {
+	claims, ok := auth.GetClaimsFromContext(r.Context())
+	if !ok {
+		httpio.Error(w, http.StatusUnauthorized, httpio.CodeUnauthorized, "authentication required")
+		return
+	}
+	if !auth.NotImpersonating(w, claims) {
+		return
+	}
+
+	n, err := h.svc.RevokeUserSessions(r.Context(), claims.ID, claims.SessionID)
+	if err != nil {
+		httpio.WriteError(w, err)
+		return
+	}
+
+	resp := revokeSessionsResp{Revoked: n}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// ListUserSessionsHandler godoc
+// @Summary      List a user's sessions (Admin)
+// @Description  Get the active sessions of any user (admin only)
+// @Tags         Admin
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "User ID"
+// @Success      200 {object} listSessionsResp
+// @Failure      400 {object} httpio.Problem "Invalid request"
+// @Failure      401 {object} httpio.Problem "Unauthorized"
+// @Failure      403 {object} httpio.Problem "Forbidden"
+// @Failure      500 {object} httpio.Problem "Internal server error"
+// @Security     BearerAuth
+// @Router       /admin/users/{id}/sessions [get]
+func (h *Handler) ListUserSessionsHandler(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		httpio.InvalidField(w, "id", "invalid user id")
+		return
+	}
+
+	sessions, err := h.svc.ListUserSessions(r.Context(), userID)
+	if err != nil {
+		httpio.WriteError(w, err)
+		return
+	}
+
+	resp := toListSessionsResp(sessions, "")
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// RevokeUserSessionsHandler godoc
+// @Summary      Force logout a user (Admin)
+// @Description  Revoke every session of a user (admin only)
+// @Tags         Admin
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "User ID"
+// @Success      200 {object} revokeSessionsResp
+// @Failure      400 {object} httpio.Problem "Invalid request"
+// @Failure      401 {object} httpio.Problem "Unauthorized"
+// @Failure      403 {object} httpio.Problem "Forbidden"
+// @Failure      500 {object} httpio.Problem "Internal server error"
+// @Security     BearerAuth
+// @Router       /admin/users/{id}/sessions [delete]
+func (h *Handler) RevokeUserSessionsHandler(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		httpio.InvalidField(w, "id", "invalid user id")
+		return
+	}
+
+	n, err := h.svc.RevokeUserSessions(r.Context(), userID, "")
+	if err != nil {
+		httpio.WriteError(w, err)
+		return
+	}
+
+	resp := revokeSessionsResp{Revoked: n}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(resp)
+}
+
+func toListSessionsResp(sessions []domainsession.Session, currentSessionID string) listSessionsResp {
+	infos := make([]sessionInfoResp, 0, len(sessions))
+	for _, s := range sessions {
+		infos = append(infos, sessionInfoResp{
+			ID:         s.ID,
+			UserAgent:  s.UserAgent,
+			IPAddress:  s.IPAddress,
+			CreatedAt:  s.CreatedAt,
+			LastUsedAt: s.LastUsedAt,
+			ExpiresAt:  s.ExpiresAt,
+			Current:    s.ID == currentSessionID,
+		})
+	}
+	return listSessionsResp{Sessions: infos}
+}
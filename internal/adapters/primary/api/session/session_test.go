@@ -0,0 +1,181 @@
+package session
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/frostnzx/go-ecommerce-api/internal/adapters/primary/api/auth"
+	"github.com/frostnzx/go-ecommerce-api/internal/adapters/secondary/memory"
+	domainsession "github.com/frostnzx/go-ecommerce-api/internal/core/domain/session"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/user"
+	coresession "github.com/frostnzx/go-ecommerce-api/internal/core/services/session"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/utils"
+	"github.com/google/uuid"
+)
+
+type fixture struct {
+	svc   *coresession.Service
+	users *memory.UserRepo
+	repo  *memory.SessionRepo
+}
+
+func newFixture(t *testing.T) fixture {
+	t.Helper()
+	s := memory.NewStore()
+	users, _ := memory.NewUserRepo(s)
+	repo, _ := memory.NewSessionRepo(s)
+	return fixture{svc: coresession.NewService(repo), users: users, repo: repo}
+}
+
+func (f fixture) createUser(t *testing.T, email string) user.User {
+	t.Helper()
+	u := user.New(email, "hash", "User", false)
+	if err := f.users.Create(context.Background(), u); err != nil {
+		t.Fatal(err)
+	}
+	return u
+}
+
+func (f fixture) createSession(t *testing.T, u user.User) string {
+	t.Helper()
+	s := domainsession.New(uuid.NewString(), u.ID, u.Email, false, time.Now().Add(time.Hour))
+	if _, err := f.repo.CreateSession(context.Background(), &s); err != nil {
+		t.Fatal(err)
+	}
+	return s.ID
+}
+
+// serveAs routes the request as the given user on the given session
+func (f fixture) serveAs(u user.User, sessionID, method, path string) *httptest.ResponseRecorder {
+	return f.serve(&utils.UserClaims{ID: u.ID, Email: u.Email, SessionID: sessionID}, method, path)
+}
+
+func (f fixture) serve(caller *utils.UserClaims, method, path string) *httptest.ResponseRecorder {
+	withCaller := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(w, r.WithContext(auth.SetClaimsInContext(r.Context(), caller)))
+		})
+	}
+	mux := http.NewServeMux()
+	New(f.svc, withCaller, withCaller).SetupRoutes(mux)
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(method, path, nil))
+	return rec
+}
+
+func (f fixture) listSessions(t *testing.T, u user.User, sessionID string) []sessionInfoResp {
+	t.Helper()
+	rec := f.serveAs(u, sessionID, http.MethodGet, "/users/me/sessions")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", rec.Code)
+	}
+	var resp listSessionsResp
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatal(err)
+	}
+	return resp.Sessions
+}
+
+func TestListMySessionsMarksTheCurrentOne(t *testing.T) {
+	f := newFixture(t)
+	ada := f.createUser(t, "ada@example.com")
+	grace := f.createUser(t, "grace@example.com")
+	current := f.createSession(t, ada)
+	f.createSession(t, ada)
+	f.createSession(t, grace)
+
+	sessions := f.listSessions(t, ada, current)
+	if len(sessions) != 2 {
+		t.Fatalf("got %d sessions, want the caller's 2", len(sessions))
+	}
+	for _, s := range sessions {
+		if s.Current != (s.ID == current) {
+			t.Errorf("session %s: current = %v", s.ID, s.Current)
+		}
+	}
+}
+
+func TestListMySessionsEncodesEmptyList(t *testing.T) {
+	f := newFixture(t)
+	ada := f.createUser(t, "ada@example.com")
+
+	rec := f.serveAs(ada, "", http.MethodGet, "/users/me/sessions")
+	if body := rec.Body.String(); body != "{\"sessions\":[]}\n" {
+		t.Fatalf("body = %q, want an empty list", body)
+	}
+}
+
+func TestRevokeMySession(t *testing.T) {
+	f := newFixture(t)
+	ada := f.createUser(t, "ada@example.com")
+	grace := f.createUser(t, "grace@example.com")
+	current := f.createSession(t, ada)
+	other := f.createSession(t, ada)
+	foreign := f.createSession(t, grace)
+
+	if rec := f.serveAs(ada, current, http.MethodDelete, "/users/me/sessions/"+other); rec.Code != http.StatusNoContent {
+		t.Fatalf("revoking own session = %d, want 204", rec.Code)
+	}
+	if sessions := f.listSessions(t, ada, current); len(sessions) != 1 || sessions[0].ID != current {
+		t.Fatalf("got %+v, want only the current session left", sessions)
+	}
+
+	// Another user's session looks the same as one that does not exist
+	for _, id := range []string{foreign, uuid.NewString()} {
+		if rec := f.serveAs(ada, current, http.MethodDelete, "/users/me/sessions/"+id); rec.Code != http.StatusNotFound {
+			t.Errorf("revoking %s = %d, want 404", id, rec.Code)
+		}
+	}
+	if sessions := f.listSessions(t, grace, foreign); len(sessions) != 1 {
+		t.Fatal("another user's session was revoked")
+	}
+}
+
+func TestRevokeOtherSessionsKeepsTheCurrentOne(t *testing.T) {
+	f := newFixture(t)
+	ada := f.createUser(t, "ada@example.com")
+	grace := f.createUser(t, "grace@example.com")
+	current := f.createSession(t, ada)
+	f.createSession(t, ada)
+	f.createSession(t, ada)
+	f.createSession(t, grace)
+
+	rec := f.serveAs(ada, current, http.MethodDelete, "/users/me/sessions")
+	var resp revokeSessionsResp
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil || rec.Code != http.StatusOK || resp.Revoked != 2 {
+		t.Fatalf("status = %d, revoked = %d (%v), want 200 with 2 revoked", rec.Code, resp.Revoked, err)
+	}
+	if sessions := f.listSessions(t, ada, current); len(sessions) != 1 || sessions[0].ID != current {
+		t.Fatalf("got %+v, want only the current session left", sessions)
+	}
+	if sessions := f.listSessions(t, grace, ""); len(sessions) != 1 {
+		t.Fatal("another user's sessions were revoked")
+	}
+}
+
+func TestRevokeRoutesRefuseImpersonation(t *testing.T) {
+	f := newFixture(t)
+	ada := f.createUser(t, "ada@example.com")
+	current := f.createSession(t, ada)
+	other := f.createSession(t, ada)
+	impersonated := &utils.UserClaims{ID: ada.ID, Email: ada.Email, SessionID: current, ImpersonatorID: uuid.NewString()}
+
+	for _, path := range []string{"/users/me/sessions/" + other, "/users/me/sessions"} {
+		rec := f.serve(impersonated, http.MethodDelete, path)
+		if rec.Code != http.StatusForbidden || !strings.Contains(rec.Body.String(), "impersonation_not_allowed") {
+			t.Errorf("DELETE %s while impersonating = %d %s, want 403 impersonation_not_allowed", path, rec.Code, rec.Body)
+		}
+	}
+	if sessions := f.listSessions(t, ada, current); len(sessions) != 2 {
+		t.Fatalf("got %d sessions, want both left", len(sessions))
+	}
+	// Looking is fine
+	if rec := f.serve(impersonated, http.MethodGet, "/users/me/sessions"); rec.Code != http.StatusOK {
+		t.Fatalf("listing while impersonating = %d, want 200", rec.Code)
+	}
+}
@@ -0,0 +1,62 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/frostnzx/go-ecommerce-api/internal/logging"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/frostnzx/go-ecommerce-api/internal/adapters/primary/api"
+
+// TracingMiddleware continues the caller's W3C trace context, or starts a new
+// trace, with a server span named after the matched route pattern. The trace ID
+// is added to the request logger so log lines can be joined with traces.
+func TracingMiddleware(mux *http.ServeMux) func(http.Handler) http.Handler {
+	tracer := otel.Tracer(tracerName)
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+
+			_, pattern := mux.Handler(r)
+			name := r.Method
+			attrs := []trace.SpanStartOption{
+				trace.WithSpanKind(trace.SpanKindServer),
+				trace.WithAttributes(
+					semconv.HTTPRequestMethodKey.String(r.Method),
+					semconv.URLPath(r.URL.Path),
+				),
+			}
+			if pattern != "" {
+				route := pattern
+				if !strings.HasPrefix(pattern, r.Method+" ") {
+					route = r.Method + " " + pattern
+				}
+				name = route
+				attrs = append(attrs, trace.WithAttributes(semconv.HTTPRoute(strings.TrimPrefix(route, r.Method+" "))))
+			}
+
+			ctx, span := tracer.Start(ctx, name, attrs...)
+			defer span.End()
+			if sc := span.SpanContext(); sc.IsValid() {
+				ctx = logging.With(ctx, "trace_id", sc.TraceID().String())
+			}
+
+			rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(rec, r.WithContext(ctx))
+
+			span.SetAttributes(semconv.HTTPResponseStatusCode(rec.status))
+			if rec.status >= http.StatusInternalServerError {
+				span.SetStatus(codes.Error, http.StatusText(rec.status))
+				if rec.err != nil {
+					span.RecordError(rec.err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,273 @@
+package user
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/frostnzx/go-ecommerce-api/internal/adapters/primary/api/auth"
+	"github.com/frostnzx/go-ecommerce-api/internal/adapters/primary/api/httpio"
+	coreuser "github.com/frostnzx/go-ecommerce-api/internal/core/services/user"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/utils"
+	"github.com/google/uuid"
+)
+
+// DTOs
+type suspendUserReq struct {
+	Reason string `json:"reason" validate:"max=500"`
+}
+
+type resetUserPasswordResp struct {
+	TemporaryPassword string `json:"temporary_password"`
+}
+
+type impersonateUserReq struct {
+	Reason string `json:"reason" validate:"notblank,max=500"`
+}
+type impersonateUserResp struct {
+	SessionID            string    `json:"session_id"`
+	AccessToken          string    `json:"access_token"`
+	AccessTokenExpiresAt time.Time `json:"access_token_expires_at"`
+}
+
+type auditEntryResp struct {
+	ID           uuid.UUID `json:"id"`
+	ActorID      uuid.UUID `json:"actor_id"`
+	Action       string    `json:"action"`
+	TargetUserID uuid.UUID `json:"target_user_id"`
+	Details      string    `json:"details"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+type listAuditLogResp struct {
+	Entries []auditEntryResp `json:"entries"`
+	Limit   int              `json:"limit"`
+	Offset  int              `json:"offset"`
+}
+
+// SuspendUserHandler godoc
+// @Summary      Suspend a user (Admin)
+// @Description  Block login and API key use for the account and revoke all of its sessions (admin only)
+// @Tags         Admin
+// @Accept       json
+// @Param        id path string true "User ID"
+// @Param        request body suspendUserReq false "Reason, recorded in the audit log"
+// @Success      204 {string} string "No Content"
+// @Failure      400 {object} httpio.Problem "Invalid request"
+// @Failure      401 {object} httpio.Problem "Unauthorized"
+// @Failure      403 {object} httpio.Problem "Forbidden"
+// @Failure      404 {object} httpio.Problem "User not found"
+// @Failure      500 {object} httpio.Problem "Internal server error"
+// @Security     BearerAuth
+// @Router       /admin/users/{id}/suspend [post]
+func (h *Handler) SuspendUserHandler(w http.ResponseWriter, r *http.Request) {
+	claims, id, ok := adminTarget(w, r)
+	if !ok {
+		return
+	}
+	var req suspendUserReq
+	if r.ContentLength != 0 {
+		if !httpio.DecodeJSON(w, r, &req) {
+			return
+		}
+	}
+
+	err := h.svc.SuspendUser(r.Context(), coreuser.SuspendUserReq{AdminID: claims.ID, ID: id, Reason: req.Reason})
+	if err != nil {
+		httpio.WriteError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent) // 204
+}
+
+// ReactivateUserHandler godoc
+// @Summary      Reactivate a user (Admin)
+// @Description  Lift a suspension (admin only)
+// @Tags         Admin
+// @Param        id path string true "User ID"
+// @Success      204 {string} string "No Content"
+// @Failure      400 {object} httpio.Problem "Invalid request"
+// @Failure      401 {object} httpio.Problem "Unauthorized"
+// @Failure      403 {object} httpio.Problem "Forbidden"
+// @Failure      404 {object} httpio.Problem "User not found"
+// @Failure      500 {object} httpio.Problem "Internal server error"
+// @Security     BearerAuth
+// @Router       /admin/users/{id}/reactivate [post]
+func (h *Handler) ReactivateUserHandler(w http.ResponseWriter, r *http.Request) {
+	claims, id, ok := adminTarget(w, r)
+	if !ok {
+		return
+	}
+	err := h.svc.ReactivateUser(r.Context(), coreuser.ReactivateUserReq{AdminID: claims.ID, ID: id})
+	if err != nil {
+		httpio.WriteError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent) // 204
+}
+
+// ResetUserPasswordHandler godoc
+// @Summary      Reset a user's password (Admin)
+// @Description  Replace the password with a temporary one, returned once, and log the user out everywhere (admin only)
+// @Tags         Admin
+// @Produce      json
+// @Param        id path string true "User ID"
+// @Success      200 {object} resetUserPasswordResp
+// @Failure      400 {object} httpio.Problem "Invalid request"
+// @Failure      401 {object} httpio.Problem "Unauthorized"
+// @Failure      403 {object} httpio.Problem "Forbidden"
+// @Failure      404 {object} httpio.Problem "User not found"
+// @Failure      500 {object} httpio.Problem "Internal server error"
+// @Security     BearerAuth
+// @Router       /admin/users/{id}/password-reset [post]
+func (h *Handler) ResetUserPasswordHandler(w http.ResponseWriter, r *http.Request) {
+	claims, id, ok := adminTarget(w, r)
+	if !ok {
+		return
+	}
+	res, err := h.svc.ResetUserPassword(r.Context(), coreuser.ResetUserPasswordReq{AdminID: claims.ID, ID: id})
+	if err != nil {
+		httpio.WriteError(w, err)
+		return
+	}
+	resp := resetUserPasswordResp{TemporaryPassword: res.TemporaryPassword}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK) // 200
+	json.NewEncoder(w).Encode(resp)
+}
+
+// ImpersonateUserHandler godoc
+// @Summary      Impersonate a user (Admin)
+// @Description  Get a 15 minute access token acting as a customer. The token cannot be renewed, carries an impersonator_id claim and is recorded in the audit log (admin only)
+// @Tags         Admin
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "User ID"
+// @Param        request body impersonateUserReq true "Reason, recorded in the audit log"
+// @Success      200 {object} impersonateUserResp
+// @Failure      400 {object} httpio.Problem "Invalid request"
+// @Failure      401 {object} httpio.Problem "Unauthorized"
+// @Failure      403 {object} httpio.Problem "Forbidden"
+// @Failure      404 {object} httpio.Problem "User not found"
+// @Failure      500 {object} httpio.Problem "Internal server error"
+// @Security     BearerAuth
+// @Router       /admin/users/{id}/impersonate [post]
+func (h *Handler) ImpersonateUserHandler(w http.ResponseWriter, r *http.Request) {
+	claims, id, ok := adminTarget(w, r)
+	if !ok {
+		return
+	}
+	if claims.APIKeyID != "" {
+		httpio.Error(w, http.StatusForbidden, httpio.CodeSessionRequired, "impersonation requires a logged in admin, not an api key")
+		return
+	}
+	var req impersonateUserReq
+	if !httpio.DecodeJSON(w, r, &req) {
+		return
+	}
+
+	in := coreuser.ImpersonateUserReq{
+		AdminID:   claims.ID,
+		ID:        id,
+		Reason:    req.Reason,
+		UserAgent: r.UserAgent(),
+		IPAddress: httpio.ClientIP(r),
+	}
+	res, err := h.svc.ImpersonateUser(r.Context(), in)
+	if err != nil {
+		httpio.WriteError(w, err)
+		return
+	}
+	resp := impersonateUserResp{
+		SessionID:            res.SessionID,
+		AccessToken:          res.AccessToken,
+		AccessTokenExpiresAt: res.AccessTokenExpiresAt,
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK) // 200
+	json.NewEncoder(w).Encode(resp)
+}
+
+// ListAuditLogHandler godoc
+// @Summary      Admin audit log (Admin)
+// @Description  Get recorded admin actions on user accounts, newest first (admin only)
+// @Tags         Admin
+// @Produce      json
+// @Param        user_id query string false "Only entries about this user"
+// @Param        limit query int false "Page size (default 20, max 100)"
+// @Param        offset query int false "Number of entries to skip"
+// @Success      200 {object} listAuditLogResp
+// @Failure      400 {object} httpio.Problem "Invalid request"
+// @Failure      401 {object} httpio.Problem "Unauthorized"
+// @Failure      403 {object} httpio.Problem "Forbidden"
+// @Failure      500 {object} httpio.Problem "Internal server error"
+// @Security     BearerAuth
+// @Router       /admin/audit-log [get]
+func (h *Handler) ListAuditLogHandler(w http.ResponseWriter, r *http.Request) {
+	limit, offset, err := pageParams(r)
+	if err != nil {
+		httpio.WriteError(w, err)
+		return
+	}
+	in := coreuser.ListAuditLogReq{Limit: limit, Offset: offset}
+	if raw := r.URL.Query().Get("user_id"); raw != "" {
+		if in.UserID, err = uuid.Parse(raw); err != nil {
+			httpio.InvalidField(w, "user_id", "invalid user id")
+			return
+		}
+	}
+
+	res, err := h.svc.ListAuditLog(r.Context(), in)
+	if err != nil {
+		httpio.WriteError(w, err)
+		return
+	}
+	entries := make([]auditEntryResp, 0, len(res.Entries))
+	for _, e := range res.Entries {
+		entries = append(entries, auditEntryResp{
+			ID:           e.ID,
+			ActorID:      e.ActorID,
+			Action:       string(e.Action),
+			TargetUserID: e.TargetUserID,
+			Details:      e.Details,
+			CreatedAt:    e.CreatedAt,
+		})
+	}
+	resp := listAuditLogResp{Entries: entries, Limit: res.Limit, Offset: res.Offset}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK) // 200
+	json.NewEncoder(w).Encode(resp)
+}
+
+// adminTarget returns the acting admin's claims and the user ID from the path
+func adminTarget(w http.ResponseWriter, r *http.Request) (*utils.UserClaims, uuid.UUID, bool) {
+	claims, ok := auth.GetClaimsFromContext(r.Context())
+	if !ok {
+		httpio.Error(w, http.StatusUnauthorized, httpio.CodeUnauthorized, "authentication required")
+		return nil, uuid.Nil, false
+	}
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		httpio.InvalidField(w, "id", "invalid user id")
+		return nil, uuid.Nil, false
+	}
+	return claims, id, true
+}
+
+// pageParams reads the optional limit and offset query parameters
+func pageParams(r *http.Request) (limit, offset int, err error) {
+	q := r.URL.Query()
+	if raw := q.Get("limit"); raw != "" {
+		if limit, err = strconv.Atoi(raw); err != nil || limit < 0 {
+			return 0, 0, httpio.Invalid(httpio.FieldError{Field: "limit", Code: "invalid", Message: "limit must be a non-negative integer"})
+		}
+	}
+	if raw := q.Get("offset"); raw != "" {
+		if offset, err = strconv.Atoi(raw); err != nil || offset < 0 {
+			return 0, 0, httpio.Invalid(httpio.FieldError{Field: "offset", Code: "invalid", Message: "offset must be a non-negative integer"})
+		}
+	}
+	return limit, offset, nil
+}
@@ -0,0 +1,105 @@
+package user
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+
+	"github.com/frostnzx/go-ecommerce-api/internal/adapters/primary/api/httpio"
+	coreuser "github.com/frostnzx/go-ecommerce-api/internal/core/services/user"
+)
+
+// oidcStateCookie binds the login state to the browser that started the flow,
+// so a callback URL handed to someone else cannot log them into our account
+const oidcStateCookie = "oidc_state"
+
+// OIDCLoginHandler godoc
+// @Summary      Start social login
+// @Description  Redirect to the OpenID Connect provider (authorization code flow with PKCE)
+// @Tags         Auth
+// @Param        provider path string true "Provider name"
+// @Success      302 {string} string "Redirect to the provider"
+// @Failure      404 {object} httpio.Problem "Unknown provider"
+// @Failure      500 {object} httpio.Problem "Internal server error"
+// @Router       /auth/oidc/{provider}/login [get]
+func (h *Handler) OIDCLoginHandler(w http.ResponseWriter, r *http.Request) {
+	res, err := h.svc.StartOIDCLogin(r.Context(), coreuser.StartOIDCLoginReq{Provider: r.PathValue("provider")})
+	if err != nil {
+		httpio.WriteError(w, err)
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     oidcStateCookie,
+		Value:    res.State,
+		Path:     "/auth/oidc/",
+		Expires:  res.ExpiresAt,
+		HttpOnly: true,
+		Secure:   r.TLS != nil,
+		SameSite: http.SameSiteLaxMode,
+	})
+	http.Redirect(w, r, res.AuthURL, http.StatusFound)
+}
+
+// OIDCCallbackHandler godoc
+// @Summary      Finish social login
+// @Description  Redirect target of the OpenID Connect provider; links the identity and returns tokens
+// @Tags         Auth
+// @Produce      json
+// @Param        provider path string true "Provider name"
+// @Param        code query string true "Authorization code"
+// @Param        state query string true "Login state"
+// @Success      200 {object} loginUserResp
+// @Failure      400 {object} httpio.Problem "Invalid request"
+// @Failure      401 {object} httpio.Problem "Login failed"
+// @Failure      403 {object} httpio.Problem "Account suspended"
+// @Failure      404 {object} httpio.Problem "Unknown provider"
+// @Failure      500 {object} httpio.Problem "Internal server error"
+// @Router       /auth/oidc/{provider}/callback [get]
+func (h *Handler) OIDCCallbackHandler(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	// The state cookie is single use whatever the outcome
+	http.SetCookie(w, &http.Cookie{Name: oidcStateCookie, Path: "/auth/oidc/", MaxAge: -1, HttpOnly: true})
+
+	if q.Get("error") != "" {
+		httpio.Error(w, http.StatusUnauthorized, "social_login_denied", "login denied by provider: "+q.Get("error"))
+		return
+	}
+	state, code := q.Get("state"), q.Get("code")
+	if state == "" || code == "" {
+		httpio.Error(w, http.StatusBadRequest, httpio.CodeInvalidRequest, "state and code are required")
+		return
+	}
+	cookie, err := r.Cookie(oidcStateCookie)
+	if err != nil || subtle.ConstantTimeCompare([]byte(cookie.Value), []byte(state)) != 1 {
+		httpio.WriteError(w, coreuser.ErrInvalidOIDCState)
+		return
+	}
+
+	in := coreuser.CompleteOIDCLoginReq{
+		Provider:  r.PathValue("provider"),
+		State:     state,
+		Code:      code,
+		UserAgent: r.UserAgent(),
+		IPAddress: httpio.ClientIP(r),
+	}
+	res, err := h.svc.CompleteOIDCLogin(r.Context(), in)
+	if err != nil {
+		httpio.WriteError(w, err)
+		return
+	}
+	resp := loginUserResp{
+		SessionID:             res.SessionID,
+		AccessToken:           res.AccessToken,
+		RefreshToken:          res.RefreshToken,
+		AccessTokenExpiresAt:  res.AccessTokenExpiresAt,
+		RefreshTokenExpiresAt: res.RefreshTokenExpiresAt,
+		Name:                  res.Name,
+		Email:                 res.Email,
+		IsAdmin:               res.IsAdmin,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK) // 200
+	json.NewEncoder(w).Encode(resp)
+}
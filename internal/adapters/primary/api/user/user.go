@@ -2,10 +2,14 @@ package user
 
 import (
 	"encoding/json"
+	"errors"
+	"math"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/frostnzx/go-ecommerce-api/internal/adapters/primary/api/auth"
+	"github.com/frostnzx/go-ecommerce-api/internal/adapters/primary/api/httpio"
 	coreuser "github.com/frostnzx/go-ecommerce-api/internal/core/services/user"
 	"github.com/google/uuid"
 )
@@ -25,18 +29,17 @@ func New(svc coreuser.API, authMiddleware, adminMiddleware func(http.Handler) ht
 }
 
 type registerUserRequest struct {
-	Name     string `json:"name"`
-	Email    string `json:"email"`
-	Password string `json:"password"`
-	IsAdmin  bool   `json:"is_admin"`
+	Name     string `json:"name" validate:"notblank,max=100"`
+	Email    string `json:"email" validate:"required,email,max=254"`
+	Password string `json:"password" validate:"required,password"`
 }
 type registerUserResp struct {
 	ID uuid.UUID `json:"id"`
 }
 
 type loginUserReq struct {
-	Email    string `json:"email"`
-	Password string `json:"password"`
+	Email    string `json:"email" validate:"required,max=254"`
+	Password string `json:"password" validate:"required,max=72"`
 }
 type loginUserResp struct {
 	SessionID             string    `json:"session_id"`
@@ -54,21 +57,18 @@ type logoutUserReq struct {
 }
 
 type renewAccessTokenReq struct {
-	RefreshToken string `json:"refresh_token"`
+	RefreshToken string `json:"refresh_token" validate:"required"`
 }
 type renewAccessTokenResp struct {
-	AccessToken          string    `json:"access_token"`
-	AccessTokenExpiresAt time.Time `json:"access_token_expires_at"`
+	AccessToken           string    `json:"access_token"`
+	RefreshToken          string    `json:"refresh_token"`
+	AccessTokenExpiresAt  time.Time `json:"access_token_expires_at"`
+	RefreshTokenExpiresAt time.Time `json:"refresh_token_expires_at"`
 }
 
 type updateUserProfileReq struct {
-	ID    uuid.UUID `json:"id"`
-	Name  string    `json:"name"`
-	Email string    `json:"email"`
-}
-
-type getUserProfileReq struct {
-	ID uuid.UUID `json:"id"`
+	Name  string `json:"name" validate:"notblank,max=100"`
+	Email string `json:"email" validate:"required,email,max=254"`
 }
 
 type getUserProfileResp struct {
@@ -78,27 +78,26 @@ type getUserProfileResp struct {
 	IsAdmin bool      `json:"is_admin"`
 }
 
-type deleteAccountReq struct {
-	ID        uuid.UUID `json:"id"`
-	SessionID string    `json:"session_id"`
-}
-
 type changePasswordProfileReq struct {
-	ID              uuid.UUID `json:"id"`
-	CurrentPassword string    `json:"current_password"`
-	NewPassword     string    `json:"new_password"`
+	CurrentPassword string `json:"current_password" validate:"required,max=72"`
+	NewPassword     string `json:"new_password" validate:"required,password"`
 }
 
 // Admin DTOs
 type listUsersResp struct {
-	Users []userInfo `json:"users"`
+	Users  []userInfo `json:"users"`
+	Total  int        `json:"total"`
+	Limit  int        `json:"limit"`
+	Offset int        `json:"offset"`
 }
 
 type userInfo struct {
-	ID      uuid.UUID `json:"id"`
-	Name    string    `json:"name"`
-	Email   string    `json:"email"`
-	IsAdmin bool      `json:"is_admin"`
+	ID          uuid.UUID  `json:"id"`
+	Name        string     `json:"name"`
+	Email       string     `json:"email"`
+	IsAdmin     bool       `json:"is_admin"`
+	CreatedAt   time.Time  `json:"created_at"`
+	SuspendedAt *time.Time `json:"suspended_at"`
 }
 
 func (h *Handler) SetupRoutes(mux *http.ServeMux) {
@@ -106,16 +105,26 @@ func (h *Handler) SetupRoutes(mux *http.ServeMux) {
 	mux.HandleFunc("POST /auth/register", h.RegisterUserHandler)
 	mux.HandleFunc("POST /auth/login", h.LoginHandler)
 	mux.HandleFunc("POST /auth/renew", h.RenewAccessTokenHandler)
+	mux.HandleFunc("GET /auth/oidc/{provider}/login", h.OIDCLoginHandler)
+	mux.HandleFunc("GET /auth/oidc/{provider}/callback", h.OIDCCallbackHandler)
 
-	// Protected routes (auth required)
+	// Protected routes (auth required); /users/me acts on the caller, other IDs need admin
+	mux.Handle("GET /users/me", h.authMiddleware(http.HandlerFunc(h.GetUserProfileHandler)))
+	mux.Handle("PUT /users/me", h.authMiddleware(http.HandlerFunc(h.UpdateUserProfileHandler)))
+	mux.Handle("PUT /users/me/password", h.authMiddleware(http.HandlerFunc(h.ChangePasswordHandler)))
 	mux.Handle("GET /users/{id}", h.authMiddleware(http.HandlerFunc(h.GetUserProfileHandler)))
 	mux.Handle("PUT /users/{id}", h.authMiddleware(http.HandlerFunc(h.UpdateUserProfileHandler)))
 	mux.Handle("PUT /users/{id}/password", h.authMiddleware(http.HandlerFunc(h.ChangePasswordHandler)))
-	mux.Handle("DELETE /users/{id}", h.authMiddleware(http.HandlerFunc(h.DeleteAccountHandler)))
 	mux.Handle("POST /auth/logout", h.authMiddleware(http.HandlerFunc(h.LogoutHandler)))
 
 	// Admin routes (admin only)
 	mux.Handle("GET /admin/users", h.adminMiddleware(http.HandlerFunc(h.ListAllUsersHandler)))
+	mux.Handle("POST /admin/users/{id}/unlock", h.adminMiddleware(http.HandlerFunc(h.UnlockUserHandler)))
+	mux.Handle("POST /admin/users/{id}/suspend", h.adminMiddleware(http.HandlerFunc(h.SuspendUserHandler)))
+	mux.Handle("POST /admin/users/{id}/reactivate", h.adminMiddleware(http.HandlerFunc(h.ReactivateUserHandler)))
+	mux.Handle("POST /admin/users/{id}/password-reset", h.adminMiddleware(http.HandlerFunc(h.ResetUserPasswordHandler)))
+	mux.Handle("POST /admin/users/{id}/impersonate", h.adminMiddleware(http.HandlerFunc(h.ImpersonateUserHandler)))
+	mux.Handle("GET /admin/audit-log", h.adminMiddleware(http.HandlerFunc(h.ListAuditLogHandler)))
 }
 
 // RegisterUserHandler godoc
@@ -126,24 +135,24 @@ func (h *Handler) SetupRoutes(mux *http.ServeMux) {
 // @Produce      json
 // @Param        request body registerUserRequest true "User registration data"
 // @Success      201 {object} registerUserResp
-// @Failure      400 {string} string "Invalid request"
-// @Failure      500 {string} string "Internal server error"
+// @Failure      400 {object} httpio.Problem "Invalid request"
+// @Failure      429 {object} httpio.Problem "Rate limited"
+// @Failure      500 {object} httpio.Problem "Internal server error"
 // @Router       /auth/register [post]
 func (h *Handler) RegisterUserHandler(w http.ResponseWriter, r *http.Request) {
 	var req registerUserRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "invalid request", http.StatusBadRequest)
+	if !httpio.DecodeJSON(w, r, &req) {
 		return
 	}
 	in := coreuser.RegisterUserReq{
 		Name:     req.Name,
 		Email:    req.Email,
 		Password: req.Password,
-		IsAdmin:  req.IsAdmin,
+		IsAdmin:  false, // Admins are only made through the admin CLI, never by self-registration
 	}
 	res, err := h.svc.RegisterUser(r.Context(), in)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		httpio.WriteError(w, err)
 		return
 	}
 	resp := registerUserResp{
@@ -156,30 +165,30 @@ func (h *Handler) RegisterUserHandler(w http.ResponseWriter, r *http.Request) {
 
 // GetUserProfileHandler godoc
 // @Summary      Get user profile
-// @Description  Get user profile by ID
+// @Description  Get a user's profile. Use "me" as the ID for the caller's own profile; other IDs require admin
 // @Tags         Users
 // @Accept       json
 // @Produce      json
-// @Param        id path string true "User ID"
-// @Param        request body getUserProfileReq true "User ID"
+// @Param        id path string true "User ID or \"me\""
 // @Success      200 {object} getUserProfileResp
-// @Failure      400 {string} string "Invalid request"
-// @Failure      401 {string} string "Unauthorized"
-// @Failure      500 {string} string "Internal server error"
+// @Failure      400 {object} httpio.Problem "Invalid request"
+// @Failure      401 {object} httpio.Problem "Unauthorized"
+// @Failure      403 {object} httpio.Problem "Forbidden"
+// @Failure      404 {object} httpio.Problem "User not found"
+// @Failure      500 {object} httpio.Problem "Internal server error"
 // @Security     BearerAuth
 // @Router       /users/{id} [get]
 func (h *Handler) GetUserProfileHandler(w http.ResponseWriter, r *http.Request) {
-	var req getUserProfileReq
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "invalid request", http.StatusBadRequest)
+	_, userID, ok := auth.TargetUser(w, r)
+	if !ok {
 		return
 	}
 	in := coreuser.GetUserProfileReq{
-		ID: req.ID,
+		ID: userID,
 	}
 	res, err := h.svc.GetUserProfile(r.Context(), in)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		httpio.WriteError(w, err)
 		return
 	}
 	resp := getUserProfileResp{
@@ -195,32 +204,38 @@ func (h *Handler) GetUserProfileHandler(w http.ResponseWriter, r *http.Request)
 
 // UpdateUserProfileHandler godoc
 // @Summary      Update user profile
-// @Description  Update user profile information
+// @Description  Update a user's name and email. Use "me" as the ID for the caller's own profile; other IDs require admin
 // @Tags         Users
 // @Accept       json
 // @Produce      json
-// @Param        id path string true "User ID"
+// @Param        id path string true "User ID or \"me\""
 // @Param        request body updateUserProfileReq true "Updated profile data"
 // @Success      200 {string} string "OK"
-// @Failure      400 {string} string "Invalid request"
-// @Failure      401 {string} string "Unauthorized"
-// @Failure      500 {string} string "Internal server error"
+// @Failure      400 {object} httpio.Problem "Invalid request"
+// @Failure      401 {object} httpio.Problem "Unauthorized"
+// @Failure      403 {object} httpio.Problem "Forbidden or not allowed while impersonating"
+// @Failure      404 {object} httpio.Problem "User not found"
+// @Failure      500 {object} httpio.Problem "Internal server error"
 // @Security     BearerAuth
 // @Router       /users/{id} [put]
 func (h *Handler) UpdateUserProfileHandler(w http.ResponseWriter, r *http.Request) {
+	// Changing the email would let an impersonating admin take the account over through social login
+	claims, userID, ok := auth.TargetUser(w, r)
+	if !ok || !auth.NotImpersonating(w, claims) {
+		return
+	}
 	var req updateUserProfileReq
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "invalid request", http.StatusBadRequest)
+	if !httpio.DecodeJSON(w, r, &req) {
 		return
 	}
 	in := coreuser.UpdateUserProfileReq{
-		ID:    req.ID,
+		ID:    userID,
 		Name:  req.Name,
 		Email: req.Email,
 	}
 	err := h.svc.UpdateUserProfile(r.Context(), in)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		httpio.WriteError(w, err)
 		return
 	}
 	w.Header().Set("Content-Type", "application/json")
@@ -229,69 +244,37 @@ func (h *Handler) UpdateUserProfileHandler(w http.ResponseWriter, r *http.Reques
 
 // ChangePasswordHandler godoc
 // @Summary      Change password
-// @Description  Change user password
+// @Description  Change a user's password. Use "me" as the ID for the caller's own account; other IDs require admin
 // @Tags         Users
 // @Accept       json
 // @Produce      json
-// @Param        id path string true "User ID"
+// @Param        id path string true "User ID or \"me\""
 // @Param        request body changePasswordProfileReq true "Password change data"
 // @Success      204 {string} string "No Content"
-// @Failure      400 {string} string "Invalid request"
-// @Failure      401 {string} string "Unauthorized"
-// @Failure      500 {string} string "Internal server error"
+// @Failure      400 {object} httpio.Problem "Invalid request"
+// @Failure      401 {object} httpio.Problem "Unauthorized"
+// @Failure      403 {object} httpio.Problem "Forbidden or current password is incorrect"
+// @Failure      404 {object} httpio.Problem "User not found"
+// @Failure      500 {object} httpio.Problem "Internal server error"
 // @Security     BearerAuth
 // @Router       /users/{id}/password [put]
 func (h *Handler) ChangePasswordHandler(w http.ResponseWriter, r *http.Request) {
+	claims, userID, ok := auth.TargetUser(w, r)
+	if !ok || !auth.NotImpersonating(w, claims) {
+		return
+	}
 	var req changePasswordProfileReq
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "invalid request", http.StatusBadRequest)
+	if !httpio.DecodeJSON(w, r, &req) {
 		return
 	}
 	in := coreuser.ChangePasswordProfileReq{
-		ID:              req.ID,
+		ID:              userID,
 		CurrentPassword: req.CurrentPassword,
 		NewPassword:     req.NewPassword,
 	}
 	err := h.svc.ChangePassword(r.Context(), in)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	w.WriteHeader(http.StatusNoContent) // 204
-}
-
-// DeleteAccountHandler godoc
-// @Summary      Delete account
-// @Description  Delete user account
-// @Tags         Users
-// @Accept       json
-// @Produce      json
-// @Param        id path string true "User ID"
-// @Param        request body deleteAccountReq true "Account deletion data"
-// @Success      204 {string} string "No Content"
-// @Failure      400 {string} string "Invalid request"
-// @Failure      401 {string} string "Unauthorized"
-// @Failure      500 {string} string "Internal server error"
-// @Security     BearerAuth
-// @Router       /users/{id} [delete]
-func (h *Handler) DeleteAccountHandler(w http.ResponseWriter, r *http.Request) {
-	claims, ok := auth.GetClaimsFromContext(r.Context())
-	if !ok {
-		http.Error(w, "unauthorized", http.StatusUnauthorized)
-		return
-	}
-	var req deleteAccountReq
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "invalid request", http.StatusBadRequest)
-		return
-	}
-	in := coreuser.DeleteAccountReq{
-		ID:        req.ID,
-		SessionID: claims.SessionID,
-	}
-	err := h.svc.DeleteAccount(r.Context(), in)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		httpio.WriteError(w, err)
 		return
 	}
 	w.WriteHeader(http.StatusNoContent) // 204
@@ -305,22 +288,30 @@ func (h *Handler) DeleteAccountHandler(w http.ResponseWriter, r *http.Request) {
 // @Produce      json
 // @Param        request body loginUserReq true "Login credentials"
 // @Success      200 {object} loginUserResp
-// @Failure      400 {string} string "Invalid request"
-// @Failure      401 {string} string "Unauthorized"
+// @Failure      400 {object} httpio.Problem "Invalid request"
+// @Failure      401 {object} httpio.Problem "Invalid email or password"
+// @Failure      403 {object} httpio.Problem "Account suspended"
+// @Failure      429 {object} httpio.Problem "Too many failed attempts or rate limited"
+// @Failure      500 {object} httpio.Problem "Internal server error"
 // @Router       /auth/login [post]
 func (h *Handler) LoginHandler(w http.ResponseWriter, r *http.Request) {
 	var req loginUserReq
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "invalid request", http.StatusBadRequest)
+	if !httpio.DecodeJSON(w, r, &req) {
 		return
 	}
 	in := coreuser.LoginUserReq{
-		Email:    req.Email,
-		Password: req.Password,
+		Email:     req.Email,
+		Password:  req.Password,
+		UserAgent: r.UserAgent(),
+		IPAddress: httpio.ClientIP(r),
 	}
 	res, err := h.svc.LoginUser(r.Context(), in)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusUnauthorized)
+		var throttled *coreuser.LoginThrottledError
+		if errors.As(err, &throttled) {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(throttled.RetryAfter.Seconds()))))
+		}
+		httpio.WriteError(w, err)
 		return
 	}
 	resp := loginUserResp{
@@ -346,14 +337,14 @@ func (h *Handler) LoginHandler(w http.ResponseWriter, r *http.Request) {
 // @Accept       json
 // @Produce      json
 // @Success      204 {string} string "No Content"
-// @Failure      401 {string} string "Unauthorized"
-// @Failure      500 {string} string "Internal server error"
+// @Failure      401 {object} httpio.Problem "Unauthorized"
+// @Failure      500 {object} httpio.Problem "Internal server error"
 // @Security     BearerAuth
 // @Router       /auth/logout [post]
 func (h *Handler) LogoutHandler(w http.ResponseWriter, r *http.Request) {
 	claims, ok := auth.GetClaimsFromContext(r.Context())
 	if !ok {
-		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		httpio.Error(w, http.StatusUnauthorized, httpio.CodeUnauthorized, "authentication required")
 		return
 	}
 	in := coreuser.LogoutUserReq{
@@ -361,7 +352,7 @@ func (h *Handler) LogoutHandler(w http.ResponseWriter, r *http.Request) {
 	}
 	err := h.svc.LogoutUser(r.Context(), in)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		httpio.WriteError(w, err)
 		return
 	}
 
@@ -370,19 +361,19 @@ func (h *Handler) LogoutHandler(w http.ResponseWriter, r *http.Request) {
 
 // RenewAccessTokenHandler godoc
 // @Summary      Renew access token
-// @Description  Get a new access token using refresh token
+// @Description  Exchange a refresh token for a new access and refresh token pair. Each refresh token can be used once; replaying a used token revokes the session
 // @Tags         Auth
 // @Accept       json
 // @Produce      json
 // @Param        request body renewAccessTokenReq true "Refresh token"
 // @Success      200 {object} renewAccessTokenResp
-// @Failure      400 {string} string "Invalid request"
-// @Failure      401 {string} string "Unauthorized"
+// @Failure      400 {object} httpio.Problem "Invalid request"
+// @Failure      401 {object} httpio.Problem "Unauthorized"
+// @Failure      429 {object} httpio.Problem "Rate limited"
 // @Router       /auth/renew [post]
 func (h *Handler) RenewAccessTokenHandler(w http.ResponseWriter, r *http.Request) {
 	var req renewAccessTokenReq
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "invalid request", http.StatusBadRequest)
+	if !httpio.DecodeJSON(w, r, &req) {
 		return
 	}
 
@@ -391,12 +382,14 @@ func (h *Handler) RenewAccessTokenHandler(w http.ResponseWriter, r *http.Request
 	}
 	res, err := h.svc.RenewAccessToken(r.Context(), in)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusUnauthorized)
+		httpio.WriteError(w, err)
 		return
 	}
 	resp := renewAccessTokenResp{
-		AccessToken:          res.AccessToken,
-		AccessTokenExpiresAt: res.AccessTokenExpiresAt,
+		AccessToken:           res.AccessToken,
+		RefreshToken:          res.RefreshToken,
+		AccessTokenExpiresAt:  res.AccessTokenExpiresAt,
+		RefreshTokenExpiresAt: res.RefreshTokenExpiresAt,
 	}
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK) // 200
@@ -404,36 +397,82 @@ func (h *Handler) RenewAccessTokenHandler(w http.ResponseWriter, r *http.Request
 }
 
 // ListAllUsersHandler godoc
-// @Summary      List all users (Admin)
-// @Description  Get a list of all users (admin only)
+// @Summary      Search users (Admin)
+// @Description  Get a page of users, optionally filtered by email or name (admin only)
 // @Tags         Admin
 // @Accept       json
 // @Produce      json
+// @Param        q query string false "Matches email or name"
+// @Param        limit query int false "Page size (default 20, max 100)"
+// @Param        offset query int false "Number of users to skip"
 // @Success      200 {object} listUsersResp
-// @Failure      401 {string} string "Unauthorized"
-// @Failure      403 {string} string "Forbidden"
-// @Failure      500 {string} string "Internal server error"
+// @Failure      400 {object} httpio.Problem "Invalid request"
+// @Failure      401 {object} httpio.Problem "Unauthorized"
+// @Failure      403 {object} httpio.Problem "Forbidden"
+// @Failure      500 {object} httpio.Problem "Internal server error"
 // @Security     BearerAuth
 // @Router       /admin/users [get]
 func (h *Handler) ListAllUsersHandler(w http.ResponseWriter, r *http.Request) {
-	res, err := h.svc.ListUsers(r.Context())
+	limit, offset, err := pageParams(r)
+	if err != nil {
+		httpio.WriteError(w, err)
+		return
+	}
+	in := coreuser.ListUsersReq{
+		Query:  r.URL.Query().Get("q"),
+		Limit:  limit,
+		Offset: offset,
+	}
+	res, err := h.svc.ListUsers(r.Context(), in)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		httpio.WriteError(w, err)
 		return
 	}
 
-	var users []userInfo
+	users := make([]userInfo, 0, len(res.Users))
 	for _, u := range res.Users {
 		users = append(users, userInfo{
-			ID:      u.ID,
-			Name:    u.Name,
-			Email:   u.Email,
-			IsAdmin: u.IsAdmin,
+			ID:          u.ID,
+			Name:        u.Name,
+			Email:       u.Email,
+			IsAdmin:     u.IsAdmin,
+			CreatedAt:   u.CreatedAt,
+			SuspendedAt: u.SuspendedAt,
 		})
 	}
 
-	resp := listUsersResp{Users: users}
+	resp := listUsersResp{Users: users, Total: res.Total, Limit: res.Limit, Offset: res.Offset}
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK) // 200
 	json.NewEncoder(w).Encode(resp)
 }
+
+// UnlockUserHandler godoc
+// @Summary      Unlock a user account (Admin)
+// @Description  Clear failed login attempts and any lockout on a user's account (admin only)
+// @Tags         Admin
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "User ID"
+// @Success      204 {string} string "No Content"
+// @Failure      400 {object} httpio.Problem "Invalid request"
+// @Failure      401 {object} httpio.Problem "Unauthorized"
+// @Failure      403 {object} httpio.Problem "Forbidden"
+// @Failure      500 {object} httpio.Problem "Internal server error"
+// @Security     BearerAuth
+// @Router       /admin/users/{id}/unlock [post]
+func (h *Handler) UnlockUserHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		httpio.InvalidField(w, "id", "invalid user id")
+		return
+	}
+
+	err = h.svc.UnlockUser(r.Context(), coreuser.UnlockUserReq{ID: id})
+	if err != nil {
+		httpio.WriteError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent) // 204
+}
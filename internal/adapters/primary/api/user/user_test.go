@@ -0,0 +1,193 @@
+package user
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/frostnzx/go-ecommerce-api/internal/adapters/primary/api/auth"
+	coreuser "github.com/frostnzx/go-ecommerce-api/internal/core/services/user"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/utils"
+	"github.com/google/uuid"
+)
+
+// fakeUserAPI records which user each profile call acted on
+type fakeUserAPI struct {
+	coreuser.API
+	gotID uuid.UUID
+}
+
+func (f *fakeUserAPI) GetUserProfile(_ context.Context, req coreuser.GetUserProfileReq) (*coreuser.GetUserProfileResp, error) {
+	f.gotID = req.ID
+	return &coreuser.GetUserProfileResp{ID: req.ID}, nil
+}
+
+func (f *fakeUserAPI) UpdateUserProfile(_ context.Context, req coreuser.UpdateUserProfileReq) error {
+	f.gotID = req.ID
+	return nil
+}
+
+func (f *fakeUserAPI) ChangePassword(_ context.Context, req coreuser.ChangePasswordProfileReq) error {
+	f.gotID = req.ID
+	return nil
+}
+
+// fakeRegistration records the registrations that reached the service
+type fakeRegistration struct {
+	coreuser.API
+	got []coreuser.RegisterUserReq
+}
+
+func (f *fakeRegistration) RegisterUser(_ context.Context, req coreuser.RegisterUserReq) (*coreuser.RegisterUserResp, error) {
+	f.got = append(f.got, req)
+	return &coreuser.RegisterUserResp{ID: uuid.New()}, nil
+}
+
+// newTestMux routes requests as the given caller
+func newTestMux(svc coreuser.API, caller *utils.UserClaims) *http.ServeMux {
+	withCaller := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(w, r.WithContext(auth.SetClaimsInContext(r.Context(), caller)))
+		})
+	}
+	mux := http.NewServeMux()
+	New(svc, withCaller, withCaller).SetupRoutes(mux)
+	return mux
+}
+
+type profileRequest struct {
+	method, path, body string
+}
+
+func profileRequests(id string) []profileRequest {
+	return []profileRequest{
+		{http.MethodGet, "/users/" + id, ""},
+		{http.MethodPut, "/users/" + id, `{"name":"Mallory","email":"mallory@example.com"}`},
+		{http.MethodPut, "/users/" + id + "/password", `{"current_password":"old","new_password":"new-passw0rd"}`},
+	}
+}
+
+func serve(mux *http.ServeMux, pr profileRequest) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(pr.method, pr.path, strings.NewReader(pr.body))
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestProfileRoutesDenyOtherUsers(t *testing.T) {
+	caller := &utils.UserClaims{ID: uuid.New(), Email: "alice@example.com"}
+	victim := uuid.New()
+
+	for _, pr := range profileRequests(victim.String()) {
+		svc := &fakeUserAPI{}
+		rec := serve(newTestMux(svc, caller), pr)
+		if rec.Code != http.StatusForbidden {
+			t.Errorf("%s %s: status = %d, want %d", pr.method, pr.path, rec.Code, http.StatusForbidden)
+		}
+		if svc.gotID != uuid.Nil {
+			t.Errorf("%s %s: service was called for %s", pr.method, pr.path, svc.gotID)
+		}
+	}
+}
+
+func TestProfileRoutesRejectBodyUserID(t *testing.T) {
+	caller := &utils.UserClaims{ID: uuid.New(), Email: "alice@example.com"}
+	victim := uuid.New()
+
+	svc := &fakeUserAPI{}
+	rec := serve(newTestMux(svc, caller), profileRequest{
+		http.MethodPut, "/users/me", `{"id":"` + victim.String() + `","name":"Mallory","email":"mallory@example.com"}`,
+	})
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+	if svc.gotID != uuid.Nil {
+		t.Errorf("service was called for %s", svc.gotID)
+	}
+}
+
+func TestProfileRoutesAllowSelf(t *testing.T) {
+	caller := &utils.UserClaims{ID: uuid.New(), Email: "alice@example.com"}
+
+	for _, id := range []string{"me", caller.ID.String()} {
+		for _, pr := range profileRequests(id) {
+			svc := &fakeUserAPI{}
+			rec := serve(newTestMux(svc, caller), pr)
+			if rec.Code >= 300 {
+				t.Errorf("%s %s: status = %d, want success", pr.method, pr.path, rec.Code)
+			}
+			if svc.gotID != caller.ID {
+				t.Errorf("%s %s: acted on %s, want caller %s", pr.method, pr.path, svc.gotID, caller.ID)
+			}
+		}
+	}
+}
+
+func TestProfileRoutesAllowAdmin(t *testing.T) {
+	admin := &utils.UserClaims{ID: uuid.New(), Email: "admin@example.com", IsAdmin: true}
+	target := uuid.New()
+
+	for _, pr := range profileRequests(target.String()) {
+		svc := &fakeUserAPI{}
+		rec := serve(newTestMux(svc, admin), pr)
+		if rec.Code >= 300 {
+			t.Errorf("%s %s: status = %d, want success", pr.method, pr.path, rec.Code)
+		}
+		if svc.gotID != target {
+			t.Errorf("%s %s: acted on %s, want %s", pr.method, pr.path, svc.gotID, target)
+		}
+	}
+}
+
+func TestProfileRoutesRejectInvalidID(t *testing.T) {
+	caller := &utils.UserClaims{ID: uuid.New(), Email: "alice@example.com"}
+
+	rec := serve(newTestMux(&fakeUserAPI{}, caller), profileRequest{http.MethodGet, "/users/not-a-uuid", ""})
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+}
+
+func TestAccountChangesRefuseImpersonation(t *testing.T) {
+	impersonated := &utils.UserClaims{ID: uuid.New(), Email: "alice@example.com", ImpersonatorID: uuid.NewString()}
+
+	// Changing the email or the password would hand the account to the admin for good
+	for _, pr := range profileRequests("me")[1:] {
+		svc := &fakeUserAPI{}
+		rec := serve(newTestMux(svc, impersonated), pr)
+		if rec.Code != http.StatusForbidden || !strings.Contains(rec.Body.String(), "impersonation_not_allowed") {
+			t.Errorf("%s %s while impersonating = %d %s, want 403 impersonation_not_allowed", pr.method, pr.path, rec.Code, rec.Body)
+		}
+		if svc.gotID != uuid.Nil {
+			t.Errorf("%s %s changed %s while impersonating", pr.method, pr.path, svc.gotID)
+		}
+	}
+
+	// Support staff can still look at the profile
+	if rec := serve(newTestMux(&fakeUserAPI{}, impersonated), profileRequests("me")[0]); rec.Code != http.StatusOK {
+		t.Errorf("GET /users/me while impersonating: status = %d, want 200", rec.Code)
+	}
+}
+
+func TestRegisterCannotCreateAdmins(t *testing.T) {
+	const account = `"name":"Mallory","email":"mallory@example.com","password":"correct-horse-1"`
+
+	svc := &fakeRegistration{}
+	rec := serve(newTestMux(svc, nil), profileRequest{http.MethodPost, "/auth/register", `{` + account + `,"is_admin":true}`})
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("register with is_admin: status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+	if len(svc.got) != 0 {
+		t.Fatalf("registration with is_admin reached the service: %+v", svc.got)
+	}
+
+	rec = serve(newTestMux(svc, nil), profileRequest{http.MethodPost, "/auth/register", `{` + account + `}`})
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("register: status = %d %s, want %d", rec.Code, rec.Body, http.StatusCreated)
+	}
+	if len(svc.got) != 1 || svc.got[0].IsAdmin {
+		t.Fatalf("registered %+v, want one customer account", svc.got)
+	}
+}
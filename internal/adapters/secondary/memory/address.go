@@ -0,0 +1,105 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/address"
+	"github.com/google/uuid"
+)
+
+type AddressRepo struct {
+	s *Store
+}
+
+func NewAddressRepo(s *Store) (*AddressRepo, error) {
+	if s == nil {
+		return nil, errors.New("store required")
+	}
+	return &AddressRepo{s: s}, nil
+}
+
+func (ar *AddressRepo) Create(ctx context.Context, a address.Address) (address.Address, error) {
+	ar.s.mu.Lock()
+	defer ar.s.mu.Unlock()
+	if _, ok := ar.s.addresses[a.ID]; ok {
+		return address.Address{}, uniqueViolation("addresses_pkey")
+	}
+	if _, ok := ar.s.users[a.UserID]; !ok {
+		return address.Address{}, foreignKeyViolation("addresses_user_id_fkey")
+	}
+	ar.s.addresses[a.ID] = a
+	return a, nil
+}
+
+func (ar *AddressRepo) ListByUserID(ctx context.Context, userID uuid.UUID) ([]address.Address, error) {
+	ar.s.mu.RLock()
+	defer ar.s.mu.RUnlock()
+	var addresses []address.Address
+	for _, a := range ar.s.addresses {
+		if a.UserID == userID {
+			addresses = append(addresses, a)
+		}
+	}
+	// Default first, then by ID
+	slices.SortFunc(addresses, func(a, b address.Address) int {
+		if a.IsDefault != b.IsDefault {
+			if a.IsDefault {
+				return -1
+			}
+			return 1
+		}
+		return compareUUID(a.ID, b.ID)
+	})
+	return addresses, nil
+}
+
+func (ar *AddressRepo) GetByID(ctx context.Context, id uuid.UUID) (address.Address, error) {
+	ar.s.mu.RLock()
+	defer ar.s.mu.RUnlock()
+	a, ok := ar.s.addresses[id]
+	if !ok {
+		return address.Address{}, notFound("address")
+	}
+	return a, nil
+}
+
+func (ar *AddressRepo) DeleteById(ctx context.Context, id uuid.UUID) error {
+	ar.s.mu.Lock()
+	defer ar.s.mu.Unlock()
+	// Orders keep pointing at the address they were shipped to
+	for _, o := range ar.s.orders {
+		if o.AddressID == id {
+			return foreignKeyViolation("orders_address_id_fkey")
+		}
+	}
+	delete(ar.s.addresses, id)
+	return nil
+}
+
+func (ar *AddressRepo) SetDefault(ctx context.Context, userID, addressID uuid.UUID) error {
+	ar.s.mu.Lock()
+	defer ar.s.mu.Unlock()
+	if a, ok := ar.s.addresses[addressID]; !ok || a.UserID != userID {
+		return notFound("address")
+	}
+	for id, a := range ar.s.addresses {
+		if a.UserID == userID {
+			a.IsDefault = id == addressID
+			ar.s.addresses[id] = a
+		}
+	}
+	return nil
+}
+
+func (ar *AddressRepo) GetDefault(ctx context.Context, userID uuid.UUID) (address.Address, error) {
+	ar.s.mu.RLock()
+	defer ar.s.mu.RUnlock()
+	for _, a := range ar.s.addresses {
+		if a.UserID == userID && a.IsDefault {
+			return a, nil
+		}
+	}
+	return address.Address{}, notFound("address")
+}
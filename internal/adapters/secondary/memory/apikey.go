@@ -0,0 +1,100 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/apikey"
+	"github.com/frostnzx/go-ecommerce-api/internal/ports"
+	"github.com/google/uuid"
+)
+
+type APIKeyRepo struct {
+	s *Store
+}
+
+func NewAPIKeyRepo(s *Store) (*APIKeyRepo, error) {
+	if s == nil {
+		return nil, errors.New("store required")
+	}
+	return &APIKeyRepo{s: s}, nil
+}
+
+func (ar *APIKeyRepo) Create(ctx context.Context, k apikey.APIKey) error {
+	ar.s.mu.Lock()
+	defer ar.s.mu.Unlock()
+	if _, ok := ar.s.apiKeys[k.ID]; ok {
+		return uniqueViolation("api_keys_pkey")
+	}
+	for _, other := range ar.s.apiKeys {
+		if other.KeyHash == k.KeyHash {
+			return uniqueViolation("api_keys_key_hash_key")
+		}
+	}
+	if _, ok := ar.s.users[k.UserID]; !ok {
+		return foreignKeyViolation("api_keys_user_id_fkey")
+	}
+	ar.s.apiKeys[k.ID] = cloneAPIKey(k)
+	return nil
+}
+
+func (ar *APIKeyRepo) GetByHash(ctx context.Context, keyHash string) (*apikey.APIKey, error) {
+	ar.s.mu.RLock()
+	defer ar.s.mu.RUnlock()
+	for _, k := range ar.s.apiKeys {
+		if k.KeyHash == keyHash {
+			c := cloneAPIKey(k)
+			return &c, nil
+		}
+	}
+	return nil, notFound("api key")
+}
+
+func (ar *APIKeyRepo) ListByUserID(ctx context.Context, userID uuid.UUID) ([]apikey.APIKey, error) {
+	ar.s.mu.RLock()
+	defer ar.s.mu.RUnlock()
+	var keys []apikey.APIKey
+	for _, k := range ar.s.apiKeys {
+		if k.UserID == userID {
+			keys = append(keys, cloneAPIKey(k))
+		}
+	}
+	slices.SortFunc(keys, func(a, b apikey.APIKey) int {
+		return b.CreatedAt.Compare(a.CreatedAt)
+	})
+	return keys, nil
+}
+
+func (ar *APIKeyRepo) Revoke(ctx context.Context, id, userID uuid.UUID) error {
+	ar.s.mu.Lock()
+	defer ar.s.mu.Unlock()
+	k, ok := ar.s.apiKeys[id]
+	if !ok || k.UserID != userID || k.RevokedAt != nil {
+		return ports.ErrAPIKeyNotFound
+	}
+	now := timestamp(time.Now())
+	k.RevokedAt = &now
+	ar.s.apiKeys[id] = k
+	return nil
+}
+
+func (ar *APIKeyRepo) TouchLastUsed(ctx context.Context, id uuid.UUID, at time.Time) error {
+	ar.s.mu.Lock()
+	defer ar.s.mu.Unlock()
+	if k, ok := ar.s.apiKeys[id]; ok {
+		k.LastUsedAt = timestampPtr(&at)
+		ar.s.apiKeys[id] = k
+	}
+	return nil
+}
+
+func cloneAPIKey(k apikey.APIKey) apikey.APIKey {
+	k.Scopes = slices.Clone(k.Scopes)
+	k.CreatedAt = timestamp(k.CreatedAt)
+	k.ExpiresAt = timestamp(k.ExpiresAt)
+	k.LastUsedAt = timestampPtr(k.LastUsedAt)
+	k.RevokedAt = timestampPtr(k.RevokedAt)
+	return k
+}
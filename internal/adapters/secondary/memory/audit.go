@@ -0,0 +1,50 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/audit"
+	"github.com/google/uuid"
+)
+
+type AuditRepo struct {
+	s *Store
+}
+
+func NewAuditRepo(s *Store) (*AuditRepo, error) {
+	if s == nil {
+		return nil, errors.New("store required")
+	}
+	return &AuditRepo{s: s}, nil
+}
+
+func (ar *AuditRepo) Record(ctx context.Context, e audit.Entry) error {
+	ar.s.mu.Lock()
+	defer ar.s.mu.Unlock()
+	for _, other := range ar.s.auditLog {
+		if other.ID == e.ID {
+			return uniqueViolation("admin_audit_log_pkey")
+		}
+	}
+	e.CreatedAt = timestamp(e.CreatedAt)
+	ar.s.auditLog = append(ar.s.auditLog, e)
+	return nil
+}
+
+func (ar *AuditRepo) List(ctx context.Context, targetUserID uuid.UUID, limit, offset int) ([]audit.Entry, error) {
+	ar.s.mu.RLock()
+	defer ar.s.mu.RUnlock()
+	var entries []audit.Entry
+	for _, e := range ar.s.auditLog {
+		if targetUserID == uuid.Nil || e.TargetUserID == targetUserID {
+			entries = append(entries, e)
+		}
+	}
+	slices.SortStableFunc(entries, func(a, b audit.Entry) int {
+		return b.CreatedAt.Compare(a.CreatedAt)
+	})
+	total := len(entries)
+	return entries[min(offset, total):min(offset+limit, total)], nil
+}
@@ -0,0 +1,38 @@
+package memory
+
+import (
+	"testing"
+
+	"github.com/frostnzx/go-ecommerce-api/internal/ports/porttest"
+)
+
+func TestContract(t *testing.T) {
+	porttest.Run(t, func(t *testing.T) porttest.Repos {
+		s := NewStore()
+		users, _ := NewUserRepo(s)
+		sessions, _ := NewSessionRepo(s)
+		addresses, _ := NewAddressRepo(s)
+		orders, _ := NewOrderRepo(s)
+		items, _ := NewItemsRepo(s)
+		products, _ := NewProductRepo(s)
+		loginAttempts, _ := NewLoginAttemptRepo(s)
+		apiKeys, _ := NewAPIKeyRepo(s)
+		identities, _ := NewIdentityRepo(s)
+		privacy, _ := NewPrivacyRepo(s)
+		auditLog, _ := NewAuditRepo(s)
+		return porttest.Repos{
+			Users:     users,
+			Sessions:  sessions,
+			Addresses: addresses,
+			Orders:    orders,
+			Items:     items,
+			Products:  products,
+
+			LoginAttempts: loginAttempts,
+			APIKeys:       apiKeys,
+			Identities:    identities,
+			Privacy:       privacy,
+			Audit:         auditLog,
+		}
+	})
+}
@@ -0,0 +1,66 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/identity"
+	"github.com/google/uuid"
+)
+
+type IdentityRepo struct {
+	s *Store
+}
+
+func NewIdentityRepo(s *Store) (*IdentityRepo, error) {
+	if s == nil {
+		return nil, errors.New("store required")
+	}
+	return &IdentityRepo{s: s}, nil
+}
+
+func (ir *IdentityRepo) Create(ctx context.Context, i identity.Identity) error {
+	ir.s.mu.Lock()
+	defer ir.s.mu.Unlock()
+	if _, ok := ir.s.identities[i.ID]; ok {
+		return uniqueViolation("user_identities_pkey")
+	}
+	for _, other := range ir.s.identities {
+		if other.Provider == i.Provider && other.Subject == i.Subject {
+			return uniqueViolation("user_identities_provider_subject_key")
+		}
+	}
+	if _, ok := ir.s.users[i.UserID]; !ok {
+		return foreignKeyViolation("user_identities_user_id_fkey")
+	}
+	i.CreatedAt = timestamp(i.CreatedAt)
+	ir.s.identities[i.ID] = i
+	return nil
+}
+
+func (ir *IdentityRepo) GetByProviderSubject(ctx context.Context, provider, subject string) (*identity.Identity, error) {
+	ir.s.mu.RLock()
+	defer ir.s.mu.RUnlock()
+	for _, i := range ir.s.identities {
+		if i.Provider == provider && i.Subject == subject {
+			return &i, nil
+		}
+	}
+	return nil, notFound("identity")
+}
+
+func (ir *IdentityRepo) ListByUserID(ctx context.Context, userID uuid.UUID) ([]identity.Identity, error) {
+	ir.s.mu.RLock()
+	defer ir.s.mu.RUnlock()
+	var identities []identity.Identity
+	for _, i := range ir.s.identities {
+		if i.UserID == userID {
+			identities = append(identities, i)
+		}
+	}
+	slices.SortFunc(identities, func(a, b identity.Identity) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+	return identities, nil
+}
@@ -0,0 +1,85 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/items"
+	"github.com/google/uuid"
+)
+
+type ItemsRepo struct {
+	s *Store
+}
+
+func NewItemsRepo(s *Store) (*ItemsRepo, error) {
+	if s == nil {
+		return nil, errors.New("store required")
+	}
+	return &ItemsRepo{s: s}, nil
+}
+
+func (ir *ItemsRepo) Create(ctx context.Context, item items.Items) (items.Items, error) {
+	ir.s.mu.Lock()
+	defer ir.s.mu.Unlock()
+	if _, ok := ir.s.items[item.ID]; ok {
+		return items.Items{}, uniqueViolation("items_pkey")
+	}
+	if _, ok := ir.s.orders[item.OrderID]; !ok {
+		return items.Items{}, foreignKeyViolation("items_order_id_fkey")
+	}
+	if _, ok := ir.s.products[item.ProductID]; !ok {
+		return items.Items{}, foreignKeyViolation("items_product_id_fkey")
+	}
+	item.UnitPriceSnapshot = numeric(item.UnitPriceSnapshot)
+	ir.s.items[item.ID] = item
+	return item, nil
+}
+
+func (ir *ItemsRepo) ListByUserID(ctx context.Context, userID uuid.UUID) ([]items.Items, error) {
+	ir.s.mu.RLock()
+	defer ir.s.mu.RUnlock()
+	return ir.list(func(item items.Items) bool {
+		return ir.s.orders[item.OrderID].UserId == userID
+	}), nil
+}
+
+func (ir *ItemsRepo) ListByOrderID(ctx context.Context, orderID uuid.UUID) ([]items.Items, error) {
+	ir.s.mu.RLock()
+	defer ir.s.mu.RUnlock()
+	return ir.list(func(item items.Items) bool {
+		return item.OrderID == orderID
+	}), nil
+}
+
+func (ir *ItemsRepo) GetByID(ctx context.Context, id uuid.UUID) (items.Items, error) {
+	ir.s.mu.RLock()
+	defer ir.s.mu.RUnlock()
+	item, ok := ir.s.items[id]
+	if !ok {
+		return items.Items{}, notFound("item")
+	}
+	return item, nil
+}
+
+func (ir *ItemsRepo) DeleteById(ctx context.Context, id uuid.UUID) error {
+	ir.s.mu.Lock()
+	defer ir.s.mu.Unlock()
+	delete(ir.s.items, id)
+	return nil
+}
+
+// list returns the matching items in a stable order; the caller holds the lock
+func (ir *ItemsRepo) list(match func(items.Items) bool) []items.Items {
+	var list []items.Items
+	for _, item := range ir.s.items {
+		if match(item) {
+			list = append(list, item)
+		}
+	}
+	slices.SortFunc(list, func(a, b items.Items) int {
+		return compareUUID(a.ID, b.ID)
+	})
+	return list
+}
@@ -0,0 +1,63 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/lockout"
+)
+
+type loginAttemptKey struct {
+	scope   lockout.Scope
+	subject string
+}
+
+type LoginAttemptRepo struct {
+	s *Store
+}
+
+func NewLoginAttemptRepo(s *Store) (*LoginAttemptRepo, error) {
+	if s == nil {
+		return nil, errors.New("store required")
+	}
+	return &LoginAttemptRepo{s: s}, nil
+}
+
+func (lr *LoginAttemptRepo) Get(ctx context.Context, scope lockout.Scope, subject string) (*lockout.Attempts, error) {
+	lr.s.mu.RLock()
+	defer lr.s.mu.RUnlock()
+	a, ok := lr.s.loginAttempts[loginAttemptKey{scope, subject}]
+	if !ok {
+		a = lockout.New(scope, subject)
+	}
+	a = cloneAttempts(a)
+	return &a, nil
+}
+
+func (lr *LoginAttemptRepo) RecordFailure(ctx context.Context, scope lockout.Scope, subject string, p lockout.Policy, maxAttempts int, now time.Time) (*lockout.Attempts, error) {
+	lr.s.mu.Lock()
+	defer lr.s.mu.Unlock()
+	key := loginAttemptKey{scope, subject}
+	a, ok := lr.s.loginAttempts[key]
+	if !ok {
+		a = lockout.New(scope, subject)
+	}
+	a.RecordFailure(p, maxAttempts, now)
+	a = cloneAttempts(a)
+	lr.s.loginAttempts[key] = a
+	return &a, nil
+}
+
+func (lr *LoginAttemptRepo) Reset(ctx context.Context, scope lockout.Scope, subject string) error {
+	lr.s.mu.Lock()
+	defer lr.s.mu.Unlock()
+	delete(lr.s.loginAttempts, loginAttemptKey{scope, subject})
+	return nil
+}
+
+func cloneAttempts(a lockout.Attempts) lockout.Attempts {
+	a.LastFailedAt = timestamp(a.LastFailedAt)
+	a.LockedUntil = timestampPtr(a.LockedUntil)
+	return a
+}
@@ -0,0 +1,79 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/order"
+	"github.com/google/uuid"
+)
+
+type OrderRepo struct {
+	s *Store
+}
+
+func NewOrderRepo(s *Store) (*OrderRepo, error) {
+	if s == nil {
+		return nil, errors.New("store required")
+	}
+	return &OrderRepo{s: s}, nil
+}
+
+func (or *OrderRepo) Create(ctx context.Context, o order.Order) (order.Order, error) {
+	or.s.mu.Lock()
+	defer or.s.mu.Unlock()
+	if _, ok := or.s.orders[o.ID]; ok {
+		return order.Order{}, uniqueViolation("orders_pkey")
+	}
+	if _, ok := or.s.users[o.UserId]; !ok {
+		return order.Order{}, foreignKeyViolation("orders_user_id_fkey")
+	}
+	if _, ok := or.s.addresses[o.AddressID]; !ok {
+		return order.Order{}, foreignKeyViolation("orders_address_id_fkey")
+	}
+	o = cloneOrder(o)
+	or.s.orders[o.ID] = o
+	return o, nil
+}
+
+func (or *OrderRepo) GetByID(ctx context.Context, orderID uuid.UUID) (order.Order, error) {
+	or.s.mu.RLock()
+	defer or.s.mu.RUnlock()
+	o, ok := or.s.orders[orderID]
+	if !ok {
+		return order.Order{}, notFound("order")
+	}
+	return o, nil
+}
+
+func (or *OrderRepo) ListByUserID(ctx context.Context, userID uuid.UUID) ([]order.Order, error) {
+	or.s.mu.RLock()
+	defer or.s.mu.RUnlock()
+	var orders []order.Order
+	for _, o := range or.s.orders {
+		if o.UserId == userID {
+			orders = append(orders, o)
+		}
+	}
+	slices.SortFunc(orders, func(a, b order.Order) int {
+		return b.CreatedAt.Compare(a.CreatedAt)
+	})
+	return orders, nil
+}
+
+func (or *OrderRepo) UpdateStatus(ctx context.Context, orderID uuid.UUID, status order.OrderStatus) error {
+	or.s.mu.Lock()
+	defer or.s.mu.Unlock()
+	if o, ok := or.s.orders[orderID]; ok {
+		o.Status = status
+		or.s.orders[orderID] = o
+	}
+	return nil
+}
+
+func cloneOrder(o order.Order) order.Order {
+	o.TotalAmount = numeric(o.TotalAmount)
+	o.CreatedAt = timestamp(o.CreatedAt)
+	return o
+}
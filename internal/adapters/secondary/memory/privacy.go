@@ -0,0 +1,77 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/lockout"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/user"
+	"github.com/google/uuid"
+)
+
+type PrivacyRepo struct {
+	s *Store
+}
+
+func NewPrivacyRepo(s *Store) (*PrivacyRepo, error) {
+	if s == nil {
+		return nil, errors.New("store required")
+	}
+	return &PrivacyRepo{s: s}, nil
+}
+
+func (pr *PrivacyRepo) EraseUser(ctx context.Context, u user.User) error {
+	pr.s.mu.Lock()
+	defer pr.s.mu.Unlock()
+	stored, ok := pr.s.users[u.ID]
+	if !ok {
+		return notFound("user")
+	}
+	// The login attempt counter is keyed by the email, drop it before the email is replaced
+	delete(pr.s.loginAttempts, loginAttemptKey{lockout.ScopeAccount, strings.ToLower(strings.TrimSpace(stored.Email))})
+
+	stored.Email = u.Email
+	stored.PasswordHash = u.PasswordHash
+	stored.Name = u.Name
+	stored.IsAdmin = u.IsAdmin
+	stored.ErasedAt = timestampPtr(u.ErasedAt)
+	pr.s.users[u.ID] = stored
+
+	// Orders keep pointing at their address; city, province and country stay for accounting
+	ordered := make(map[uuid.UUID]bool)
+	for _, o := range pr.s.orders {
+		if o.UserId == u.ID {
+			ordered[o.AddressID] = true
+		}
+	}
+	for id, a := range pr.s.addresses {
+		if a.UserID != u.ID {
+			continue
+		}
+		if !ordered[id] {
+			delete(pr.s.addresses, id)
+			continue
+		}
+		a.Line1 = ""
+		a.PostalCode = ""
+		a.IsDefault = false
+		pr.s.addresses[id] = a
+	}
+	for id, sess := range pr.s.sessions {
+		if sess.UserID == u.ID {
+			pr.s.deleteSession(id)
+		}
+	}
+	for id, k := range pr.s.apiKeys {
+		if k.UserID == u.ID {
+			delete(pr.s.apiKeys, id)
+		}
+	}
+	for id, i := range pr.s.identities {
+		if i.UserID == u.ID {
+			delete(pr.s.identities, id)
+		}
+	}
+	return nil
+}
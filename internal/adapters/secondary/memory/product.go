@@ -0,0 +1,176 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/product"
+	"github.com/google/uuid"
+)
+
+type ProductRepo struct {
+	s *Store
+}
+
+func NewProductRepo(s *Store) (*ProductRepo, error) {
+	if s == nil {
+		return nil, errors.New("store required")
+	}
+	return &ProductRepo{s: s}, nil
+}
+
+func (pr *ProductRepo) Create(ctx context.Context, p product.Product) (product.Product, error) {
+	pr.s.mu.Lock()
+	defer pr.s.mu.Unlock()
+	if err := pr.checkUnique(p); err != nil {
+		return product.Product{}, err
+	}
+	if _, ok := pr.s.products[p.ID]; ok {
+		return product.Product{}, uniqueViolation("products_pkey")
+	}
+	p = cloneProduct(p)
+	pr.s.products[p.ID] = p
+	return p, nil
+}
+
+func (pr *ProductRepo) GetByID(ctx context.Context, id uuid.UUID) (product.Product, error) {
+	pr.s.mu.RLock()
+	defer pr.s.mu.RUnlock()
+	p, ok := pr.s.products[id]
+	if !ok {
+		return product.Product{}, notFound("product")
+	}
+	return p, nil
+}
+
+func (pr *ProductRepo) GetBySKU(ctx context.Context, sku string) (product.Product, error) {
+	pr.s.mu.RLock()
+	defer pr.s.mu.RUnlock()
+	if p, ok := pr.bySKU(sku); ok {
+		return p, nil
+	}
+	return product.Product{}, notFound("product")
+}
+
+func (pr *ProductRepo) DeleteById(ctx context.Context, id uuid.UUID) error {
+	pr.s.mu.Lock()
+	defer pr.s.mu.Unlock()
+	// Order items keep pointing at the product they were sold as
+	for _, item := range pr.s.items {
+		if item.ProductID == id {
+			return foreignKeyViolation("items_product_id_fkey")
+		}
+	}
+	delete(pr.s.products, id)
+	return nil
+}
+
+func (pr *ProductRepo) UpdateById(ctx context.Context, p product.Product) (product.Product, error) {
+	pr.s.mu.Lock()
+	defer pr.s.mu.Unlock()
+	existing, ok := pr.s.products[p.ID]
+	if !ok {
+		return product.Product{}, notFound("product")
+	}
+	if err := pr.checkUnique(p); err != nil {
+		return product.Product{}, err
+	}
+	existing.SKU, existing.Name, existing.Description = p.SKU, p.Name, p.Description
+	existing.Price, existing.StockQty, existing.Active = numeric(p.Price), p.StockQty, p.Active
+	pr.s.products[p.ID] = existing
+	return existing, nil
+}
+
+func (pr *ProductRepo) List(ctx context.Context) ([]product.Product, error) {
+	pr.s.mu.RLock()
+	defer pr.s.mu.RUnlock()
+	var products []product.Product
+	for _, p := range pr.s.products {
+		if p.Active {
+			products = append(products, p)
+		}
+	}
+	slices.SortFunc(products, func(a, b product.Product) int {
+		return b.CreatedAt.Compare(a.CreatedAt)
+	})
+	return products, nil
+}
+
+func (pr *ProductRepo) UpdateStock(ctx context.Context, id uuid.UUID, quantity int) error {
+	pr.s.mu.Lock()
+	defer pr.s.mu.Unlock()
+	p, ok := pr.s.products[id]
+	if !ok {
+		return notFound("product")
+	}
+	p.StockQty = quantity
+	pr.s.products[id] = p
+	return nil
+}
+
+func (pr *ProductRepo) AdjustStock(ctx context.Context, id uuid.UUID, delta int) (int, error) {
+	pr.s.mu.Lock()
+	defer pr.s.mu.Unlock()
+	p, ok := pr.s.products[id]
+	if !ok || p.StockQty+delta < 0 {
+		return 0, notFound("product")
+	}
+	p.StockQty += delta
+	pr.s.products[id] = p
+	return p.StockQty, nil
+}
+
+func (pr *ProductRepo) UpsertBySKU(ctx context.Context, products []product.Product) (int, error) {
+	pr.s.mu.Lock()
+	defer pr.s.mu.Unlock()
+
+	// Check every row before writing any, so a failed import changes nothing
+	inserted := make(map[uuid.UUID]bool)
+	for _, p := range products {
+		if _, ok := pr.bySKU(p.SKU); ok {
+			continue
+		}
+		if _, ok := pr.s.products[p.ID]; ok || inserted[p.ID] {
+			return 0, uniqueViolation("products_pkey")
+		}
+		inserted[p.ID] = true
+	}
+
+	created := 0
+	for _, p := range products {
+		if existing, ok := pr.bySKU(p.SKU); ok {
+			existing.Name, existing.Description = p.Name, p.Description
+			existing.Price, existing.StockQty = numeric(p.Price), p.StockQty
+			pr.s.products[existing.ID] = existing
+			continue
+		}
+		pr.s.products[p.ID] = cloneProduct(p)
+		created++
+	}
+	return created, nil
+}
+
+// bySKU finds a product by SKU; the caller holds the lock
+func (pr *ProductRepo) bySKU(sku string) (product.Product, bool) {
+	for _, p := range pr.s.products {
+		if p.SKU == sku {
+			return p, true
+		}
+	}
+	return product.Product{}, false
+}
+
+// checkUnique reports whether another product already uses p's SKU; the caller holds the lock
+func (pr *ProductRepo) checkUnique(p product.Product) error {
+	if other, ok := pr.bySKU(p.SKU); ok && other.ID != p.ID {
+		return uniqueViolation("products_sku_key")
+	}
+	return nil
+}
+
+func cloneProduct(p product.Product) product.Product {
+	p.Price = numeric(p.Price)
+	p.CreatedAt = timestamp(p.CreatedAt)
+	return p
+}
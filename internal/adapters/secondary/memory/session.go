@@ -0,0 +1,210 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/session"
+	"github.com/frostnzx/go-ecommerce-api/internal/ports"
+	"github.com/google/uuid"
+)
+
+type SessionRepo struct {
+	s *Store
+}
+
+func NewSessionRepo(s *Store) (*SessionRepo, error) {
+	if s == nil {
+		return nil, errors.New("store required")
+	}
+	return &SessionRepo{s: s}, nil
+}
+
+func (sr *SessionRepo) CreateSession(ctx context.Context, s *session.Session) (*session.Session, error) {
+	sr.s.mu.Lock()
+	defer sr.s.mu.Unlock()
+	if _, ok := sr.s.sessions[s.ID]; ok {
+		return nil, uniqueViolation("sessions_pkey")
+	}
+	if _, ok := sr.s.users[s.UserID]; !ok {
+		return nil, foreignKeyViolation("sessions_user_id_fkey")
+	}
+	if s.ImpersonatorID != nil {
+		if _, ok := sr.s.users[*s.ImpersonatorID]; !ok {
+			return nil, foreignKeyViolation("sessions_impersonator_id_fkey")
+		}
+	}
+	sr.s.sessions[s.ID] = cloneSession(*s)
+	return s, nil
+}
+
+func (sr *SessionRepo) GetSession(ctx context.Context, id string) (*session.Session, error) {
+	sr.s.mu.RLock()
+	defer sr.s.mu.RUnlock()
+	s, ok := sr.s.sessions[id]
+	if !ok {
+		return nil, notFound("session")
+	}
+	c := cloneSession(s)
+	return &c, nil
+}
+
+func (sr *SessionRepo) RevokeSession(ctx context.Context, id string) error {
+	sr.s.mu.Lock()
+	defer sr.s.mu.Unlock()
+	if s, ok := sr.s.sessions[id]; ok {
+		s.IsRevoked = true
+		sr.s.sessions[id] = s
+	}
+	return nil
+}
+
+func (sr *SessionRepo) DeleteSession(ctx context.Context, id string) error {
+	sr.s.mu.Lock()
+	defer sr.s.mu.Unlock()
+	sr.s.deleteSession(id)
+	return nil
+}
+
+func (sr *SessionRepo) DeleteSessionsByEmail(ctx context.Context, email string) error {
+	sr.s.mu.Lock()
+	defer sr.s.mu.Unlock()
+	for id, s := range sr.s.sessions {
+		if s.Email == email {
+			sr.s.deleteSession(id)
+		}
+	}
+	return nil
+}
+
+func (sr *SessionRepo) TouchSession(ctx context.Context, id string) error {
+	sr.s.mu.Lock()
+	defer sr.s.mu.Unlock()
+	if s, ok := sr.s.sessions[id]; ok {
+		s.LastUsedAt = timestamp(time.Now())
+		sr.s.sessions[id] = s
+	}
+	return nil
+}
+
+func (sr *SessionRepo) DeleteExpiredSessions(ctx context.Context, before time.Time) (int64, error) {
+	sr.s.mu.Lock()
+	defer sr.s.mu.Unlock()
+	var n int64
+	for id, s := range sr.s.sessions {
+		if s.ExpiresAt.Before(before) {
+			sr.s.deleteSession(id)
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (sr *SessionRepo) ListSessionsByUserID(ctx context.Context, userID uuid.UUID) ([]session.Session, error) {
+	sr.s.mu.RLock()
+	defer sr.s.mu.RUnlock()
+	now := time.Now()
+	var sessions []session.Session
+	for _, s := range sr.s.sessions {
+		if s.UserID == userID && !s.IsRevoked && s.ExpiresAt.After(now) {
+			sessions = append(sessions, cloneSession(s))
+		}
+	}
+	slices.SortFunc(sessions, func(a, b session.Session) int {
+		return b.LastUsedAt.Compare(a.LastUsedAt)
+	})
+	return sessions, nil
+}
+
+func (sr *SessionRepo) RevokeSessionsByUserID(ctx context.Context, userID uuid.UUID, exceptID string) ([]string, error) {
+	sr.s.mu.Lock()
+	defer sr.s.mu.Unlock()
+	var ids []string
+	for id, s := range sr.s.sessions {
+		if s.UserID == userID && !s.IsRevoked && id != exceptID {
+			s.IsRevoked = true
+			sr.s.sessions[id] = s
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+func (sr *SessionRepo) CreateRefreshToken(ctx context.Context, t *session.RefreshToken) error {
+	sr.s.mu.Lock()
+	defer sr.s.mu.Unlock()
+	if err := sr.s.checkRefreshToken(*t); err != nil {
+		return err
+	}
+	sr.s.refreshTokens[t.ID] = cloneRefreshToken(*t)
+	return nil
+}
+
+// checkRefreshToken enforces the refresh_tokens constraints; the caller holds the write lock
+func (s *Store) checkRefreshToken(t session.RefreshToken) error {
+	if _, ok := s.refreshTokens[t.ID]; ok {
+		return uniqueViolation("refresh_tokens_pkey")
+	}
+	for _, other := range s.refreshTokens {
+		if other.TokenHash == t.TokenHash {
+			return uniqueViolation("refresh_tokens_token_hash_key")
+		}
+	}
+	if _, ok := s.sessions[t.SessionID]; !ok {
+		return foreignKeyViolation("refresh_tokens_session_id_fkey")
+	}
+	return nil
+}
+
+func (sr *SessionRepo) GetRefreshToken(ctx context.Context, tokenHash string) (*session.RefreshToken, error) {
+	sr.s.mu.RLock()
+	defer sr.s.mu.RUnlock()
+	for _, t := range sr.s.refreshTokens {
+		if t.TokenHash == tokenHash {
+			c := cloneRefreshToken(t)
+			return &c, nil
+		}
+	}
+	return nil, notFound("refresh token")
+}
+
+func (sr *SessionRepo) RotateRefreshToken(ctx context.Context, consumedID string, next *session.RefreshToken) error {
+	sr.s.mu.Lock()
+	defer sr.s.mu.Unlock()
+	t, ok := sr.s.refreshTokens[consumedID]
+	if !ok || t.IsConsumed() {
+		return ports.ErrRefreshTokenConsumed
+	}
+	if err := sr.s.checkRefreshToken(*next); err != nil {
+		return err
+	}
+	now := timestamp(time.Now())
+	t.ConsumedAt = &now
+	sr.s.refreshTokens[consumedID] = t
+	sr.s.refreshTokens[next.ID] = cloneRefreshToken(*next)
+	if s, ok := sr.s.sessions[next.SessionID]; ok {
+		s.LastUsedAt = now
+		sr.s.sessions[next.SessionID] = s
+	}
+	return nil
+}
+
+func cloneSession(s session.Session) session.Session {
+	s.CreatedAt = timestamp(s.CreatedAt)
+	s.LastUsedAt = timestamp(s.LastUsedAt)
+	s.ExpiresAt = timestamp(s.ExpiresAt)
+	if s.ImpersonatorID != nil {
+		id := *s.ImpersonatorID
+		s.ImpersonatorID = &id
+	}
+	return s
+}
+
+func cloneRefreshToken(t session.RefreshToken) session.RefreshToken {
+	t.CreatedAt = timestamp(t.CreatedAt)
+	t.ExpiresAt = timestamp(t.ExpiresAt)
+	t.ConsumedAt = timestampPtr(t.ConsumedAt)
+	return t
+}
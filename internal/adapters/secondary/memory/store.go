@@ -0,0 +1,148 @@
+// Package memory implements the repository ports in process memory. The
+// adapters are safe for concurrent use and enforce the same keys, foreign keys
+// and cascades as the PostgreSQL schema, so services can be exercised without
+// a database.
+package memory
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/address"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/apikey"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/audit"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/identity"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/items"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/lockout"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/order"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/product"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/session"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/user"
+	"github.com/frostnzx/go-ecommerce-api/internal/ports"
+	"github.com/google/uuid"
+)
+
+// Store holds the tables shared by the repositories, the way a database does for
+// the postgres adapters. Repositories over the same Store see each other's rows.
+type Store struct {
+	mu            sync.RWMutex
+	users         map[uuid.UUID]user.User
+	sessions      map[string]session.Session
+	refreshTokens map[string]session.RefreshToken
+	addresses     map[uuid.UUID]address.Address
+	orders        map[uuid.UUID]order.Order
+	items         map[uuid.UUID]items.Items
+	products      map[uuid.UUID]product.Product
+	loginAttempts map[loginAttemptKey]lockout.Attempts
+	apiKeys       map[uuid.UUID]apikey.APIKey
+	identities    map[uuid.UUID]identity.Identity
+	auditLog      []audit.Entry // Has no foreign keys, it outlives the accounts it mentions
+}
+
+func NewStore() *Store {
+	return &Store{
+		users:         make(map[uuid.UUID]user.User),
+		sessions:      make(map[string]session.Session),
+		refreshTokens: make(map[string]session.RefreshToken),
+		addresses:     make(map[uuid.UUID]address.Address),
+		orders:        make(map[uuid.UUID]order.Order),
+		items:         make(map[uuid.UUID]items.Items),
+		products:      make(map[uuid.UUID]product.Product),
+		loginAttempts: make(map[loginAttemptKey]lockout.Attempts),
+		apiKeys:       make(map[uuid.UUID]apikey.APIKey),
+		identities:    make(map[uuid.UUID]identity.Identity),
+	}
+}
+
+// The errors below wrap the port sentinels the postgres adapters translate driver errors into
+
+func notFound(what string) error {
+	return fmt.Errorf("error getting %s: %w", what, ports.ErrNotFound)
+}
+
+func uniqueViolation(constraint string) error {
+	return fmt.Errorf("%w: violates unique constraint %q", ports.ErrConflict, constraint)
+}
+
+func foreignKeyViolation(constraint string) error {
+	return fmt.Errorf("%w: violates foreign key constraint %q", ports.ErrReference, constraint)
+}
+
+// timestamp stores t the way a TIMESTAMP column does: in UTC with microsecond precision
+func timestamp(t time.Time) time.Time {
+	return t.Round(time.Microsecond).UTC()
+}
+
+func timestampPtr(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	ts := timestamp(*t)
+	return &ts
+}
+
+// numeric stores v the way a NUMERIC(12,2) column does
+func numeric(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+// compareUUID orders IDs the way PostgreSQL orders the uuid type
+func compareUUID(a, b uuid.UUID) int {
+	return bytes.Compare(a[:], b[:])
+}
+
+// deleteUser removes the user and cascades to the rows that reference it
+func (s *Store) deleteUser(id uuid.UUID) {
+	if _, ok := s.users[id]; !ok {
+		return
+	}
+	delete(s.users, id)
+	for sid, sess := range s.sessions {
+		if sess.UserID == id || (sess.ImpersonatorID != nil && *sess.ImpersonatorID == id) {
+			s.deleteSession(sid)
+		}
+	}
+	for oid, o := range s.orders {
+		if o.UserId == id {
+			s.deleteOrder(oid)
+		}
+	}
+	for aid, a := range s.addresses {
+		if a.UserID == id {
+			delete(s.addresses, aid)
+		}
+	}
+	for kid, k := range s.apiKeys {
+		if k.UserID == id {
+			delete(s.apiKeys, kid)
+		}
+	}
+	for iid, i := range s.identities {
+		if i.UserID == id {
+			delete(s.identities, iid)
+		}
+	}
+}
+
+// deleteSession removes the session and its refresh tokens
+func (s *Store) deleteSession(id string) {
+	delete(s.sessions, id)
+	for tid, t := range s.refreshTokens {
+		if t.SessionID == id {
+			delete(s.refreshTokens, tid)
+		}
+	}
+}
+
+// deleteOrder removes the order and its items
+func (s *Store) deleteOrder(id uuid.UUID) {
+	delete(s.orders, id)
+	for iid, it := range s.items {
+		if it.OrderID == id {
+			delete(s.items, iid)
+		}
+	}
+}
@@ -0,0 +1,162 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/user"
+	"github.com/frostnzx/go-ecommerce-api/internal/ports"
+	"github.com/google/uuid"
+)
+
+type UserRepo struct {
+	s *Store
+}
+
+func NewUserRepo(s *Store) (*UserRepo, error) {
+	if s == nil {
+		return nil, errors.New("store required")
+	}
+	return &UserRepo{s: s}, nil
+}
+
+func (ur *UserRepo) Create(ctx context.Context, u user.User) error {
+	ur.s.mu.Lock()
+	defer ur.s.mu.Unlock()
+	if _, ok := ur.s.users[u.ID]; ok {
+		return uniqueViolation("users_pkey")
+	}
+	if ur.emailTaken(u.Email, u.ID) {
+		return uniqueViolation("users_email_key")
+	}
+	ur.s.users[u.ID] = cloneUser(u)
+	return nil
+}
+
+func (ur *UserRepo) GetUser(ctx context.Context, email string) (*user.User, error) {
+	ur.s.mu.RLock()
+	defer ur.s.mu.RUnlock()
+	for _, u := range ur.s.users {
+		if u.Email == email {
+			c := cloneUser(u)
+			return &c, nil
+		}
+	}
+	return nil, notFound("user")
+}
+
+func (ur *UserRepo) GetUserByID(ctx context.Context, id uuid.UUID) (*user.User, error) {
+	ur.s.mu.RLock()
+	defer ur.s.mu.RUnlock()
+	u, ok := ur.s.users[id]
+	if !ok {
+		return nil, notFound("user")
+	}
+	c := cloneUser(u)
+	return &c, nil
+}
+
+func (ur *UserRepo) ListUsers(ctx context.Context) ([]*user.User, error) {
+	ur.s.mu.RLock()
+	defer ur.s.mu.RUnlock()
+	users := make([]*user.User, 0, len(ur.s.users))
+	for _, u := range ur.s.users {
+		c := cloneUser(u)
+		users = append(users, &c)
+	}
+	return users, nil
+}
+
+func (ur *UserRepo) SearchUsers(ctx context.Context, f ports.UserFilter) ([]*user.User, int, error) {
+	ur.s.mu.RLock()
+	defer ur.s.mu.RUnlock()
+	query := strings.ToLower(f.Query)
+	var matches []user.User
+	for _, u := range ur.s.users {
+		if strings.Contains(strings.ToLower(u.Email), query) || strings.Contains(strings.ToLower(u.Name), query) {
+			matches = append(matches, u)
+		}
+	}
+	slices.SortFunc(matches, func(a, b user.User) int {
+		if c := b.CreatedAt.Compare(a.CreatedAt); c != 0 {
+			return c
+		}
+		return compareUUID(a.ID, b.ID)
+	})
+
+	total := len(matches)
+	page := matches[min(f.Offset, total):min(f.Offset+f.Limit, total)]
+	users := make([]*user.User, 0, len(page))
+	for _, u := range page {
+		c := cloneUser(u)
+		users = append(users, &c)
+	}
+	return users, total, nil
+}
+
+func (ur *UserRepo) SetSuspended(ctx context.Context, id uuid.UUID, suspendedAt *time.Time) error {
+	ur.s.mu.Lock()
+	defer ur.s.mu.Unlock()
+	u, ok := ur.s.users[id]
+	if !ok {
+		return ports.ErrUserNotFound
+	}
+	u.SuspendedAt = timestampPtr(suspendedAt)
+	ur.s.users[id] = u
+	return nil
+}
+
+func (ur *UserRepo) UpdatePasswordHash(ctx context.Context, id uuid.UUID, passwordHash string) error {
+	ur.s.mu.Lock()
+	defer ur.s.mu.Unlock()
+	u, ok := ur.s.users[id]
+	if !ok {
+		return ports.ErrUserNotFound
+	}
+	u.PasswordHash = passwordHash
+	ur.s.users[id] = u
+	return nil
+}
+
+func (ur *UserRepo) UpdateUser(ctx context.Context, u user.User) error {
+	ur.s.mu.Lock()
+	defer ur.s.mu.Unlock()
+	existing, ok := ur.s.users[u.ID]
+	if !ok {
+		return ports.ErrUserNotFound
+	}
+	if ur.emailTaken(u.Email, u.ID) {
+		return uniqueViolation("users_email_key")
+	}
+	existing.Name, existing.Email, existing.PasswordHash, existing.IsAdmin = u.Name, u.Email, u.PasswordHash, u.IsAdmin
+	ur.s.users[u.ID] = existing
+	return nil
+}
+
+func (ur *UserRepo) DeleteUser(ctx context.Context, id uuid.UUID) error {
+	ur.s.mu.Lock()
+	defer ur.s.mu.Unlock()
+	ur.s.deleteUser(id)
+	return nil
+}
+
+// emailTaken reports whether another user already has the email; the caller holds the lock
+func (ur *UserRepo) emailTaken(email string, id uuid.UUID) bool {
+	for _, other := range ur.s.users {
+		if other.Email == email && other.ID != id {
+			return true
+		}
+	}
+	return false
+}
+
+// cloneUser copies u with its timestamps as the database would store them
+func cloneUser(u user.User) user.User {
+	u.CreatedAt = timestamp(u.CreatedAt)
+	u.ErasedAt = timestampPtr(u.ErasedAt)
+	u.SuspendedAt = timestampPtr(u.SuspendedAt)
+	return u
+}
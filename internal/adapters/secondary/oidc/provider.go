@@ -0,0 +1,100 @@
+package oidc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/identity"
+	"golang.org/x/oauth2"
+)
+
+type Config struct {
+	Issuer       string // Discovery is done against <Issuer>/.well-known/openid-configuration
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string // Defaults to openid, email and profile
+}
+
+// Provider is an OpenID Connect provider driven through the authorization code flow with PKCE
+type Provider struct {
+	name     string
+	oauth    *oauth2.Config
+	verifier *oidc.IDTokenVerifier
+}
+
+func NewProvider(ctx context.Context, name string, cfg Config) (*Provider, error) {
+	if name == "" || cfg.Issuer == "" || cfg.ClientID == "" || cfg.RedirectURL == "" {
+		return nil, errors.New("provider name, issuer, client ID and redirect URL are required")
+	}
+
+	discovered, err := oidc.NewProvider(ctx, cfg.Issuer)
+	if err != nil {
+		return nil, fmt.Errorf("error discovering provider %q: %w", name, err)
+	}
+
+	scopes := cfg.Scopes
+	if len(scopes) == 0 {
+		scopes = []string{oidc.ScopeOpenID, "email", "profile"}
+	}
+
+	return &Provider{
+		name: name,
+		oauth: &oauth2.Config{
+			ClientID:     cfg.ClientID,
+			ClientSecret: cfg.ClientSecret,
+			RedirectURL:  cfg.RedirectURL,
+			Endpoint:     discovered.Endpoint(),
+			Scopes:       scopes,
+		},
+		verifier: discovered.Verifier(&oidc.Config{ClientID: cfg.ClientID}),
+	}, nil
+}
+
+func (p *Provider) Name() string {
+	return p.name
+}
+
+func (p *Provider) AuthCodeURL(state, nonce, codeVerifier string) string {
+	return p.oauth.AuthCodeURL(state, oidc.Nonce(nonce), oauth2.S256ChallengeOption(codeVerifier))
+}
+
+func (p *Provider) Exchange(ctx context.Context, code, codeVerifier, nonce string) (*identity.External, error) {
+	token, err := p.oauth.Exchange(ctx, code, oauth2.VerifierOption(codeVerifier))
+	if err != nil {
+		return nil, fmt.Errorf("error exchanging authorization code: %w", err)
+	}
+
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok || rawIDToken == "" {
+		return nil, errors.New("token response has no id_token")
+	}
+
+	// Checks signature, issuer, audience and expiry
+	idToken, err := p.verifier.Verify(ctx, rawIDToken)
+	if err != nil {
+		return nil, fmt.Errorf("error verifying id token: %w", err)
+	}
+	if idToken.Nonce != nonce {
+		return nil, errors.New("id token nonce mismatch")
+	}
+
+	var claims struct {
+		Email         string `json:"email"`
+		EmailVerified bool   `json:"email_verified"`
+		Name          string `json:"name"`
+	}
+	if err := idToken.Claims(&claims); err != nil {
+		return nil, fmt.Errorf("error reading id token claims: %w", err)
+	}
+
+	return &identity.External{
+		Provider:      p.name,
+		Subject:       idToken.Subject,
+		Email:         claims.Email,
+		EmailVerified: claims.EmailVerified,
+		Name:          claims.Name,
+	}, nil
+}
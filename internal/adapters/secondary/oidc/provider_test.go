@@ -0,0 +1,234 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+)
+
+const (
+	testClientID     = "shop"
+	testClientSecret = "secret"
+	testRedirectURL  = "http://localhost:8080/auth/oidc/mock/callback"
+)
+
+// mockIdP is a minimal OpenID Connect provider: discovery, JWKS and a token
+// endpoint that enforces PKCE. Authorization is simulated with authorize().
+type mockIdP struct {
+	t      *testing.T
+	server *httptest.Server
+	key    *rsa.PrivateKey
+
+	mu    sync.Mutex
+	codes map[string]grant
+}
+
+type grant struct {
+	challenge string
+	nonce     string
+	subject   string
+	email     string
+	verified  bool
+}
+
+func newMockIdP(t *testing.T) *mockIdP {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	idp := &mockIdP{t: t, key: key, codes: map[string]grant{}}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /.well-known/openid-configuration", idp.discovery)
+	mux.HandleFunc("GET /jwks", idp.jwks)
+	mux.HandleFunc("POST /token", idp.token)
+	idp.server = httptest.NewServer(mux)
+	t.Cleanup(idp.server.Close)
+	return idp
+}
+
+func (idp *mockIdP) discovery(w http.ResponseWriter, r *http.Request) {
+	issuer := idp.server.URL
+	json.NewEncoder(w).Encode(map[string]any{
+		"issuer":                                issuer,
+		"authorization_endpoint":                issuer + "/authorize",
+		"token_endpoint":                        issuer + "/token",
+		"jwks_uri":                              issuer + "/jwks",
+		"id_token_signing_alg_values_supported": []string{"RS256"},
+	})
+}
+
+func (idp *mockIdP) jwks(w http.ResponseWriter, r *http.Request) {
+	json.NewEncoder(w).Encode(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{
+		{Key: &idp.key.PublicKey, KeyID: "mock", Algorithm: "RS256", Use: "sig"},
+	}})
+}
+
+// authorize simulates the user approving the request described by authURL and returns the code
+func (idp *mockIdP) authorize(authURL string, g grant) (state, code string) {
+	idp.t.Helper()
+	u, err := url.Parse(authURL)
+	if err != nil {
+		idp.t.Fatal(err)
+	}
+	q := u.Query()
+	if q.Get("code_challenge_method") != "S256" {
+		idp.t.Fatalf("code_challenge_method = %q, want S256", q.Get("code_challenge_method"))
+	}
+	g.challenge = q.Get("code_challenge")
+	g.nonce = q.Get("nonce")
+
+	code = "code-" + g.subject
+	idp.mu.Lock()
+	idp.codes[code] = g
+	idp.mu.Unlock()
+	return q.Get("state"), code
+}
+
+func (idp *mockIdP) token(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "bad request", http.StatusBadRequest)
+		return
+	}
+	clientID, clientSecret, ok := r.BasicAuth()
+	if !ok {
+		clientID, clientSecret = r.PostForm.Get("client_id"), r.PostForm.Get("client_secret")
+	}
+	if clientID != testClientID || clientSecret != testClientSecret {
+		tokenError(w, "invalid_client")
+		return
+	}
+
+	idp.mu.Lock()
+	g, ok := idp.codes[r.PostForm.Get("code")]
+	delete(idp.codes, r.PostForm.Get("code"))
+	idp.mu.Unlock()
+	if !ok || r.PostForm.Get("redirect_uri") != testRedirectURL {
+		tokenError(w, "invalid_grant")
+		return
+	}
+	sum := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+	if base64.RawURLEncoding.EncodeToString(sum[:]) != g.challenge {
+		tokenError(w, "invalid_grant")
+		return
+	}
+
+	idToken := idp.sign(map[string]any{
+		"iss":            idp.server.URL,
+		"sub":            g.subject,
+		"aud":            testClientID,
+		"exp":            time.Now().Add(time.Minute).Unix(),
+		"iat":            time.Now().Unix(),
+		"nonce":          g.nonce,
+		"email":          g.email,
+		"email_verified": g.verified,
+		"name":           "Mock User",
+	})
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]any{
+		"access_token": "mock-access-token",
+		"token_type":   "Bearer",
+		"expires_in":   60,
+		"id_token":     idToken,
+	})
+}
+
+func (idp *mockIdP) sign(claims map[string]any) string {
+	idp.t.Helper()
+	signer, err := jose.NewSigner(
+		jose.SigningKey{Algorithm: jose.RS256, Key: idp.key},
+		(&jose.SignerOptions{}).WithHeader("kid", "mock"),
+	)
+	if err != nil {
+		idp.t.Fatal(err)
+	}
+	payload, _ := json.Marshal(claims)
+	jws, err := signer.Sign(payload)
+	if err != nil {
+		idp.t.Fatal(err)
+	}
+	compact, err := jws.CompactSerialize()
+	if err != nil {
+		idp.t.Fatal(err)
+	}
+	return compact
+}
+
+func tokenError(w http.ResponseWriter, code string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusBadRequest)
+	json.NewEncoder(w).Encode(map[string]string{"error": code})
+}
+
+func newTestProvider(t *testing.T, idp *mockIdP) *Provider {
+	t.Helper()
+	p, err := NewProvider(context.Background(), "mock", Config{
+		Issuer:       idp.server.URL,
+		ClientID:     testClientID,
+		ClientSecret: testClientSecret,
+		RedirectURL:  testRedirectURL,
+	})
+	if err != nil {
+		t.Fatalf("NewProvider: %v", err)
+	}
+	return p
+}
+
+func TestProviderExchange(t *testing.T) {
+	idp := newMockIdP(t)
+	p := newTestProvider(t, idp)
+
+	authURL := p.AuthCodeURL("state-1", "nonce-1", "verifier-verifier-verifier-verifier-verifier")
+	if strings.Contains(authURL, "verifier-verifier") {
+		t.Fatal("auth URL leaks the PKCE verifier")
+	}
+	state, code := idp.authorize(authURL, grant{subject: "42", email: "jane@example.com", verified: true})
+	if state != "state-1" {
+		t.Fatalf("state = %q, want state-1", state)
+	}
+
+	ext, err := p.Exchange(context.Background(), code, "verifier-verifier-verifier-verifier-verifier", "nonce-1")
+	if err != nil {
+		t.Fatalf("Exchange: %v", err)
+	}
+	if ext.Provider != "mock" || ext.Subject != "42" || ext.Email != "jane@example.com" || !ext.EmailVerified {
+		t.Fatalf("unexpected identity %+v", ext)
+	}
+}
+
+func TestProviderExchangeRejectsWrongVerifier(t *testing.T) {
+	idp := newMockIdP(t)
+	p := newTestProvider(t, idp)
+
+	authURL := p.AuthCodeURL("state-1", "nonce-1", "verifier-verifier-verifier-verifier-verifier")
+	_, code := idp.authorize(authURL, grant{subject: "42", email: "jane@example.com", verified: true})
+
+	if _, err := p.Exchange(context.Background(), code, "another-verifier-another-verifier-another", "nonce-1"); err == nil {
+		t.Fatal("expected exchange with the wrong PKCE verifier to fail")
+	}
+}
+
+func TestProviderExchangeRejectsNonceMismatch(t *testing.T) {
+	idp := newMockIdP(t)
+	p := newTestProvider(t, idp)
+
+	authURL := p.AuthCodeURL("state-1", "nonce-1", "verifier-verifier-verifier-verifier-verifier")
+	_, code := idp.authorize(authURL, grant{subject: "42", email: "jane@example.com", verified: true})
+
+	if _, err := p.Exchange(context.Background(), code, "verifier-verifier-verifier-verifier-verifier", "nonce-2"); err == nil {
+		t.Fatal("expected exchange with a different nonce to fail")
+	}
+}
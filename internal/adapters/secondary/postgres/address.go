@@ -3,8 +3,10 @@ package postgres
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/address"
+	"github.com/frostnzx/go-ecommerce-api/internal/ports"
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
 )
@@ -31,7 +33,7 @@ func (ar *AddressRepo) Create(ctx context.Context, a address.Address) (address.A
 		a.ID, a.UserID, a.Line1, a.City, a.Province, a.PostalCode, a.Country, a.IsDefault,
 	).StructScan(&created)
 	if err != nil {
-		return address.Address{}, err
+		return address.Address{}, translateError(err)
 	}
 	return created, nil
 }
@@ -46,7 +48,7 @@ func (ar *AddressRepo) ListByUserID(ctx context.Context, userID uuid.UUID) ([]ad
 	var addresses []address.Address
 	err := ar.db.SelectContext(ctx, &addresses, query, userID)
 	if err != nil {
-		return nil, err
+		return nil, translateError(err)
 	}
 	return addresses, nil
 }
@@ -60,7 +62,7 @@ func (ar *AddressRepo) GetByID(ctx context.Context, id uuid.UUID) (address.Addre
 	var a address.Address
 	err := ar.db.GetContext(ctx, &a, query, id)
 	if err != nil {
-		return address.Address{}, err
+		return address.Address{}, translateError(err)
 	}
 	return a, nil
 }
@@ -68,21 +70,21 @@ func (ar *AddressRepo) GetByID(ctx context.Context, id uuid.UUID) (address.Addre
 func (ar *AddressRepo) DeleteById(ctx context.Context, id uuid.UUID) error {
 	query := `DELETE FROM addresses WHERE id = $1`
 	_, err := ar.db.ExecContext(ctx, query, id)
-	return err
+	return translateError(err)
 }
 
 func (ar *AddressRepo) SetDefault(ctx context.Context, userID, addressID uuid.UUID) error {
-	// First, unset all defaults for this user
-	unsetQuery := `UPDATE addresses SET is_default = false WHERE user_id = $1`
-	_, err := ar.db.ExecContext(ctx, unsetQuery, userID)
+	// One statement, so the old default is only cleared when the new one exists and belongs to the user
+	query := `
+		UPDATE addresses SET is_default = (id = $2)
+		WHERE user_id = $1
+		AND EXISTS (SELECT 1 FROM addresses WHERE id = $2 AND user_id = $1)
+	`
+	res, err := ar.db.ExecContext(ctx, query, userID, addressID)
 	if err != nil {
-		return err
+		return translateError(err)
 	}
-
-	// Then set the new default
-	setQuery := `UPDATE addresses SET is_default = true WHERE id = $1 AND user_id = $2`
-	_, err = ar.db.ExecContext(ctx, setQuery, addressID, userID)
-	return err
+	return requireRow(res, fmt.Errorf("address %s: %w", addressID, ports.ErrNotFound))
 }
 
 func (ar *AddressRepo) GetDefault(ctx context.Context, userID uuid.UUID) (address.Address, error) {
@@ -94,7 +96,7 @@ func (ar *AddressRepo) GetDefault(ctx context.Context, userID uuid.UUID) (addres
 	var a address.Address
 	err := ar.db.GetContext(ctx, &a, query, userID)
 	if err != nil {
-		return address.Address{}, err
+		return address.Address{}, translateError(err)
 	}
 	return a, nil
 }
@@ -0,0 +1,66 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/apikey"
+	"github.com/frostnzx/go-ecommerce-api/internal/ports"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type APIKeyRepo struct {
+	db *sqlx.DB
+}
+
+func NewAPIKeyRepo(db *sqlx.DB) (*APIKeyRepo, error) {
+	if db == nil {
+		return nil, errors.New("database connection required")
+	}
+	return &APIKeyRepo{db: db}, nil
+}
+
+func (ar *APIKeyRepo) Create(ctx context.Context, k apikey.APIKey) error {
+	_, err := ar.db.NamedExecContext(ctx, "INSERT INTO api_keys (id, user_id, name, prefix, key_hash, scopes, created_at, expires_at) VALUES (:id, :user_id, :name, :prefix, :key_hash, :scopes, :created_at, :expires_at)", k)
+	if err != nil {
+		return fmt.Errorf("error creating api key: %w", translateError(err))
+	}
+	return nil
+}
+
+func (ar *APIKeyRepo) GetByHash(ctx context.Context, keyHash string) (*apikey.APIKey, error) {
+	var k apikey.APIKey
+	err := ar.db.GetContext(ctx, &k, "SELECT * FROM api_keys WHERE key_hash=$1", keyHash)
+	if err != nil {
+		return nil, fmt.Errorf("error getting api key: %w", translateError(err))
+	}
+	return &k, nil
+}
+
+func (ar *APIKeyRepo) ListByUserID(ctx context.Context, userID uuid.UUID) ([]apikey.APIKey, error) {
+	var keys []apikey.APIKey
+	err := ar.db.SelectContext(ctx, &keys, "SELECT * FROM api_keys WHERE user_id=$1 ORDER BY created_at DESC", userID)
+	if err != nil {
+		return nil, fmt.Errorf("error listing api keys: %w", err)
+	}
+	return keys, nil
+}
+
+func (ar *APIKeyRepo) Revoke(ctx context.Context, id, userID uuid.UUID) error {
+	res, err := ar.db.ExecContext(ctx, "UPDATE api_keys SET revoked_at=NOW() WHERE id=$1 AND user_id=$2 AND revoked_at IS NULL", id, userID)
+	if err != nil {
+		return fmt.Errorf("error revoking api key: %w", translateError(err))
+	}
+	return requireRow(res, ports.ErrAPIKeyNotFound)
+}
+
+func (ar *APIKeyRepo) TouchLastUsed(ctx context.Context, id uuid.UUID, at time.Time) error {
+	_, err := ar.db.ExecContext(ctx, "UPDATE api_keys SET last_used_at=$2 WHERE id=$1", id, at)
+	if err != nil {
+		return fmt.Errorf("error updating api key: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/audit"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type AuditRepo struct {
+	db *sqlx.DB
+}
+
+func NewAuditRepo(db *sqlx.DB) (*AuditRepo, error) {
+	if db == nil {
+		return nil, errors.New("database connection required")
+	}
+	return &AuditRepo{db: db}, nil
+}
+
+func (ar *AuditRepo) Record(ctx context.Context, e audit.Entry) error {
+	_, err := ar.db.NamedExecContext(ctx, "INSERT INTO admin_audit_log (id, actor_id, action, target_user_id, details, created_at) VALUES (:id, :actor_id, :action, :target_user_id, :details, :created_at)", e)
+	if err != nil {
+		return fmt.Errorf("error recording audit entry: %w", err)
+	}
+	return nil
+}
+
+func (ar *AuditRepo) List(ctx context.Context, targetUserID uuid.UUID, limit, offset int) ([]audit.Entry, error) {
+	var entries []audit.Entry
+	err := ar.db.SelectContext(ctx, &entries, "SELECT * FROM admin_audit_log WHERE ($1 = '00000000-0000-0000-0000-000000000000'::uuid OR target_user_id = $1) ORDER BY created_at DESC LIMIT $2 OFFSET $3", targetUserID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("error listing audit entries: %w", err)
+	}
+	return entries, nil
+}
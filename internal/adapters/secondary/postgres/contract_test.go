@@ -0,0 +1,63 @@
+package postgres
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/frostnzx/go-ecommerce-api/internal/ports/porttest"
+)
+
+// TestContract runs the repository contract suite against a real database.
+// Set TEST_DATABASE_URL to a scratch database; every table in it is emptied.
+func TestContract(t *testing.T) {
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL not set")
+	}
+	ctx := context.Background()
+	db, err := Open(ctx, dsn, nil)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	m, err := NewMigrator(db)
+	if err != nil {
+		t.Fatalf("migrator: %v", err)
+	}
+	if _, err := m.Up(ctx); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+
+	porttest.Run(t, func(t *testing.T) porttest.Repos {
+		// Every other table the suite writes to references users or products, so the cascade empties them
+		if _, err := db.ExecContext(ctx, "TRUNCATE users, products, login_attempts, admin_audit_log CASCADE"); err != nil {
+			t.Fatalf("truncate: %v", err)
+		}
+		users, _ := NewUserRepo(db)
+		sessions, _ := NewSessionRepo(db)
+		addresses, _ := NewAddressRepo(db)
+		orders, _ := NewOrderRepo(db)
+		items, _ := NewItemsRepo(db)
+		products, _ := NewProductRepo(db)
+		loginAttempts, _ := NewLoginAttemptRepo(db)
+		apiKeys, _ := NewAPIKeyRepo(db)
+		identities, _ := NewIdentityRepo(db)
+		privacy, _ := NewPrivacyRepo(db)
+		auditLog, _ := NewAuditRepo(db)
+		return porttest.Repos{
+			Users:     users,
+			Sessions:  sessions,
+			Addresses: addresses,
+			Orders:    orders,
+			Items:     items,
+			Products:  products,
+
+			LoginAttempts: loginAttempts,
+			APIKeys:       apiKeys,
+			Identities:    identities,
+			Privacy:       privacy,
+			Audit:         auditLog,
+		}
+	})
+}
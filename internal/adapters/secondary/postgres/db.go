@@ -0,0 +1,173 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// QueryObserver is told about every statement the repositories send to the database
+type QueryObserver interface {
+	ObserveQuery(operation, table string, d time.Duration, err error)
+}
+
+// Open connects to PostgreSQL and verifies the connection. Every query gets a
+// client span under the caller's trace and, when obs is not nil, is timed and
+// reported to it by statement type and table.
+func Open(ctx context.Context, dsn string, obs QueryObserver) (*sqlx.DB, error) {
+	connector, err := pq.NewConnector(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing database DSN: %w", err)
+	}
+	c := &instrumentedConnector{Connector: connector, obs: obs, tracer: otel.Tracer(tracerName)}
+	db := sqlx.NewDb(sql.OpenDB(c), "postgres")
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("error connecting to database: %w", err)
+	}
+	return db, nil
+}
+
+const tracerName = "github.com/frostnzx/go-ecommerce-api/internal/adapters/secondary/postgres"
+
+type instrumentedConnector struct {
+	driver.Connector
+	obs    QueryObserver
+	tracer trace.Tracer
+}
+
+func (c *instrumentedConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.Connector.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &instrumentedConn{Conn: conn, connector: c}, nil
+}
+
+// instrumentedConn traces and times queries and forwards the optional driver interfaces lib/pq implements
+type instrumentedConn struct {
+	driver.Conn
+	connector *instrumentedConnector
+}
+
+func (c *instrumentedConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	execer, ok := c.Conn.(driver.ExecerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	ctx, done := c.start(ctx, query)
+	res, err := execer.ExecContext(ctx, query, args)
+	done(err)
+	return res, err
+}
+
+func (c *instrumentedConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	queryer, ok := c.Conn.(driver.QueryerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	ctx, done := c.start(ctx, query)
+	rows, err := queryer.QueryContext(ctx, query, args)
+	done(err)
+	return rows, err
+}
+
+func (c *instrumentedConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	if p, ok := c.Conn.(driver.ConnPrepareContext); ok {
+		return p.PrepareContext(ctx, query)
+	}
+	return c.Conn.Prepare(query)
+}
+
+func (c *instrumentedConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if b, ok := c.Conn.(driver.ConnBeginTx); ok {
+		return b.BeginTx(ctx, opts)
+	}
+	return c.Conn.Begin()
+}
+
+func (c *instrumentedConn) Ping(ctx context.Context) error {
+	if p, ok := c.Conn.(driver.Pinger); ok {
+		return p.Ping(ctx)
+	}
+	return nil
+}
+
+func (c *instrumentedConn) ResetSession(ctx context.Context) error {
+	if r, ok := c.Conn.(driver.SessionResetter); ok {
+		return r.ResetSession(ctx)
+	}
+	return nil
+}
+
+func (c *instrumentedConn) IsValid() bool {
+	if v, ok := c.Conn.(driver.Validator); ok {
+		return v.IsValid()
+	}
+	return true
+}
+
+// start opens a client span for query; the returned function ends it and reports the duration
+func (c *instrumentedConn) start(ctx context.Context, query string) (context.Context, func(error)) {
+	op, table := describeQuery(query)
+	ctx, span := c.connector.tracer.Start(ctx, strings.ToUpper(op)+" "+table,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemNamePostgreSQL,
+			semconv.DBOperationName(op),
+			semconv.DBCollectionName(table),
+			semconv.DBQueryText(query),
+		),
+	)
+	start := time.Now()
+	return ctx, func(err error) {
+		defer span.End()
+		if err == driver.ErrSkip {
+			return
+		}
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "query failed")
+		}
+		if c.connector.obs != nil {
+			c.connector.obs.ObserveQuery(op, table, time.Since(start), err)
+		}
+	}
+}
+
+var (
+	tablePattern = regexp.MustCompile(`(?i)\b(?:from|into|update|join)\s+([a-z_][a-z0-9_.]*)`)
+	describeMemo sync.Map // query -> [2]string; the repositories only use a fixed set of statements
+)
+
+// describeQuery returns the statement type and first table of query, e.g. ("select", "users")
+func describeQuery(query string) (string, string) {
+	if v, ok := describeMemo.Load(query); ok {
+		d := v.([2]string)
+		return d[0], d[1]
+	}
+	op, table := "other", "unknown"
+	if fields := strings.Fields(query); len(fields) > 0 {
+		switch w := strings.ToLower(fields[0]); w {
+		case "select", "insert", "update", "delete", "with":
+			op = w
+		}
+	}
+	if m := tablePattern.FindStringSubmatch(query); m != nil {
+		table = strings.ToLower(m[1])
+	}
+	describeMemo.Store(query, [2]string{op, table})
+	return op, table
+}
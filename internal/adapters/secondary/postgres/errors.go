@@ -0,0 +1,43 @@
+package postgres
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/frostnzx/go-ecommerce-api/internal/ports"
+	"github.com/lib/pq"
+)
+
+// translateError wraps driver errors in the matching port sentinel; the original
+// error stays in the chain for logs. Other errors are returned unchanged.
+func translateError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("%w: %w", ports.ErrNotFound, err)
+	}
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		switch pqErr.Code.Name() {
+		case "unique_violation":
+			return fmt.Errorf("%w: %w", ports.ErrConflict, err)
+		case "foreign_key_violation":
+			return fmt.Errorf("%w: %w", ports.ErrReference, err)
+		}
+	}
+	return err
+}
+
+// requireRow returns notFound, which must wrap ports.ErrNotFound, when a statement matched no row
+func requireRow(res sql.Result, notFound error) error {
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error reading affected rows: %w", err)
+	}
+	if n == 0 {
+		return notFound
+	}
+	return nil
+}
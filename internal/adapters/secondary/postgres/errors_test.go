@@ -0,0 +1,55 @@
+package postgres
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/frostnzx/go-ecommerce-api/internal/ports"
+	"github.com/lib/pq"
+)
+
+func TestTranslateErrorMapsDriverErrors(t *testing.T) {
+	tests := map[string]struct {
+		err  error
+		want error
+	}{
+		"no rows":          {fmt.Errorf("scan: %w", sql.ErrNoRows), ports.ErrNotFound},
+		"unique violation": {&pq.Error{Code: "23505"}, ports.ErrConflict},
+		"foreign key":      {&pq.Error{Code: "23503"}, ports.ErrReference},
+	}
+	for name, tt := range tests {
+		got := translateError(tt.err)
+		if !errors.Is(got, tt.want) {
+			t.Errorf("%s: translateError = %v, want %v", name, got, tt.want)
+		}
+		// The driver error stays available for logs
+		if !errors.Is(got, tt.err) {
+			t.Errorf("%s: translateError dropped the original error", name)
+		}
+	}
+}
+
+func TestTranslateErrorKeepsOtherErrors(t *testing.T) {
+	for _, err := range []error{nil, errors.New("connection refused"), &pq.Error{Code: "57014"}} {
+		if got := translateError(err); got != err {
+			t.Errorf("translateError(%v) = %v, want it unchanged", err, got)
+		}
+	}
+}
+
+type affectedRows int64
+
+func (n affectedRows) LastInsertId() (int64, error) { return 0, errors.New("not supported") }
+func (n affectedRows) RowsAffected() (int64, error) { return int64(n), nil }
+
+func TestRequireRowReportsNotFound(t *testing.T) {
+	if err := requireRow(affectedRows(1), ports.ErrUserNotFound); err != nil {
+		t.Fatalf("matched row: err = %v", err)
+	}
+	err := requireRow(affectedRows(0), ports.ErrUserNotFound)
+	if !errors.Is(err, ports.ErrUserNotFound) || !errors.Is(err, ports.ErrNotFound) {
+		t.Fatalf("no row: err = %v, want ErrUserNotFound and ErrNotFound", err)
+	}
+}
@@ -0,0 +1,83 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/idempotency"
+	"github.com/frostnzx/go-ecommerce-api/internal/ports"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type IdempotencyRepo struct {
+	db *sqlx.DB
+}
+
+func NewIdempotencyRepo(db *sqlx.DB) (*IdempotencyRepo, error) {
+	if db == nil {
+		return nil, errors.New("database connection required")
+	}
+	return &IdempotencyRepo{db: db}, nil
+}
+
+func (ir *IdempotencyRepo) CreateKey(ctx context.Context, r idempotency.Record, staleBefore time.Time) error {
+	// The conditional upsert takes over expired or abandoned records atomically; when
+	// the existing record is still live no row is returned
+	var key string
+	err := ir.db.GetContext(ctx, &key, `INSERT INTO idempotency_keys (user_id, key, fingerprint, status_code, content_type, body, created_at, expires_at)
+		VALUES ($1, $2, $3, 0, '', NULL, $4, $5)
+		ON CONFLICT (user_id, key) DO UPDATE SET fingerprint=EXCLUDED.fingerprint, status_code=0, content_type='', body=NULL,
+			created_at=EXCLUDED.created_at, expires_at=EXCLUDED.expires_at
+		WHERE idempotency_keys.expires_at <= EXCLUDED.created_at
+			OR (idempotency_keys.status_code = 0 AND idempotency_keys.created_at < $6)
+		RETURNING key`,
+		r.UserID, r.Key, r.Fingerprint, r.CreatedAt, r.ExpiresAt, staleBefore)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ports.ErrIdempotencyKeyExists
+	}
+	if err != nil {
+		return fmt.Errorf("error creating idempotency key: %w", err)
+	}
+	return nil
+}
+
+func (ir *IdempotencyRepo) GetKey(ctx context.Context, userID uuid.UUID, key string) (*idempotency.Record, error) {
+	var r idempotency.Record
+	err := ir.db.GetContext(ctx, &r, "SELECT * FROM idempotency_keys WHERE user_id=$1 AND key=$2", userID, key)
+	if err != nil {
+		return nil, fmt.Errorf("error getting idempotency key: %w", translateError(err))
+	}
+	return &r, nil
+}
+
+func (ir *IdempotencyRepo) CompleteKey(ctx context.Context, r idempotency.Record) error {
+	_, err := ir.db.NamedExecContext(ctx, "UPDATE idempotency_keys SET status_code=:status_code, content_type=:content_type, body=:body WHERE user_id=:user_id AND key=:key AND status_code=0", r)
+	if err != nil {
+		return fmt.Errorf("error completing idempotency key: %w", err)
+	}
+	return nil
+}
+
+func (ir *IdempotencyRepo) DeleteKey(ctx context.Context, userID uuid.UUID, key string) error {
+	_, err := ir.db.ExecContext(ctx, "DELETE FROM idempotency_keys WHERE user_id=$1 AND key=$2 AND status_code=0", userID, key)
+	if err != nil {
+		return fmt.Errorf("error deleting idempotency key: %w", err)
+	}
+	return nil
+}
+
+func (ir *IdempotencyRepo) DeleteExpiredKeys(ctx context.Context, before time.Time) (int64, error) {
+	res, err := ir.db.ExecContext(ctx, "DELETE FROM idempotency_keys WHERE expires_at <= $1", before)
+	if err != nil {
+		return 0, fmt.Errorf("error deleting expired idempotency keys: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("error deleting expired idempotency keys: %w", err)
+	}
+	return n, nil
+}
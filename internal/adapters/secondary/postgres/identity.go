@@ -0,0 +1,76 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/identity"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type IdentityRepo struct {
+	db *sqlx.DB
+}
+
+func NewIdentityRepo(db *sqlx.DB) (*IdentityRepo, error) {
+	if db == nil {
+		return nil, errors.New("database connection required")
+	}
+	return &IdentityRepo{db: db}, nil
+}
+
+func (ir *IdentityRepo) Create(ctx context.Context, i identity.Identity) error {
+	_, err := ir.db.NamedExecContext(ctx, "INSERT INTO user_identities (id, user_id, provider, subject, email, created_at) VALUES (:id, :user_id, :provider, :subject, :email, :created_at)", i)
+	if err != nil {
+		return fmt.Errorf("error creating identity: %w", translateError(err))
+	}
+	return nil
+}
+
+func (ir *IdentityRepo) GetByProviderSubject(ctx context.Context, provider, subject string) (*identity.Identity, error) {
+	var i identity.Identity
+	err := ir.db.GetContext(ctx, &i, "SELECT * FROM user_identities WHERE provider=$1 AND subject=$2", provider, subject)
+	if err != nil {
+		return nil, fmt.Errorf("error getting identity: %w", translateError(err))
+	}
+	return &i, nil
+}
+
+func (ir *IdentityRepo) ListByUserID(ctx context.Context, userID uuid.UUID) ([]identity.Identity, error) {
+	var identities []identity.Identity
+	err := ir.db.SelectContext(ctx, &identities, "SELECT * FROM user_identities WHERE user_id=$1 ORDER BY created_at", userID)
+	if err != nil {
+		return nil, fmt.Errorf("error listing identities: %w", err)
+	}
+	return identities, nil
+}
+
+type OIDCStateRepo struct {
+	db *sqlx.DB
+}
+
+func NewOIDCStateRepo(db *sqlx.DB) (*OIDCStateRepo, error) {
+	if db == nil {
+		return nil, errors.New("database connection required")
+	}
+	return &OIDCStateRepo{db: db}, nil
+}
+
+func (sr *OIDCStateRepo) Create(ctx context.Context, s identity.LoginState) error {
+	_, err := sr.db.NamedExecContext(ctx, "INSERT INTO oidc_login_states (state, provider, code_verifier, nonce, created_at, expires_at) VALUES (:state, :provider, :code_verifier, :nonce, :created_at, :expires_at)", s)
+	if err != nil {
+		return fmt.Errorf("error creating login state: %w", translateError(err))
+	}
+	return nil
+}
+
+func (sr *OIDCStateRepo) Take(ctx context.Context, state string) (*identity.LoginState, error) {
+	var s identity.LoginState
+	err := sr.db.GetContext(ctx, &s, "DELETE FROM oidc_login_states WHERE state=$1 RETURNING *", state)
+	if err != nil {
+		return nil, fmt.Errorf("error taking login state: %w", translateError(err))
+	}
+	return &s, nil
+}
@@ -31,7 +31,7 @@ func (ir *ItemsRepo) Create(ctx context.Context, item items.Items) (items.Items,
 		item.ID, item.OrderID, item.ProductID, item.Quantity, item.UnitPriceSnapshot,
 	).StructScan(&created)
 	if err != nil {
-		return items.Items{}, err
+		return items.Items{}, translateError(err)
 	}
 	return created, nil
 }
@@ -74,7 +74,7 @@ func (ir *ItemsRepo) GetByID(ctx context.Context, id uuid.UUID) (items.Items, er
 	var item items.Items
 	err := ir.db.GetContext(ctx, &item, query, id)
 	if err != nil {
-		return items.Items{}, err
+		return items.Items{}, translateError(err)
 	}
 	return item, nil
 }
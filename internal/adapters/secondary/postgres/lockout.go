@@ -0,0 +1,76 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/lockout"
+	"github.com/jmoiron/sqlx"
+)
+
+type LoginAttemptRepo struct {
+	db *sqlx.DB
+}
+
+func NewLoginAttemptRepo(db *sqlx.DB) (*LoginAttemptRepo, error) {
+	if db == nil {
+		return nil, errors.New("database connection required")
+	}
+	return &LoginAttemptRepo{db: db}, nil
+}
+
+func (lr *LoginAttemptRepo) Get(ctx context.Context, scope lockout.Scope, subject string) (*lockout.Attempts, error) {
+	var a lockout.Attempts
+	err := lr.db.GetContext(ctx, &a, "SELECT * FROM login_attempts WHERE scope=$1 AND subject=$2", scope, subject)
+	if errors.Is(err, sql.ErrNoRows) {
+		empty := lockout.New(scope, subject)
+		return &empty, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error getting login attempts: %w", err)
+	}
+	return &a, nil
+}
+
+func (lr *LoginAttemptRepo) RecordFailure(ctx context.Context, scope lockout.Scope, subject string, p lockout.Policy, maxAttempts int, now time.Time) (_ *lockout.Attempts, err error) {
+	tx, err := lr.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error starting login failure: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	// Make sure the row exists, then hold its lock while counting so parallel failures queue up
+	_, err = tx.ExecContext(ctx, "INSERT INTO login_attempts (scope, subject, failures, last_failed_at) VALUES ($1, $2, 0, $3) ON CONFLICT (scope, subject) DO NOTHING", scope, subject, now)
+	if err != nil {
+		return nil, fmt.Errorf("error recording login failure: %w", err)
+	}
+	var a lockout.Attempts
+	if err = tx.GetContext(ctx, &a, "SELECT * FROM login_attempts WHERE scope=$1 AND subject=$2 FOR UPDATE", scope, subject); err != nil {
+		return nil, fmt.Errorf("error recording login failure: %w", err)
+	}
+	a.RecordFailure(p, maxAttempts, now)
+	_, err = tx.NamedExecContext(ctx, "UPDATE login_attempts SET failures=:failures, last_failed_at=:last_failed_at, locked_until=:locked_until WHERE scope=:scope AND subject=:subject", a)
+	if err != nil {
+		return nil, fmt.Errorf("error recording login failure: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("error committing login failure: %w", err)
+	}
+	return &a, nil
+}
+
+func (lr *LoginAttemptRepo) Reset(ctx context.Context, scope lockout.Scope, subject string) error {
+	_, err := lr.db.ExecContext(ctx, "DELETE FROM login_attempts WHERE scope=$1 AND subject=$2", scope, subject)
+	if err != nil {
+		return fmt.Errorf("error resetting login attempts: %w", err)
+	}
+	return nil
+}
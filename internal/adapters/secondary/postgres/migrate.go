@@ -0,0 +1,374 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/frostnzx/go-ecommerce-api/db"
+	"github.com/jmoiron/sqlx"
+)
+
+// migrationLockID is the advisory lock held while migrating, so instances that
+// start together apply each migration once
+const migrationLockID int64 = 0x65636f6d6d6967 // "ecommig"
+
+var ErrDirtySchema = errors.New("schema is dirty")
+
+// Migration is one versioned schema change from db/migrations
+type Migration struct {
+	Version uint64
+	Name    string
+	Up      string
+	Down    string
+}
+
+func (m Migration) String() string {
+	return strconv.FormatUint(m.Version, 10) + "_" + m.Name
+}
+
+// MigrationStatus describes the schema relative to the embedded migrations
+type MigrationStatus struct {
+	Version uint64 // Last applied migration; 0 when none has been applied
+	Dirty   bool   // A migration failed halfway and the schema needs fixing by hand
+	Latest  uint64 // Newest embedded migration
+	Pending []Migration
+}
+
+// Migrator applies the embedded migrations. Versions are tracked in the same
+// schema_migrations table golang-migrate uses, so databases migrated with the
+// external tool carry on where they left off.
+type Migrator struct {
+	db         *sqlx.DB
+	migrations []Migration // Ascending by version
+}
+
+func NewMigrator(conn *sqlx.DB) (*Migrator, error) {
+	if conn == nil {
+		return nil, errors.New("database connection required")
+	}
+	migrations, err := loadMigrations(db.Migrations, "migrations")
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: conn, migrations: migrations}, nil
+}
+
+// loadMigrations reads <version>_<name>.up.sql and .down.sql pairs from dir
+func loadMigrations(fsys fs.FS, dir string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, fmt.Errorf("error reading migrations: %w", err)
+	}
+	byVersion := map[uint64]*Migration{}
+	for _, e := range entries {
+		base, direction, ok := strings.Cut(strings.TrimSuffix(e.Name(), ".sql"), ".")
+		prefix, name, hasName := strings.Cut(base, "_")
+		version, err := strconv.ParseUint(prefix, 10, 64)
+		if !ok || !hasName || err != nil || version == 0 || (direction != "up" && direction != "down") {
+			return nil, fmt.Errorf("migration file %q is not named <version>_<name>.up.sql or .down.sql", e.Name())
+		}
+		content, err := fs.ReadFile(fsys, path.Join(dir, e.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("error reading migration %q: %w", e.Name(), err)
+		}
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: name}
+			byVersion[version] = m
+		} else if m.Name != name {
+			return nil, fmt.Errorf("migration version %d is used by both %q and %q", version, m.Name, name)
+		}
+		if direction == "up" {
+			m.Up = string(content)
+		} else {
+			m.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" {
+			return nil, fmt.Errorf("migration %s has no up file", m)
+		}
+		migrations = append(migrations, *m)
+	}
+	slices.SortFunc(migrations, func(a, b Migration) int { return cmpVersion(a.Version, b.Version) })
+	return migrations, nil
+}
+
+func cmpVersion(a, b uint64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// Migrations returns the embedded migrations, oldest first
+func (m *Migrator) Migrations() []Migration {
+	return slices.Clone(m.migrations)
+}
+
+// Latest returns the newest embedded migration version
+func (m *Migrator) Latest() uint64 {
+	if len(m.migrations) == 0 {
+		return 0
+	}
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+func (m *Migrator) Status(ctx context.Context) (*MigrationStatus, error) {
+	version, dirty, err := readSchemaVersion(ctx, m.db)
+	if err != nil {
+		return nil, err
+	}
+	st := &MigrationStatus{Version: version, Dirty: dirty, Latest: m.Latest()}
+	for _, mig := range m.migrations {
+		if mig.Version > version {
+			st.Pending = append(st.Pending, mig)
+		}
+	}
+	return st, nil
+}
+
+// Check reports an error when the schema is behind the embedded migrations or
+// dirty. A schema ahead of the binary passes, so an older release keeps running
+// while a newer one rolls out.
+func (m *Migrator) Check(ctx context.Context) error {
+	st, err := m.Status(ctx)
+	if err != nil {
+		return err
+	}
+	if st.Dirty {
+		return fmt.Errorf("%w at version %d", ErrDirtySchema, st.Version)
+	}
+	if st.Version < st.Latest {
+		return fmt.Errorf("schema is at version %d, expected %d (pending: %d)", st.Version, st.Latest, len(st.Pending))
+	}
+	return nil
+}
+
+// Up applies every pending migration and returns the ones it applied. A schema
+// ahead of the binary is left as it is, so an older release that migrates on
+// start keeps running while a newer one rolls out.
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	var done []Migration
+	err := m.withLock(ctx, func(conn *sqlx.Conn) error {
+		var err error
+		done, err = m.migrate(ctx, conn, m.Latest(), true)
+		return err
+	})
+	return done, err
+}
+
+// Down rolls back the last steps migrations
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	if steps < 1 {
+		return nil, errors.New("steps must be at least 1")
+	}
+	var done []Migration
+	err := m.withLock(ctx, func(conn *sqlx.Conn) error {
+		version, _, err := readSchemaVersion(ctx, conn)
+		if err != nil {
+			return err
+		}
+		i, err := m.index(version)
+		if err != nil {
+			return err
+		}
+		var target uint64
+		if i-steps >= 0 {
+			target = m.migrations[i-steps].Version
+		}
+		done, err = m.migrate(ctx, conn, target, false)
+		return err
+	})
+	return done, err
+}
+
+// To migrates up or down to version; 0 rolls back every migration
+func (m *Migrator) To(ctx context.Context, version uint64) ([]Migration, error) {
+	if _, err := m.index(version); err != nil {
+		return nil, err
+	}
+	var done []Migration
+	err := m.withLock(ctx, func(conn *sqlx.Conn) error {
+		var err error
+		done, err = m.migrate(ctx, conn, version, false)
+		return err
+	})
+	return done, err
+}
+
+// Force records version as applied and clears the dirty flag without running
+// anything, after a failed migration has been repaired by hand
+func (m *Migrator) Force(ctx context.Context, version uint64) error {
+	if _, err := m.index(version); err != nil {
+		return err
+	}
+	return m.withLock(ctx, func(conn *sqlx.Conn) error {
+		tx, err := conn.BeginTxx(ctx, nil)
+		if err != nil {
+			return fmt.Errorf("error starting transaction: %w", err)
+		}
+		defer tx.Rollback()
+		if err := writeSchemaVersion(ctx, tx, version); err != nil {
+			return err
+		}
+		return tx.Commit()
+	})
+}
+
+// index returns the position of version among the migrations, or -1 for version 0
+func (m *Migrator) index(version uint64) (int, error) {
+	if version == 0 {
+		return -1, nil
+	}
+	i, ok := slices.BinarySearchFunc(m.migrations, version, func(mig Migration, v uint64) int { return cmpVersion(mig.Version, v) })
+	if !ok {
+		return 0, fmt.Errorf("unknown migration version %d", version)
+	}
+	return i, nil
+}
+
+// migrate moves the schema to target; with upOnly a schema at or past target is left alone
+func (m *Migrator) migrate(ctx context.Context, conn *sqlx.Conn, target uint64, upOnly bool) ([]Migration, error) {
+	version, dirty, err := readSchemaVersion(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+	if dirty {
+		return nil, fmt.Errorf("%w at version %d: repair it, then run migrate force", ErrDirtySchema, version)
+	}
+	up, down, err := m.plan(version, target, upOnly)
+	if err != nil {
+		return nil, err
+	}
+
+	var done []Migration
+	for _, mig := range up {
+		if err := applyMigration(ctx, conn, mig.Up, mig.Version); err != nil {
+			return done, fmt.Errorf("error applying migration %s: %w", mig, err)
+		}
+		done = append(done, mig)
+	}
+	for _, mig := range down {
+		if mig.Down == "" {
+			return done, fmt.Errorf("migration %s has no down file", mig)
+		}
+		var previous uint64
+		if i, _ := m.index(mig.Version); i > 0 {
+			previous = m.migrations[i-1].Version
+		}
+		if err := applyMigration(ctx, conn, mig.Down, previous); err != nil {
+			return done, fmt.Errorf("error rolling back migration %s: %w", mig, err)
+		}
+		done = append(done, mig)
+	}
+	return done, nil
+}
+
+// plan returns the migrations to apply, oldest first, and to roll back, newest
+// first, to get from version to target. With upOnly a version at or past target
+// needs nothing, even one newer than any embedded migration.
+func (m *Migrator) plan(version, target uint64, upOnly bool) (up, down []Migration, err error) {
+	if upOnly && version >= target {
+		return nil, nil, nil
+	}
+	current, err := m.index(version)
+	if err != nil {
+		return nil, nil, err
+	}
+	goal, err := m.index(target)
+	if err != nil {
+		return nil, nil, err
+	}
+	for i := current + 1; i <= goal; i++ {
+		up = append(up, m.migrations[i])
+	}
+	for i := current; i > goal; i-- {
+		down = append(down, m.migrations[i])
+	}
+	return up, down, nil
+}
+
+// applyMigration runs one migration file and records the resulting version in a
+// single transaction, so a failure leaves the schema as it was
+func applyMigration(ctx context.Context, conn *sqlx.Conn, script string, version uint64) error {
+	tx, err := conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+	if _, err := tx.ExecContext(ctx, script); err != nil {
+		return err
+	}
+	if err := writeSchemaVersion(ctx, tx, version); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// withLock runs fn on a single connection holding the migration advisory lock
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sqlx.Conn) error) error {
+	conn, err := m.db.Connx(ctx)
+	if err != nil {
+		return fmt.Errorf("error getting connection: %w", err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", migrationLockID); err != nil {
+		return fmt.Errorf("error acquiring migration lock: %w", err)
+	}
+	defer conn.ExecContext(context.WithoutCancel(ctx), "SELECT pg_advisory_unlock($1)", migrationLockID)
+
+	if _, err := conn.ExecContext(ctx, "CREATE TABLE IF NOT EXISTS schema_migrations (version BIGINT NOT NULL PRIMARY KEY, dirty BOOLEAN NOT NULL)"); err != nil {
+		return fmt.Errorf("error creating schema_migrations: %w", err)
+	}
+	return fn(conn)
+}
+
+// readSchemaVersion returns the applied version, treating a missing table as an empty database
+func readSchemaVersion(ctx context.Context, q sqlx.QueryerContext) (uint64, bool, error) {
+	var exists bool
+	if err := sqlx.GetContext(ctx, q, &exists, "SELECT to_regclass('schema_migrations') IS NOT NULL"); err != nil {
+		return 0, false, fmt.Errorf("error reading schema version: %w", err)
+	}
+	if !exists {
+		return 0, false, nil
+	}
+	var row struct {
+		Version uint64 `db:"version"`
+		Dirty   bool   `db:"dirty"`
+	}
+	err := sqlx.GetContext(ctx, q, &row, "SELECT version, dirty FROM schema_migrations LIMIT 1")
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("error reading schema version: %w", err)
+	}
+	return row.Version, row.Dirty, nil
+}
+
+func writeSchemaVersion(ctx context.Context, tx *sqlx.Tx, version uint64) error {
+	if _, err := tx.ExecContext(ctx, "DELETE FROM schema_migrations"); err != nil {
+		return fmt.Errorf("error recording schema version: %w", err)
+	}
+	if version == 0 {
+		return nil
+	}
+	if _, err := tx.ExecContext(ctx, "INSERT INTO schema_migrations (version, dirty) VALUES ($1, FALSE)", int64(version)); err != nil {
+		return fmt.Errorf("error recording schema version: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package postgres
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/frostnzx/go-ecommerce-api/db"
+)
+
+func TestLoadMigrationsPairsAndSortsFiles(t *testing.T) {
+	fsys := fstest.MapFS{
+		"m/20_add_orders.up.sql":   {Data: []byte("CREATE TABLE orders ();")},
+		"m/20_add_orders.down.sql": {Data: []byte("DROP TABLE orders;")},
+		"m/3_init.up.sql":          {Data: []byte("CREATE TABLE users ();")},
+	}
+	migrations, err := loadMigrations(fsys, "m")
+	if err != nil {
+		t.Fatalf("loadMigrations: %v", err)
+	}
+	if len(migrations) != 2 || migrations[0].Version != 3 || migrations[1].Version != 20 {
+		t.Fatalf("migrations = %+v, want versions 3 then 20", migrations)
+	}
+	if migrations[1].Name != "add_orders" || migrations[1].Down != "DROP TABLE orders;" || migrations[0].Down != "" {
+		t.Errorf("migrations = %+v", migrations)
+	}
+}
+
+func TestLoadMigrationsRejectsBadFiles(t *testing.T) {
+	tests := map[string]fstest.MapFS{
+		"bad name":       {"m/init.up.sql": {}},
+		"bad direction":  {"m/1_init.sideways.sql": {}},
+		"missing up":     {"m/1_init.down.sql": {Data: []byte("DROP TABLE x;")}},
+		"version reused": {"m/1_a.up.sql": {Data: []byte("SELECT 1;")}, "m/1_b.up.sql": {Data: []byte("SELECT 1;")}},
+	}
+	for name, fsys := range tests {
+		if _, err := loadMigrations(fsys, "m"); err == nil {
+			t.Errorf("%s: loadMigrations succeeded, want an error", name)
+		}
+	}
+}
+
+func TestEmbeddedMigrationsAreReversible(t *testing.T) {
+	migrations, err := loadMigrations(db.Migrations, "migrations")
+	if err != nil {
+		t.Fatalf("loadMigrations: %v", err)
+	}
+	for _, m := range migrations {
+		if strings.TrimSpace(m.Down) == "" {
+			t.Errorf("migration %s has no down file", m)
+		}
+		// Every statement must be terminated, or multi-statement files fail to parse
+		for _, script := range []string{m.Up, m.Down} {
+			if s := strings.TrimSpace(script); s != "" && !strings.HasSuffix(s, ";") {
+				t.Errorf("migration %s does not end its last statement with a semicolon", m)
+			}
+		}
+	}
+}
+
+func TestMigratorIndex(t *testing.T) {
+	m := &Migrator{migrations: []Migration{{Version: 10}, {Version: 20}}}
+	if i, err := m.index(0); err != nil || i != -1 {
+		t.Errorf("index(0) = %d, %v; want -1", i, err)
+	}
+	if i, err := m.index(20); err != nil || i != 1 {
+		t.Errorf("index(20) = %d, %v; want 1", i, err)
+	}
+	if _, err := m.index(15); err == nil {
+		t.Error("index(15) succeeded, want unknown version")
+	}
+}
+
+func TestMigratorPlan(t *testing.T) {
+	m := &Migrator{migrations: []Migration{{Version: 10}, {Version: 20}, {Version: 30}}}
+	versions := func(migs []Migration) []uint64 {
+		var v []uint64
+		for _, mig := range migs {
+			v = append(v, mig.Version)
+		}
+		return v
+	}
+
+	tests := []struct {
+		name             string
+		version, target  uint64
+		upOnly           bool
+		wantUp, wantDown []uint64
+		wantErr          bool
+	}{
+		{name: "empty database", version: 0, target: 30, upOnly: true, wantUp: []uint64{10, 20, 30}},
+		{name: "pending", version: 10, target: 30, upOnly: true, wantUp: []uint64{20, 30}},
+		{name: "up to date", version: 30, target: 30, upOnly: true},
+		// An older binary starting against a schema a newer release already migrated
+		{name: "ahead of the binary", version: 40, target: 30, upOnly: true},
+		{name: "roll back", version: 30, target: 10, wantDown: []uint64{30, 20}},
+		{name: "roll back everything", version: 20, target: 0, wantDown: []uint64{20, 10}},
+		{name: "unknown version", version: 40, target: 30, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			up, down, err := m.plan(tt.version, tt.target, tt.upOnly)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, want error %v", err, tt.wantErr)
+			}
+			if !slices.Equal(versions(up), tt.wantUp) || !slices.Equal(versions(down), tt.wantDown) {
+				t.Fatalf("up %v, down %v; want up %v, down %v", versions(up), versions(down), tt.wantUp, tt.wantDown)
+			}
+		})
+	}
+}
@@ -31,7 +31,7 @@ func (or *OrderRepo) Create(ctx context.Context, o order.Order) (order.Order, er
 		o.ID, o.UserId, o.AddressID, o.Status, o.TotalAmount, o.CreatedAt,
 	).StructScan(&created)
 	if err != nil {
-		return order.Order{}, err
+		return order.Order{}, translateError(err)
 	}
 	return created, nil
 }
@@ -45,7 +45,7 @@ func (or *OrderRepo) GetByID(ctx context.Context, orderID uuid.UUID) (order.Orde
 	var o order.Order
 	err := or.db.GetContext(ctx, &o, query, orderID)
 	if err != nil {
-		return order.Order{}, err
+		return order.Order{}, translateError(err)
 	}
 	return o, nil
 }
@@ -0,0 +1,68 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/lockout"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/user"
+	"github.com/jmoiron/sqlx"
+)
+
+type PrivacyRepo struct {
+	db *sqlx.DB
+}
+
+func NewPrivacyRepo(db *sqlx.DB) (*PrivacyRepo, error) {
+	if db == nil {
+		return nil, errors.New("database connection required")
+	}
+	return &PrivacyRepo{db: db}, nil
+}
+
+func (pr *PrivacyRepo) EraseUser(ctx context.Context, u user.User) (err error) {
+	tx, err := pr.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error starting erasure: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	// The login attempt counter is keyed by the email, read it before it is replaced
+	var email string
+	if err = tx.GetContext(ctx, &email, "SELECT email FROM users WHERE id=$1 FOR UPDATE", u.ID); err != nil {
+		return fmt.Errorf("error getting user: %w", err)
+	}
+
+	statements := []struct {
+		query string
+		args  []any
+	}{
+		{"UPDATE users SET email=$2, password_hash=$3, name=$4, is_admin=$5, erased_at=$6 WHERE id=$1",
+			[]any{u.ID, u.Email, u.PasswordHash, u.Name, u.IsAdmin, u.ErasedAt}},
+		// Orders keep pointing at their address; city, province and country stay for accounting
+		{"UPDATE addresses SET line1='', postal_code='', is_default=FALSE WHERE user_id=$1 AND id IN (SELECT address_id FROM orders WHERE user_id=$1)",
+			[]any{u.ID}},
+		{"DELETE FROM addresses WHERE user_id=$1 AND id NOT IN (SELECT address_id FROM orders WHERE user_id=$1)",
+			[]any{u.ID}},
+		{"DELETE FROM sessions WHERE user_id=$1", []any{u.ID}},
+		{"DELETE FROM api_keys WHERE user_id=$1", []any{u.ID}},
+		{"DELETE FROM user_identities WHERE user_id=$1", []any{u.ID}},
+		{"DELETE FROM idempotency_keys WHERE user_id=$1", []any{u.ID}},
+		{"DELETE FROM login_attempts WHERE scope=$1 AND subject=LOWER(TRIM($2))", []any{lockout.ScopeAccount, email}},
+	}
+	for _, st := range statements {
+		if _, err = tx.ExecContext(ctx, st.query, st.args...); err != nil {
+			return fmt.Errorf("error erasing user: %w", err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("error committing erasure: %w", err)
+	}
+	return nil
+}
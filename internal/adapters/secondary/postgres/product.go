@@ -3,8 +3,10 @@ package postgres
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/product"
+	"github.com/frostnzx/go-ecommerce-api/internal/ports"
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
 )
@@ -31,7 +33,7 @@ func (pr *ProductRepo) Create(ctx context.Context, p product.Product) (product.P
 		p.ID, p.SKU, p.Name, p.Description, p.Price, p.StockQty, p.Active, p.CreatedAt,
 	).StructScan(&created)
 	if err != nil {
-		return product.Product{}, err
+		return product.Product{}, translateError(err)
 	}
 	return created, nil
 }
@@ -45,7 +47,7 @@ func (pr *ProductRepo) GetByID(ctx context.Context, id uuid.UUID) (product.Produ
 	var p product.Product
 	err := pr.db.GetContext(ctx, &p, query, id)
 	if err != nil {
-		return product.Product{}, err
+		return product.Product{}, translateError(err)
 	}
 	return p, nil
 }
@@ -53,7 +55,7 @@ func (pr *ProductRepo) GetByID(ctx context.Context, id uuid.UUID) (product.Produ
 func (pr *ProductRepo) DeleteById(ctx context.Context, id uuid.UUID) error {
 	query := `DELETE FROM products WHERE id = $1`
 	_, err := pr.db.ExecContext(ctx, query, id)
-	return err
+	return translateError(err)
 }
 
 func (pr *ProductRepo) UpdateById(ctx context.Context, p product.Product) (product.Product, error) {
@@ -68,7 +70,7 @@ func (pr *ProductRepo) UpdateById(ctx context.Context, p product.Product) (produ
 		p.SKU, p.Name, p.Description, p.Price, p.StockQty, p.Active, p.ID,
 	).StructScan(&updated)
 	if err != nil {
-		return product.Product{}, err
+		return product.Product{}, translateError(err)
 	}
 	return updated, nil
 }
@@ -83,13 +85,82 @@ func (pr *ProductRepo) List(ctx context.Context) ([]product.Product, error) {
 	var products []product.Product
 	err := pr.db.SelectContext(ctx, &products, query)
 	if err != nil {
-		return nil, err
+		return nil, translateError(err)
 	}
 	return products, nil
 }
 
 func (pr *ProductRepo) UpdateStock(ctx context.Context, id uuid.UUID, quantity int) error {
 	query := `UPDATE products SET stock_qty = $1 WHERE id = $2`
-	_, err := pr.db.ExecContext(ctx, query, quantity, id)
-	return err
+	res, err := pr.db.ExecContext(ctx, query, quantity, id)
+	if err != nil {
+		return translateError(err)
+	}
+	return requireRow(res, fmt.Errorf("product %s: %w", id, ports.ErrNotFound))
+}
+
+func (pr *ProductRepo) GetBySKU(ctx context.Context, sku string) (product.Product, error) {
+	query := `
+		SELECT id, sku, name, description, price, stock_qty, active, created_at
+		FROM products
+		WHERE sku = $1
+	`
+	var p product.Product
+	err := pr.db.GetContext(ctx, &p, query, sku)
+	if err != nil {
+		return product.Product{}, translateError(err)
+	}
+	return p, nil
+}
+
+func (pr *ProductRepo) AdjustStock(ctx context.Context, id uuid.UUID, delta int) (int, error) {
+	query := `
+		UPDATE products SET stock_qty = stock_qty + $2
+		WHERE id = $1 AND stock_qty + $2 >= 0
+		RETURNING stock_qty
+	`
+	var quantity int
+	err := pr.db.GetContext(ctx, &quantity, query, id, delta)
+	if err != nil {
+		return 0, translateError(err)
+	}
+	return quantity, nil
+}
+
+func (pr *ProductRepo) UpsertBySKU(ctx context.Context, products []product.Product) (created int, err error) {
+	tx, err := pr.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("error starting import: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	// xmax is only zero on a freshly inserted row, which tells creates and updates apart
+	query := `
+		INSERT INTO products (id, sku, name, description, price, stock_qty, active, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (sku) DO UPDATE
+		SET name = EXCLUDED.name, description = EXCLUDED.description,
+			price = EXCLUDED.price, stock_qty = EXCLUDED.stock_qty
+		RETURNING xmax = 0
+	`
+	for _, p := range products {
+		var inserted bool
+		err = tx.GetContext(ctx, &inserted, query,
+			p.ID, p.SKU, p.Name, p.Description, p.Price, p.StockQty, p.Active, p.CreatedAt,
+		)
+		if err != nil {
+			return 0, fmt.Errorf("error importing product %s: %w", p.SKU, translateError(err))
+		}
+		if inserted {
+			created++
+		}
+	}
+	if err = tx.Commit(); err != nil {
+		return 0, fmt.Errorf("error committing import: %w", err)
+	}
+	return created, nil
 }
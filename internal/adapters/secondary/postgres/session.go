@@ -4,8 +4,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/session"
+	"github.com/frostnzx/go-ecommerce-api/internal/ports"
+	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
 )
 
@@ -21,9 +24,9 @@ func NewSessionRepo(db *sqlx.DB) (*SessionRepo, error) {
 }
 
 func (sr *SessionRepo) CreateSession(ctx context.Context, s *session.Session) (*session.Session, error) {
-	_, err := sr.db.NamedExecContext(ctx, "INSERT INTO sessions (id, user_email, refresh_token, is_revoked, expires_at) VALUES (:id, :user_email, :refresh_token, :is_revoked, :expires_at)", s)
+	_, err := sr.db.NamedExecContext(ctx, "INSERT INTO sessions (id, user_id, user_email, user_agent, ip_address, is_revoked, created_at, last_used_at, expires_at, impersonator_id) VALUES (:id, :user_id, :user_email, :user_agent, :ip_address, :is_revoked, :created_at, :last_used_at, :expires_at, :impersonator_id)", s)
 	if err != nil {
-		return nil, fmt.Errorf("error inserting session: %w", err)
+		return nil, fmt.Errorf("error inserting session: %w", translateError(err))
 	}
 	return s, nil
 }
@@ -32,7 +35,7 @@ func (sr *SessionRepo) GetSession(ctx context.Context, id string) (*session.Sess
 	var s session.Session
 	err := sr.db.GetContext(ctx, &s, "SELECT * FROM sessions WHERE id=$1", id)
 	if err != nil {
-		return nil, fmt.Errorf("error getting session: %w", err)
+		return nil, fmt.Errorf("error getting session: %w", translateError(err))
 	}
 	return &s, nil
 }
@@ -52,3 +55,101 @@ func (sr *SessionRepo) DeleteSession(ctx context.Context, id string) error {
 	}
 	return nil
 }
+
+func (sr *SessionRepo) DeleteSessionsByEmail(ctx context.Context, email string) error {
+	_, err := sr.db.ExecContext(ctx, "DELETE FROM sessions WHERE user_email=$1", email)
+	if err != nil {
+		return fmt.Errorf("error deleting sessions: %w", err)
+	}
+	return nil
+}
+
+func (sr *SessionRepo) TouchSession(ctx context.Context, id string) error {
+	_, err := sr.db.ExecContext(ctx, "UPDATE sessions SET last_used_at=NOW() WHERE id=$1", id)
+	if err != nil {
+		return fmt.Errorf("error updating session: %w", err)
+	}
+	return nil
+}
+
+func (sr *SessionRepo) DeleteExpiredSessions(ctx context.Context, before time.Time) (int64, error) {
+	res, err := sr.db.ExecContext(ctx, "DELETE FROM sessions WHERE expires_at < $1", before)
+	if err != nil {
+		return 0, fmt.Errorf("error deleting expired sessions: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("error deleting expired sessions: %w", err)
+	}
+	return n, nil
+}
+
+func (sr *SessionRepo) ListSessionsByUserID(ctx context.Context, userID uuid.UUID) ([]session.Session, error) {
+	var sessions []session.Session
+	err := sr.db.SelectContext(ctx, &sessions, "SELECT * FROM sessions WHERE user_id=$1 AND is_revoked=FALSE AND expires_at > NOW() ORDER BY last_used_at DESC", userID)
+	if err != nil {
+		return nil, fmt.Errorf("error listing sessions: %w", err)
+	}
+	return sessions, nil
+}
+
+func (sr *SessionRepo) RevokeSessionsByUserID(ctx context.Context, userID uuid.UUID, exceptID string) ([]string, error) {
+	var ids []string
+	err := sr.db.SelectContext(ctx, &ids, "UPDATE sessions SET is_revoked=TRUE WHERE user_id=$1 AND is_revoked=FALSE AND id::text <> $2 RETURNING id", userID, exceptID)
+	if err != nil {
+		return nil, fmt.Errorf("error revoking sessions: %w", err)
+	}
+	return ids, nil
+}
+
+func (sr *SessionRepo) CreateRefreshToken(ctx context.Context, t *session.RefreshToken) error {
+	_, err := sr.db.NamedExecContext(ctx, "INSERT INTO refresh_tokens (id, session_id, token_hash, created_at, expires_at) VALUES (:id, :session_id, :token_hash, :created_at, :expires_at)", t)
+	if err != nil {
+		return fmt.Errorf("error inserting refresh token: %w", translateError(err))
+	}
+	return nil
+}
+
+func (sr *SessionRepo) GetRefreshToken(ctx context.Context, tokenHash string) (*session.RefreshToken, error) {
+	var t session.RefreshToken
+	err := sr.db.GetContext(ctx, &t, "SELECT * FROM refresh_tokens WHERE token_hash=$1", tokenHash)
+	if err != nil {
+		return nil, fmt.Errorf("error getting refresh token: %w", translateError(err))
+	}
+	return &t, nil
+}
+
+func (sr *SessionRepo) RotateRefreshToken(ctx context.Context, consumedID string, next *session.RefreshToken) (err error) {
+	tx, err := sr.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error starting refresh token rotation: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	res, err := tx.ExecContext(ctx, "UPDATE refresh_tokens SET consumed_at=NOW() WHERE id=$1 AND consumed_at IS NULL", consumedID)
+	if err != nil {
+		return fmt.Errorf("error consuming refresh token: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error consuming refresh token: %w", err)
+	}
+	if n == 0 {
+		return ports.ErrRefreshTokenConsumed
+	}
+	if _, err = tx.NamedExecContext(ctx, "INSERT INTO refresh_tokens (id, session_id, token_hash, created_at, expires_at) VALUES (:id, :session_id, :token_hash, :created_at, :expires_at)", next); err != nil {
+		return fmt.Errorf("error inserting refresh token: %w", translateError(err))
+	}
+	if _, err = tx.ExecContext(ctx, "UPDATE sessions SET last_used_at=NOW() WHERE id=$1", next.SessionID); err != nil {
+		return fmt.Errorf("error updating session: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("error committing refresh token rotation: %w", err)
+	}
+	return nil
+}
@@ -2,10 +2,14 @@ package postgres
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/user"
+	"github.com/frostnzx/go-ecommerce-api/internal/ports"
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
 )
@@ -24,7 +28,7 @@ func NewUserRepo(db *sqlx.DB) (*UserRepo, error) {
 func (ur *UserRepo) Create(ctx context.Context, u user.User) error {
 	_, err := ur.db.NamedExecContext(ctx, "INSERT INTO users (id , email, password_hash, name , is_admin , created_at) VALUES (:id , :email, :password_hash, :name, :is_admin , :created_at)", u)
 	if err != nil {
-		return fmt.Errorf("error create user %w", err)
+		return fmt.Errorf("error creating user: %w", translateError(err))
 	}
 	return nil
 }
@@ -32,7 +36,7 @@ func (ur *UserRepo) GetUser(ctx context.Context, email string) (*user.User, erro
 	var u user.User
 	err := ur.db.GetContext(ctx, &u, "SELECT * FROM users WHERE email=$1", email)
 	if err != nil {
-		return nil, fmt.Errorf("error getting user: %w", err)
+		return nil, fmt.Errorf("error getting user: %w", translateError(err))
 	}
 	return &u, nil
 }
@@ -40,7 +44,7 @@ func (ur *UserRepo) GetUserByID(ctx context.Context, id uuid.UUID) (*user.User,
 	var u user.User
 	err := ur.db.GetContext(ctx, &u, "SELECT * FROM users WHERE id=$1", id)
 	if err != nil {
-		return nil, fmt.Errorf("error getting user: %w", err)
+		return nil, fmt.Errorf("error getting user: %w", translateError(err))
 	}
 	return &u, nil
 }
@@ -52,17 +56,52 @@ func (ur *UserRepo) ListUsers(ctx context.Context) ([]*user.User, error) {
 	}
 	return u, nil
 }
+func (ur *UserRepo) SearchUsers(ctx context.Context, f ports.UserFilter) ([]*user.User, int, error) {
+	// Escape LIKE wildcards so the query is matched literally
+	pattern := "%" + strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(f.Query) + "%"
+	where := "WHERE email ILIKE $1 OR name ILIKE $1"
+
+	var total int
+	if err := ur.db.GetContext(ctx, &total, "SELECT COUNT(*) FROM users "+where, pattern); err != nil {
+		return nil, 0, fmt.Errorf("error counting users: %w", err)
+	}
+	var u []*user.User
+	err := ur.db.SelectContext(ctx, &u, "SELECT * FROM users "+where+" ORDER BY created_at DESC, id LIMIT $2 OFFSET $3", pattern, f.Limit, f.Offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error searching users: %w", err)
+	}
+	return u, total, nil
+}
+func (ur *UserRepo) SetSuspended(ctx context.Context, id uuid.UUID, suspendedAt *time.Time) error {
+	res, err := ur.db.ExecContext(ctx, "UPDATE users SET suspended_at=$2 WHERE id=$1", id, suspendedAt)
+	if err != nil {
+		return fmt.Errorf("error updating user: %w", translateError(err))
+	}
+	return requireUserRow(res)
+}
+func (ur *UserRepo) UpdatePasswordHash(ctx context.Context, id uuid.UUID, passwordHash string) error {
+	res, err := ur.db.ExecContext(ctx, "UPDATE users SET password_hash=$2 WHERE id=$1", id, passwordHash)
+	if err != nil {
+		return fmt.Errorf("error updating user: %w", translateError(err))
+	}
+	return requireUserRow(res)
+}
 func (ur *UserRepo) UpdateUser(ctx context.Context, u user.User) error {
-	_, err := ur.db.NamedExecContext(ctx, "UPDATE users SET name=:name , email=:email , password_hash=:password_hash , is_admin=:is_admin , created_at=:created_at", u)
+	res, err := ur.db.NamedExecContext(ctx, "UPDATE users SET name=:name , email=:email , password_hash=:password_hash , is_admin=:is_admin WHERE id=:id", u)
 	if err != nil {
-		return fmt.Errorf("error updating user: %w", err)
+		return fmt.Errorf("error updating user: %w", translateError(err))
 	}
-	return nil
+	return requireUserRow(res)
 }
 func (ur *UserRepo) DeleteUser(ctx context.Context, id uuid.UUID) error {
-	_, err := ur.db.ExecContext(ctx, "DELETE users WHERE id = $1", id)
+	_, err := ur.db.ExecContext(ctx, "DELETE FROM users WHERE id = $1", id)
 	if err != nil {
 		return fmt.Errorf("error deleting user: %w", err)
 	}
 	return nil
 }
+
+// requireUserRow reports ErrUserNotFound when an update matched no user
+func requireUserRow(res sql.Result) error {
+	return requireRow(res, ports.ErrUserNotFound)
+}
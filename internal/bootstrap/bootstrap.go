@@ -0,0 +1,170 @@
+// Package bootstrap loads configuration and wires the repositories and core
+// services shared by the binaries under cmd/.
+package bootstrap
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+
+	"github.com/frostnzx/go-ecommerce-api/internal/adapters/secondary/postgres"
+	"github.com/frostnzx/go-ecommerce-api/internal/config"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/lockout"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/services/address"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/services/apikey"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/services/idempotency"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/services/items"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/services/order"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/services/privacy"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/services/product"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/services/session"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/services/user"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/utils"
+	"github.com/frostnzx/go-ecommerce-api/internal/logging"
+	"github.com/frostnzx/go-ecommerce-api/internal/ports"
+	"github.com/jmoiron/sqlx"
+	"github.com/joho/godotenv"
+)
+
+// Load reads an optional .env file, then loads and validates the configuration
+// and installs a default logger writing to w
+func Load(configPath string, w io.Writer) (*config.Config, *slog.Logger, error) {
+	envErr := godotenv.Load()
+
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error loading configuration: %w", err)
+	}
+
+	// Structured logging; the standard log package is routed through it as well
+	logger, err := logging.New(w, cfg.Log.Level, cfg.Log.Format)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error configuring logging: %w", err)
+	}
+	slog.SetDefault(logger)
+	if envErr != nil {
+		logger.Info("no .env file found, using environment variables")
+	}
+	return cfg, logger, nil
+}
+
+// Options are the collaborators that differ between binaries
+type Options struct {
+	// TokenMaker signs access tokens; binaries that never log anyone in may leave it nil
+	TokenMaker *utils.JWTMaker
+	// Metrics records business events; nil discards them
+	Metrics ports.BusinessMetrics
+	// IdentityProviders enables social login when not empty
+	IdentityProviders []ports.IdentityProvider
+}
+
+// Services holds the core services, ready to be handed to a primary adapter
+type Services struct {
+	Session     *session.Service
+	User        *user.Service
+	Address     *address.Service
+	Order       *order.Service
+	Product     *product.Service
+	Items       *items.Service
+	APIKey      *apikey.Service
+	Privacy     *privacy.Service
+	Idempotency *idempotency.Service
+}
+
+// NewServices creates the Postgres repositories (secondary adapters) and the core services on top of them
+func NewServices(db *sqlx.DB, cfg *config.Config, opts Options) (*Services, error) {
+	userRepo, err := postgres.NewUserRepo(db)
+	if err != nil {
+		return nil, fmt.Errorf("error creating user repository: %w", err)
+	}
+	sessionRepo, err := postgres.NewSessionRepo(db)
+	if err != nil {
+		return nil, fmt.Errorf("error creating session repository: %w", err)
+	}
+	addressRepo, err := postgres.NewAddressRepo(db)
+	if err != nil {
+		return nil, fmt.Errorf("error creating address repository: %w", err)
+	}
+	orderRepo, err := postgres.NewOrderRepo(db)
+	if err != nil {
+		return nil, fmt.Errorf("error creating order repository: %w", err)
+	}
+	itemsRepo, err := postgres.NewItemsRepo(db)
+	if err != nil {
+		return nil, fmt.Errorf("error creating items repository: %w", err)
+	}
+	productRepo, err := postgres.NewProductRepo(db)
+	if err != nil {
+		return nil, fmt.Errorf("error creating product repository: %w", err)
+	}
+	loginAttemptRepo, err := postgres.NewLoginAttemptRepo(db)
+	if err != nil {
+		return nil, fmt.Errorf("error creating login attempt repository: %w", err)
+	}
+	apiKeyRepo, err := postgres.NewAPIKeyRepo(db)
+	if err != nil {
+		return nil, fmt.Errorf("error creating api key repository: %w", err)
+	}
+	identityRepo, err := postgres.NewIdentityRepo(db)
+	if err != nil {
+		return nil, fmt.Errorf("error creating identity repository: %w", err)
+	}
+	oidcStateRepo, err := postgres.NewOIDCStateRepo(db)
+	if err != nil {
+		return nil, fmt.Errorf("error creating OIDC state repository: %w", err)
+	}
+	auditRepo, err := postgres.NewAuditRepo(db)
+	if err != nil {
+		return nil, fmt.Errorf("error creating audit repository: %w", err)
+	}
+	privacyRepo, err := postgres.NewPrivacyRepo(db)
+	if err != nil {
+		return nil, fmt.Errorf("error creating privacy repository: %w", err)
+	}
+	idempotencyRepo, err := postgres.NewIdempotencyRepo(db)
+	if err != nil {
+		return nil, fmt.Errorf("error creating idempotency repository: %w", err)
+	}
+
+	// Login throttling policy
+	lockoutPolicy := lockout.DefaultPolicy()
+	lockoutPolicy.MaxAttempts = cfg.Login.MaxAttempts
+	lockoutPolicy.MaxAttemptsPerIP = cfg.Login.MaxAttemptsPerIP
+	lockoutPolicy.LockoutDuration = cfg.Login.LockoutDuration.Duration
+
+	tokenLifetimes := user.TokenLifetimes{
+		Access:        cfg.Tokens.AccessTTL.Duration,
+		Refresh:       cfg.Tokens.RefreshTTL.Duration,
+		Impersonation: cfg.Tokens.ImpersonationTTL.Duration,
+	}
+
+	businessMetrics := opts.Metrics
+	if businessMetrics == nil {
+		businessMetrics = discardMetrics{}
+	}
+
+	sessionService := session.NewService(sessionRepo)
+	userService := user.NewService(userRepo, sessionService, opts.TokenMaker, loginAttemptRepo, lockoutPolicy, auditRepo, tokenLifetimes, businessMetrics)
+	if len(opts.IdentityProviders) > 0 {
+		userService.EnableOIDC(identityRepo, oidcStateRepo, opts.IdentityProviders...)
+	}
+
+	return &Services{
+		Session:     sessionService,
+		User:        userService,
+		Address:     address.NewService(addressRepo),
+		Order:       order.NewService(orderRepo, itemsRepo, productRepo, addressRepo, businessMetrics),
+		Product:     product.NewService(productRepo),
+		Items:       items.NewService(itemsRepo, productRepo, orderRepo),
+		APIKey:      apikey.NewService(apiKeyRepo, userRepo, auditRepo),
+		Privacy:     privacy.NewService(userRepo, addressRepo, orderRepo, itemsRepo, identityRepo, privacyRepo, sessionService),
+		Idempotency: idempotency.NewService(idempotencyRepo, cfg.Idempotency.KeyTTL.Duration),
+	}, nil
+}
+
+// discardMetrics is used by binaries that export no metrics
+type discardMetrics struct{}
+
+func (discardMetrics) OrderPlaced(float64) {}
+func (discardMetrics) OrderCancelled()     {}
+func (discardMetrics) LoginFailed(string)  {}
@@ -0,0 +1,604 @@
+// Package config loads the server configuration. Values start from built-in
+// defaults, are overridden by an optional JSON config file and then by
+// environment variables, and are validated once before anything is started.
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/netip"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/lockout"
+	"github.com/frostnzx/go-ecommerce-api/internal/logging"
+)
+
+type Config struct {
+	Server      ServerConfig      `json:"server"`
+	Database    DatabaseConfig    `json:"database"`
+	JWT         JWTConfig         `json:"jwt"`
+	Tokens      TokenConfig       `json:"tokens"`
+	Login       LoginConfig       `json:"login"`
+	OIDC        []OIDCProvider    `json:"oidc_providers"`
+	Idempotency IdempotencyConfig `json:"idempotency"`
+	Workers     WorkerConfig      `json:"workers"`
+	Log         LogConfig         `json:"log"`
+	Tracing     TracingConfig     `json:"tracing"`
+}
+
+type ServerConfig struct {
+	Port              int      `json:"port"`
+	ReadTimeout       Duration `json:"read_timeout"`
+	ReadHeaderTimeout Duration `json:"read_header_timeout"`
+	WriteTimeout      Duration `json:"write_timeout"`
+	IdleTimeout       Duration `json:"idle_timeout"`
+	MaxHeaderBytes    int      `json:"max_header_bytes"`
+	ShutdownTimeout   Duration `json:"shutdown_timeout"`  // How long in-flight requests get to finish on shutdown
+	DrainDelay        Duration `json:"drain_delay"`       // How long /readyz reports failure before the listener closes
+	ReadinessTimeout  Duration `json:"readiness_timeout"` // Per-check limit for /readyz
+
+	MetricsToken string `json:"metrics_token"` // When set, GET /metrics requires "Authorization: Bearer <token>"
+
+	RateLimit RateLimitConfig `json:"rate_limit"`
+
+	// Addresses or CIDR ranges of the reverse proxies whose Forwarded and
+	// X-Forwarded-For headers are believed; other peers are taken at their word
+	TrustedProxies []string `json:"trusted_proxies"`
+
+	// TLS is served when both files are set
+	TLSCertFile string `json:"tls_cert_file"`
+	TLSKeyFile  string `json:"tls_key_file"`
+}
+
+// DatabaseConfig holds either a full URL or the individual connection settings
+type DatabaseConfig struct {
+	URL      string `json:"url"` // Takes precedence over the fields below when set
+	Host     string `json:"host"`
+	Port     int    `json:"port"`
+	User     string `json:"user"`
+	Password string `json:"password"`
+	Name     string `json:"name"`
+	SSLMode  string `json:"sslmode"`
+
+	AutoMigrate bool `json:"auto_migrate"` // Apply pending migrations on startup, under an advisory lock
+}
+
+// RateLimitConfig holds the default policy and per-route overrides keyed by
+// ServeMux pattern, e.g. "POST /auth/login"
+type RateLimitConfig struct {
+	Enabled       bool                       `json:"enabled"`
+	Default       RateLimitPolicy            `json:"default"`
+	Routes        map[string]RateLimitPolicy `json:"routes"`
+	SweepInterval Duration                   `json:"sweep_interval"` // How often idle buckets are dropped from memory
+}
+
+// RateLimitPolicy allows Requests per Period in bursts of up to Burst. A policy
+// with zero requests leaves its routes unlimited.
+type RateLimitPolicy struct {
+	Requests int      `json:"requests"`
+	Period   Duration `json:"period"`
+	Burst    int      `json:"burst"` // Defaults to Requests
+	Key      string   `json:"key"`   // ip, or user to count per user ID or API key with the IP as fallback
+}
+
+type JWTConfig struct {
+	KeysDir     string `json:"keys_dir"`
+	ActiveKeyID string `json:"active_key_id"` // May be empty when the directory holds a single private key
+}
+
+type TokenConfig struct {
+	AccessTTL        Duration `json:"access_ttl"`
+	RefreshTTL       Duration `json:"refresh_ttl"`
+	ImpersonationTTL Duration `json:"impersonation_ttl"`
+}
+
+type LoginConfig struct {
+	MaxAttempts      int      `json:"max_attempts"`
+	MaxAttemptsPerIP int      `json:"max_attempts_per_ip"`
+	LockoutDuration  Duration `json:"lockout_duration"`
+}
+
+type OIDCProvider struct {
+	Name         string `json:"name"`
+	Issuer       string `json:"issuer"`
+	ClientID     string `json:"client_id"`
+	ClientSecret string `json:"client_secret"`
+	RedirectURL  string `json:"redirect_url"`
+}
+
+type IdempotencyConfig struct {
+	KeyTTL Duration `json:"key_ttl"` // How long a stored response is replayed for retries
+}
+
+type WorkerConfig struct {
+	SessionPurgeInterval     Duration `json:"session_purge_interval"`
+	IdempotencyPurgeInterval Duration `json:"idempotency_purge_interval"`
+}
+
+type LogConfig struct {
+	Level  string `json:"level"`  // debug, info, warn or error
+	Format string `json:"format"` // json or text
+}
+
+// TracingConfig controls OpenTelemetry span export over OTLP/HTTP
+type TracingConfig struct {
+	Enabled     bool    `json:"enabled"`
+	Endpoint    string  `json:"endpoint"` // Collector host:port, e.g. localhost:4318
+	Insecure    bool    `json:"insecure"` // Plain HTTP, for a local collector
+	SampleRatio float64 `json:"sample_ratio"`
+	ServiceName string  `json:"service_name"`
+}
+
+// Duration is a time.Duration written as a string such as "15m" in config files
+type Duration struct {
+	time.Duration
+}
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+func (d *Duration) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return errors.New(`duration must be a string such as "15m"`)
+	}
+	v, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	d.Duration = v
+	return nil
+}
+
+// Default returns the configuration used when nothing is overridden
+func Default() Config {
+	policy := lockout.DefaultPolicy()
+	return Config{
+		Server: ServerConfig{
+			Port:              8080,
+			ReadTimeout:       Duration{15 * time.Second},
+			ReadHeaderTimeout: Duration{5 * time.Second},
+			WriteTimeout:      Duration{30 * time.Second},
+			IdleTimeout:       Duration{2 * time.Minute},
+			MaxHeaderBytes:    1 << 20,
+			ShutdownTimeout:   Duration{20 * time.Second},
+			ReadinessTimeout:  Duration{2 * time.Second},
+			RateLimit: RateLimitConfig{
+				Enabled: true,
+				Default: RateLimitPolicy{Requests: 120, Period: Duration{time.Minute}, Key: "user"},
+				Routes: map[string]RateLimitPolicy{
+					"POST /auth/login":    {Requests: 10, Period: Duration{time.Minute}, Key: "ip"},
+					"POST /auth/register": {Requests: 5, Period: Duration{time.Minute}, Key: "ip"},
+					"POST /auth/renew":    {Requests: 30, Period: Duration{time.Minute}, Key: "ip"},
+					"GET /products":       {Requests: 60, Period: Duration{time.Minute}, Key: "ip"},
+					"GET /products/{id}":  {Requests: 120, Period: Duration{time.Minute}, Key: "ip"},
+					// Probes and scrapes come from infrastructure that shares addresses
+					"GET /healthz": {},
+					"GET /readyz":  {},
+					"GET /metrics": {},
+				},
+				SweepInterval: Duration{time.Minute},
+			},
+		},
+		Database: DatabaseConfig{
+			Host:     "localhost",
+			Port:     5432,
+			User:     "postgres",
+			Password: "postgres",
+			Name:     "ecommerce",
+			SSLMode:  "disable",
+		},
+		JWT: JWTConfig{KeysDir: "keys"},
+		Tokens: TokenConfig{
+			AccessTTL:        Duration{15 * time.Minute},
+			RefreshTTL:       Duration{7 * 24 * time.Hour},
+			ImpersonationTTL: Duration{15 * time.Minute},
+		},
+		Login: LoginConfig{
+			MaxAttempts:      policy.MaxAttempts,
+			MaxAttemptsPerIP: policy.MaxAttemptsPerIP,
+			LockoutDuration:  Duration{policy.LockoutDuration},
+		},
+		Idempotency: IdempotencyConfig{KeyTTL: Duration{24 * time.Hour}},
+		Workers: WorkerConfig{
+			SessionPurgeInterval:     Duration{time.Hour},
+			IdempotencyPurgeInterval: Duration{time.Hour},
+		},
+		Log: LogConfig{Level: "info", Format: "json"},
+		Tracing: TracingConfig{
+			Endpoint:    "localhost:4318",
+			Insecure:    true,
+			SampleRatio: 1,
+			ServiceName: "go-ecommerce-api",
+		},
+	}
+}
+
+// Load builds the configuration from defaults, the JSON file at path (or
+// $CONFIG_FILE when path is empty) and the environment, then validates it
+func Load(path string) (*Config, error) {
+	return load(path, os.LookupEnv)
+}
+
+func load(path string, lookupEnv func(string) (string, bool)) (*Config, error) {
+	cfg := Default()
+	if path == "" {
+		path, _ = lookupEnv("CONFIG_FILE")
+	}
+	if path != "" {
+		if err := cfg.readFile(path); err != nil {
+			return nil, err
+		}
+	}
+	if err := cfg.applyEnv(lookupEnv); err != nil {
+		return nil, err
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+func (c *Config) readFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("error opening config file: %w", err)
+	}
+	defer f.Close()
+	dec := json.NewDecoder(f)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(c); err != nil {
+		return fmt.Errorf("error reading config file %s: %w", path, err)
+	}
+	return nil
+}
+
+// applyEnv overrides the loaded values with any environment variables that are set
+func (c *Config) applyEnv(lookupEnv func(string) (string, bool)) error {
+	e := envReader{lookup: lookupEnv}
+	e.int("SERVER_PORT", &c.Server.Port)
+	e.duration("SERVER_READ_TIMEOUT", &c.Server.ReadTimeout)
+	e.duration("SERVER_READ_HEADER_TIMEOUT", &c.Server.ReadHeaderTimeout)
+	e.duration("SERVER_WRITE_TIMEOUT", &c.Server.WriteTimeout)
+	e.duration("SERVER_IDLE_TIMEOUT", &c.Server.IdleTimeout)
+	e.int("SERVER_MAX_HEADER_BYTES", &c.Server.MaxHeaderBytes)
+	e.duration("SERVER_SHUTDOWN_TIMEOUT", &c.Server.ShutdownTimeout)
+	e.duration("SERVER_DRAIN_DELAY", &c.Server.DrainDelay)
+	e.duration("SERVER_READINESS_TIMEOUT", &c.Server.ReadinessTimeout)
+	e.string("METRICS_TOKEN", &c.Server.MetricsToken)
+	e.bool("RATE_LIMIT_ENABLED", &c.Server.RateLimit.Enabled)
+	e.int("RATE_LIMIT_REQUESTS", &c.Server.RateLimit.Default.Requests)
+	e.duration("RATE_LIMIT_PERIOD", &c.Server.RateLimit.Default.Period)
+	e.int("RATE_LIMIT_BURST", &c.Server.RateLimit.Default.Burst)
+	e.list("TRUSTED_PROXIES", &c.Server.TrustedProxies)
+	e.string("TLS_CERT_FILE", &c.Server.TLSCertFile)
+	e.string("TLS_KEY_FILE", &c.Server.TLSKeyFile)
+
+	e.string("DATABASE_URL", &c.Database.URL)
+	e.string("DB_HOST", &c.Database.Host)
+	e.int("DB_PORT", &c.Database.Port)
+	e.string("DB_USER", &c.Database.User)
+	e.string("DB_PASSWORD", &c.Database.Password)
+	e.string("DB_NAME", &c.Database.Name)
+	e.string("DB_SSLMODE", &c.Database.SSLMode)
+	e.bool("DB_AUTO_MIGRATE", &c.Database.AutoMigrate)
+
+	e.string("JWT_KEYS_DIR", &c.JWT.KeysDir)
+	e.string("JWT_ACTIVE_KEY_ID", &c.JWT.ActiveKeyID)
+
+	e.duration("ACCESS_TOKEN_TTL", &c.Tokens.AccessTTL)
+	e.duration("REFRESH_TOKEN_TTL", &c.Tokens.RefreshTTL)
+	e.duration("IMPERSONATION_TOKEN_TTL", &c.Tokens.ImpersonationTTL)
+
+	e.int("LOGIN_MAX_ATTEMPTS", &c.Login.MaxAttempts)
+	e.int("LOGIN_MAX_ATTEMPTS_PER_IP", &c.Login.MaxAttemptsPerIP)
+	e.duration("LOGIN_LOCKOUT_DURATION", &c.Login.LockoutDuration)
+
+	e.duration("IDEMPOTENCY_KEY_TTL", &c.Idempotency.KeyTTL)
+
+	e.duration("SESSION_PURGE_INTERVAL", &c.Workers.SessionPurgeInterval)
+	e.duration("IDEMPOTENCY_PURGE_INTERVAL", &c.Workers.IdempotencyPurgeInterval)
+
+	e.string("LOG_LEVEL", &c.Log.Level)
+	e.string("LOG_FORMAT", &c.Log.Format)
+
+	e.bool("TRACING_ENABLED", &c.Tracing.Enabled)
+	e.string("TRACING_ENDPOINT", &c.Tracing.Endpoint)
+	e.bool("TRACING_INSECURE", &c.Tracing.Insecure)
+	e.float("TRACING_SAMPLE_RATIO", &c.Tracing.SampleRatio)
+	e.string("TRACING_SERVICE_NAME", &c.Tracing.ServiceName)
+
+	// OIDC_PROVIDERS=google,github replaces any providers from the config file, each
+	// configured through OIDC_<NAME>_ISSUER, _CLIENT_ID, _CLIENT_SECRET and _REDIRECT_URL
+	if names, ok := lookupEnv("OIDC_PROVIDERS"); ok {
+		c.OIDC = nil
+		for _, name := range strings.Split(names, ",") {
+			name = strings.ToLower(strings.TrimSpace(name))
+			if name == "" {
+				continue
+			}
+			prefix := "OIDC_" + strings.ToUpper(name) + "_"
+			p := OIDCProvider{Name: name}
+			e.string(prefix+"ISSUER", &p.Issuer)
+			e.string(prefix+"CLIENT_ID", &p.ClientID)
+			e.string(prefix+"CLIENT_SECRET", &p.ClientSecret)
+			e.string(prefix+"REDIRECT_URL", &p.RedirectURL)
+			c.OIDC = append(c.OIDC, p)
+		}
+	}
+	return errors.Join(e.errs...)
+}
+
+// Validate reports every invalid setting at once
+func (c *Config) Validate() error {
+	var errs []error
+	check := func(ok bool, format string, args ...any) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+
+	check(validPort(c.Server.Port), "server port %d is out of range", c.Server.Port)
+	check(c.Server.ReadTimeout.Duration > 0, "server read timeout must be positive")
+	check(c.Server.ReadHeaderTimeout.Duration > 0, "server read header timeout must be positive")
+	check(c.Server.WriteTimeout.Duration > 0, "server write timeout must be positive")
+	check(c.Server.IdleTimeout.Duration > 0, "server idle timeout must be positive")
+	check(c.Server.MaxHeaderBytes >= 4096, "server max header bytes must be at least 4096")
+	check(c.Server.ShutdownTimeout.Duration > 0, "server shutdown timeout must be positive")
+	check(c.Server.DrainDelay.Duration >= 0, "server drain delay must not be negative")
+	check(c.Server.ReadinessTimeout.Duration > 0, "server readiness timeout must be positive")
+	check((c.Server.TLSCertFile == "") == (c.Server.TLSKeyFile == ""), "TLS needs both a certificate and a key file")
+	if _, err := c.Server.TrustedProxyPrefixes(); err != nil {
+		errs = append(errs, err)
+	}
+	if c.Server.RateLimit.Enabled {
+		check(c.Server.RateLimit.SweepInterval.Duration > 0, "rate limit sweep interval must be positive")
+		if err := c.Server.RateLimit.Default.validate(); err != nil {
+			errs = append(errs, fmt.Errorf("default rate limit: %w", err))
+		}
+		for route, p := range c.Server.RateLimit.Routes {
+			method, path, ok := strings.Cut(route, " ")
+			check(ok && method != "" && strings.HasPrefix(path, "/"), "rate limit route %q is not a pattern such as \"GET /products\"", route)
+			if err := p.validate(); err != nil {
+				errs = append(errs, fmt.Errorf("rate limit for %q: %w", route, err))
+			}
+		}
+	}
+
+	if c.Database.URL != "" {
+		if err := validateDatabaseURL(c.Database.URL); err != nil {
+			errs = append(errs, err)
+		}
+	} else {
+		check(c.Database.Host != "", "database host is required")
+		check(validPort(c.Database.Port), "database port %d is out of range", c.Database.Port)
+		check(c.Database.User != "", "database user is required")
+		check(c.Database.Name != "", "database name is required")
+		check(validSSLMode(c.Database.SSLMode), "database sslmode %q is not supported", c.Database.SSLMode)
+	}
+
+	check(c.JWT.KeysDir != "", "JWT keys directory is required")
+
+	check(c.Tokens.AccessTTL.Duration > 0, "access token TTL must be positive")
+	check(c.Tokens.RefreshTTL.Duration > c.Tokens.AccessTTL.Duration, "refresh token TTL must be longer than the access token TTL")
+	check(c.Tokens.ImpersonationTTL.Duration > 0, "impersonation token TTL must be positive")
+
+	check(c.Login.MaxAttempts > 0, "login max attempts must be positive")
+	check(c.Login.MaxAttemptsPerIP > 0, "login max attempts per IP must be positive")
+	check(c.Login.LockoutDuration.Duration > 0, "login lockout duration must be positive")
+
+	check(c.Idempotency.KeyTTL.Duration > 0, "idempotency key TTL must be positive")
+
+	check(c.Workers.SessionPurgeInterval.Duration > 0, "session purge interval must be positive")
+	check(c.Workers.IdempotencyPurgeInterval.Duration > 0, "idempotency purge interval must be positive")
+
+	_, err := logging.ParseLevel(c.Log.Level)
+	check(err == nil, "log level %q is not one of debug, info, warn, error", c.Log.Level)
+	check(c.Log.Format == "json" || c.Log.Format == "text", "log format %q is not one of json, text", c.Log.Format)
+
+	if c.Tracing.Enabled {
+		check(c.Tracing.Endpoint != "", "tracing endpoint is required when tracing is enabled")
+		check(c.Tracing.ServiceName != "", "tracing service name is required when tracing is enabled")
+	}
+	check(c.Tracing.SampleRatio >= 0 && c.Tracing.SampleRatio <= 1, "tracing sample ratio %g must be between 0 and 1", c.Tracing.SampleRatio)
+
+	seen := map[string]bool{}
+	for _, p := range c.OIDC {
+		check(p.Name != "", "OIDC provider name is required")
+		check(!seen[p.Name], "OIDC provider %q is configured twice", p.Name)
+		seen[p.Name] = true
+		check(p.Issuer != "", "OIDC provider %q: issuer is required", p.Name)
+		check(p.ClientID != "", "OIDC provider %q: client ID is required", p.Name)
+		check(p.ClientSecret != "", "OIDC provider %q: client secret is required", p.Name)
+		check(p.RedirectURL != "", "OIDC provider %q: redirect URL is required", p.Name)
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid configuration: %w", errors.Join(errs...))
+	}
+	return nil
+}
+
+func (p RateLimitPolicy) validate() error {
+	if p.Requests == 0 {
+		return nil
+	}
+	var errs []error
+	if p.Requests < 0 {
+		errs = append(errs, errors.New("requests must not be negative"))
+	}
+	if p.Period.Duration <= 0 {
+		errs = append(errs, errors.New("period must be positive"))
+	}
+	if p.Burst < 0 {
+		errs = append(errs, errors.New("burst must not be negative"))
+	}
+	if p.Key != "ip" && p.Key != "user" {
+		errs = append(errs, fmt.Errorf("key %q is not one of ip, user", p.Key))
+	}
+	return errors.Join(errs...)
+}
+
+// Addr is the address the HTTP server listens on
+func (c ServerConfig) Addr() string {
+	return ":" + strconv.Itoa(c.Port)
+}
+
+// TLS reports whether the server should serve HTTPS
+func (c ServerConfig) TLS() bool {
+	return c.TLSCertFile != "" && c.TLSKeyFile != ""
+}
+
+// TrustedProxyPrefixes parses TrustedProxies, turning single addresses into one-address ranges
+func (c ServerConfig) TrustedProxyPrefixes() ([]netip.Prefix, error) {
+	prefixes := make([]netip.Prefix, 0, len(c.TrustedProxies))
+	for _, s := range c.TrustedProxies {
+		if addr, err := netip.ParseAddr(s); err == nil {
+			addr = addr.Unmap()
+			prefixes = append(prefixes, netip.PrefixFrom(addr, addr.BitLen()))
+			continue
+		}
+		prefix, err := netip.ParsePrefix(s)
+		if err != nil {
+			return nil, fmt.Errorf("trusted proxy %q is not an IP address or CIDR range", s)
+		}
+		prefixes = append(prefixes, prefix.Masked())
+	}
+	return prefixes, nil
+}
+
+// DSN returns the connection string for lib/pq
+func (c DatabaseConfig) DSN() string {
+	if c.URL != "" {
+		return c.URL
+	}
+	u := url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(c.User, c.Password),
+		Host:     c.Host + ":" + strconv.Itoa(c.Port),
+		Path:     "/" + c.Name,
+		RawQuery: url.Values{"sslmode": {c.SSLMode}}.Encode(),
+	}
+	return u.String()
+}
+
+func validPort(p int) bool {
+	return p > 0 && p <= 65535
+}
+
+func validSSLMode(mode string) bool {
+	switch mode {
+	case "disable", "allow", "prefer", "require", "verify-ca", "verify-full":
+		return true
+	}
+	return false
+}
+
+func validateDatabaseURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return errors.New("database URL is not a valid URL")
+	}
+	if u.Scheme != "postgres" && u.Scheme != "postgresql" {
+		return fmt.Errorf("database URL scheme %q is not supported, use postgres://", u.Scheme)
+	}
+	if u.Hostname() == "" {
+		return errors.New("database URL has no host")
+	}
+	if p := u.Port(); p != "" {
+		if n, err := strconv.Atoi(p); err != nil || !validPort(n) {
+			return fmt.Errorf("database URL port %q is out of range", p)
+		}
+	}
+	if strings.Trim(u.Path, "/") == "" {
+		return errors.New("database URL has no database name")
+	}
+	if mode := u.Query().Get("sslmode"); mode != "" && !validSSLMode(mode) {
+		return fmt.Errorf("database URL sslmode %q is not supported", mode)
+	}
+	return nil
+}
+
+// envReader applies environment overrides and collects parse errors
+type envReader struct {
+	lookup func(string) (string, bool)
+	errs   []error
+}
+
+func (e *envReader) string(key string, dst *string) {
+	if v, ok := e.lookup(key); ok {
+		*dst = v
+	}
+}
+
+// list reads a comma separated value, dropping empty entries
+func (e *envReader) list(key string, dst *[]string) {
+	v, ok := e.lookup(key)
+	if !ok {
+		return
+	}
+	*dst = nil
+	for _, item := range strings.Split(v, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			*dst = append(*dst, item)
+		}
+	}
+}
+
+func (e *envReader) int(key string, dst *int) {
+	v, ok := e.lookup(key)
+	if !ok {
+		return
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		e.errs = append(e.errs, fmt.Errorf("%s must be an integer, got %q", key, v))
+		return
+	}
+	*dst = n
+}
+
+func (e *envReader) bool(key string, dst *bool) {
+	v, ok := e.lookup(key)
+	if !ok {
+		return
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		e.errs = append(e.errs, fmt.Errorf("%s must be true or false, got %q", key, v))
+		return
+	}
+	*dst = b
+}
+
+func (e *envReader) float(key string, dst *float64) {
+	v, ok := e.lookup(key)
+	if !ok {
+		return
+	}
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		e.errs = append(e.errs, fmt.Errorf("%s must be a number, got %q", key, v))
+		return
+	}
+	*dst = f
+}
+
+func (e *envReader) duration(key string, dst *Duration) {
+	v, ok := e.lookup(key)
+	if !ok {
+		return
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		e.errs = append(e.errs, fmt.Errorf("%s must be a duration such as 15m, got %q", key, v))
+		return
+	}
+	dst.Duration = d
+}
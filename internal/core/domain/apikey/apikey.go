@@ -0,0 +1,122 @@
+package apikey
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Scope string
+
+const (
+	ScopeRead  Scope = "read"  // Safe methods (GET, HEAD, OPTIONS)
+	ScopeWrite Scope = "write" // Any method on the owner's resources
+	ScopeAdmin Scope = "admin" // Admin routes, only granted to keys of admin users
+)
+
+// Scopes is stored as a comma separated list
+type Scopes []Scope
+
+type APIKey struct {
+	ID         uuid.UUID  `db:"id"`
+	UserID     uuid.UUID  `db:"user_id"`
+	Name       string     `db:"name"`
+	Prefix     string     `db:"prefix"`   // Public part of the key, shown in listings to recognise it
+	KeyHash    string     `db:"key_hash"` // SHA-256 of the full key; the key itself is only shown once
+	Scopes     Scopes     `db:"scopes"`
+	CreatedAt  time.Time  `db:"created_at"`
+	ExpiresAt  time.Time  `db:"expires_at"`
+	LastUsedAt *time.Time `db:"last_used_at"`
+	RevokedAt  *time.Time `db:"revoked_at"`
+}
+
+func New(userID uuid.UUID, name, prefix, keyHash string, scopes Scopes, exp time.Time) APIKey {
+	return APIKey{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Name:      name,
+		Prefix:    prefix,
+		KeyHash:   keyHash,
+		Scopes:    scopes,
+		CreatedAt: time.Now().UTC(),
+		ExpiresAt: exp,
+	}
+}
+
+// IsActive reports whether the key can still be used to authenticate
+func (k APIKey) IsActive(now time.Time) bool {
+	return k.RevokedAt == nil && now.Before(k.ExpiresAt)
+}
+
+func ParseScope(s string) (Scope, error) {
+	switch sc := Scope(strings.ToLower(strings.TrimSpace(s))); sc {
+	case ScopeRead, ScopeWrite, ScopeAdmin:
+		return sc, nil
+	default:
+		return "", fmt.Errorf("unknown scope %q", s)
+	}
+}
+
+func (s Scopes) Has(scope Scope) bool {
+	for _, sc := range s {
+		if sc == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// Allows reports whether a request with the given HTTP method is within the scopes.
+// The admin scope implies write, and write implies read.
+func (s Scopes) Allows(method string) bool {
+	if s.Has(ScopeAdmin) || s.Has(ScopeWrite) {
+		return true
+	}
+	switch method {
+	case "GET", "HEAD", "OPTIONS":
+		return s.Has(ScopeRead)
+	}
+	return false
+}
+
+func (s Scopes) Strings() []string {
+	out := make([]string, len(s))
+	for i, sc := range s {
+		out[i] = string(sc)
+	}
+	return out
+}
+
+func (s Scopes) Value() (driver.Value, error) {
+	return strings.Join(s.Strings(), ","), nil
+}
+
+func (s *Scopes) Scan(src any) error {
+	var raw string
+	switch v := src.(type) {
+	case string:
+		raw = v
+	case []byte:
+		raw = string(v)
+	case nil:
+		*s = nil
+		return nil
+	default:
+		return fmt.Errorf("cannot scan %T into scopes", src)
+	}
+	*s = nil
+	for _, part := range strings.Split(raw, ",") {
+		if part == "" {
+			continue
+		}
+		sc, err := ParseScope(part)
+		if err != nil {
+			return err
+		}
+		*s = append(*s, sc)
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package audit
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Action string
+
+const (
+	ActionSuspendUser         Action = "user.suspend"
+	ActionReactivateUser      Action = "user.reactivate"
+	ActionResetPassword       Action = "user.reset_password"
+	ActionImpersonate         Action = "user.impersonate"
+	ActionImpersonatedRequest Action = "user.impersonated_request"
+	ActionCreateAPIKey        Action = "user.create_api_key"
+)
+
+// SystemActorID is the actor recorded for actions taken outside the API, such as from the admin CLI
+var SystemActorID = uuid.Nil
+
+// Entry records an admin action taken on a user account
+type Entry struct {
+	ID           uuid.UUID `db:"id"`
+	ActorID      uuid.UUID `db:"actor_id"` // Admin who performed the action
+	Action       Action    `db:"action"`
+	TargetUserID uuid.UUID `db:"target_user_id"`
+	Details      string    `db:"details"`
+	CreatedAt    time.Time `db:"created_at"`
+}
+
+func New(actorID uuid.UUID, action Action, targetUserID uuid.UUID, details string) Entry {
+	return Entry{
+		ID:           uuid.New(),
+		ActorID:      actorID,
+		Action:       action,
+		TargetUserID: targetUserID,
+		Details:      details,
+		CreatedAt:    time.Now().UTC(),
+	}
+}
@@ -0,0 +1,62 @@
+package idempotency
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxKeyLength caps client-chosen keys; UUIDs and similar random strings fit comfortably
+const MaxKeyLength = 255
+
+// Record remembers a request sent with an Idempotency-Key and, once it has
+// finished, the response to replay for retries
+type Record struct {
+	UserID      uuid.UUID `db:"user_id"`
+	Key         string    `db:"key"`
+	Fingerprint string    `db:"fingerprint"` // Identifies the request the key was first used with
+	StatusCode  int       `db:"status_code"` // Zero while the request is still being processed
+	ContentType string    `db:"content_type"`
+	Body        []byte    `db:"body"`
+	CreatedAt   time.Time `db:"created_at"`
+	ExpiresAt   time.Time `db:"expires_at"`
+}
+
+func New(userID uuid.UUID, key, fingerprint string, ttl time.Duration) Record {
+	now := time.Now().UTC()
+	return Record{
+		UserID:      userID,
+		Key:         key,
+		Fingerprint: fingerprint,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(ttl),
+	}
+}
+
+// Completed reports whether the response has been stored
+func (r Record) Completed() bool {
+	return r.StatusCode != 0
+}
+
+// Fingerprint hashes the parts of a request that must match for a key to be reused
+func Fingerprint(method, path string, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(method + " " + path + "\n"))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// ValidKey reports whether key is 1 to MaxKeyLength printable ASCII characters
+func ValidKey(key string) bool {
+	if key == "" || len(key) > MaxKeyLength {
+		return false
+	}
+	for i := 0; i < len(key); i++ {
+		if key[i] < 0x21 || key[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,59 @@
+package identity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Identity links an account at an external OpenID Connect provider to a user
+type Identity struct {
+	ID        uuid.UUID `db:"id"`
+	UserID    uuid.UUID `db:"user_id"`
+	Provider  string    `db:"provider"`
+	Subject   string    `db:"subject"` // Stable "sub" claim issued by the provider
+	Email     string    `db:"email"`   // Email at the time of linking
+	CreatedAt time.Time `db:"created_at"`
+}
+
+// External is what a provider tells us about the user after a successful login
+type External struct {
+	Provider      string
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+}
+
+// LoginState is a pending authorization request, kept until the provider redirects back
+type LoginState struct {
+	State        string    `db:"state"`
+	Provider     string    `db:"provider"`
+	CodeVerifier string    `db:"code_verifier"` // PKCE verifier, only its S256 challenge is sent to the provider
+	Nonce        string    `db:"nonce"`
+	CreatedAt    time.Time `db:"created_at"`
+	ExpiresAt    time.Time `db:"expires_at"`
+}
+
+func New(userID uuid.UUID, provider, subject, email string) Identity {
+	return Identity{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Provider:  provider,
+		Subject:   subject,
+		Email:     email,
+		CreatedAt: time.Now().UTC(),
+	}
+}
+
+func NewLoginState(state, provider, codeVerifier, nonce string, ttl time.Duration) LoginState {
+	now := time.Now().UTC()
+	return LoginState{
+		State:        state,
+		Provider:     provider,
+		CodeVerifier: codeVerifier,
+		Nonce:        nonce,
+		CreatedAt:    now,
+		ExpiresAt:    now.Add(ttl),
+	}
+}
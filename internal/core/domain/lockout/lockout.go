@@ -0,0 +1,98 @@
+package lockout
+
+import (
+	"time"
+)
+
+type Scope string
+
+const (
+	ScopeAccount Scope = "account" // Subject is the normalised email
+	ScopeIP      Scope = "ip"      // Subject is the client IP address
+)
+
+// Attempts tracks consecutive failed logins for one account or client IP
+type Attempts struct {
+	Scope        Scope      `db:"scope"`
+	Subject      string     `db:"subject"`
+	Failures     int        `db:"failures"`
+	LastFailedAt time.Time  `db:"last_failed_at"`
+	LockedUntil  *time.Time `db:"locked_until"`
+}
+
+// Policy controls progressive delays and temporary lockouts
+type Policy struct {
+	MaxAttempts      int           // Failures per account before it is locked
+	MaxAttemptsPerIP int           // Failures per client IP before it is locked
+	BaseDelay        time.Duration // Delay after the first account failure, doubled on each further failure
+	MaxDelay         time.Duration
+	LockoutDuration  time.Duration
+	FailureWindow    time.Duration // Failures older than this are forgotten
+}
+
+func DefaultPolicy() Policy {
+	return Policy{
+		MaxAttempts:      5,
+		MaxAttemptsPerIP: 20,
+		BaseDelay:        time.Second,
+		MaxDelay:         30 * time.Second,
+		LockoutDuration:  15 * time.Minute,
+		FailureWindow:    15 * time.Minute,
+	}
+}
+
+func New(scope Scope, subject string) Attempts {
+	return Attempts{
+		Scope:   scope,
+		Subject: subject,
+	}
+}
+
+// expired reports whether the recorded failures no longer count, either because
+// the lockout has run out or because the last failure is outside the window
+func (a Attempts) expired(p Policy, now time.Time) bool {
+	if a.Failures == 0 {
+		return true
+	}
+	if a.LockedUntil != nil {
+		return !now.Before(*a.LockedUntil)
+	}
+	return now.Sub(a.LastFailedAt) > p.FailureWindow
+}
+
+// RetryAt returns the earliest time another login attempt is allowed.
+// Account failures add a progressive delay; both scopes lock once maxAttempts is reached.
+func (a Attempts) RetryAt(p Policy, now time.Time) time.Time {
+	if a.expired(p, now) {
+		return time.Time{}
+	}
+	if a.LockedUntil != nil {
+		return *a.LockedUntil
+	}
+	if a.Scope != ScopeAccount || p.BaseDelay <= 0 {
+		return time.Time{}
+	}
+
+	delay := p.BaseDelay
+	for i := 1; i < a.Failures && delay < p.MaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	return a.LastFailedAt.Add(delay)
+}
+
+// RecordFailure counts a failed attempt and locks the subject once maxAttempts is reached
+func (a *Attempts) RecordFailure(p Policy, maxAttempts int, now time.Time) {
+	if a.expired(p, now) {
+		a.Failures = 0
+		a.LockedUntil = nil
+	}
+	a.Failures++
+	a.LastFailedAt = now
+	if maxAttempts > 0 && a.Failures >= maxAttempts {
+		lockedUntil := now.Add(p.LockoutDuration)
+		a.LockedUntil = &lockedUntil
+	}
+}
@@ -8,3 +8,12 @@ const (
 	OrderShipped	OrderStatus = "shipped"
 	OrderCancelled	OrderStatus = "cancelled"
 )
+
+// IsValid reports whether s is one of the known order statuses
+func (s OrderStatus) IsValid() bool {
+	switch s {
+	case OrderPending, OrderPaid, OrderShipped, OrderCancelled:
+		return true
+	}
+	return false
+}
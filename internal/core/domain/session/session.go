@@ -2,24 +2,60 @@ package session
 
 import (
 	"time"
+
+	"github.com/google/uuid"
 )
 
 type Session struct {
-	ID           string    `db:"id"`
-	Email        string    `db:"user_email"`
-	RefreshToken string    `db:"refresh_token"`
-	IsRevoked    bool      `db:"is_revoked"`
-	CreatedAt    time.Time `db:"created_at"`
-	ExpiresAt    time.Time `db:"expires_at"`
+	ID         string    `db:"id"`
+	UserID     uuid.UUID `db:"user_id"`
+	Email      string    `db:"user_email"`
+	UserAgent  string    `db:"user_agent"`
+	IPAddress  string    `db:"ip_address"`
+	IsRevoked  bool      `db:"is_revoked"`
+	CreatedAt  time.Time `db:"created_at"`
+	LastUsedAt time.Time `db:"last_used_at"` // Login or most recent token renewal
+	ExpiresAt  time.Time `db:"expires_at"`
+
+	ImpersonatorID *uuid.UUID `db:"impersonator_id"` // Admin acting as the user, nil for the user's own sessions
 }
 
-func New(id string, email, refreshToken string, isRevoked bool, exp time.Time) Session {
+// RefreshToken is one link in a session's refresh token chain.
+// Only the SHA-256 hash of the token is persisted; a token is consumed
+// once it has been exchanged for a new pair.
+type RefreshToken struct {
+	ID         string     `db:"id"` // JWT ID (jti) of the refresh token
+	SessionID  string     `db:"session_id"`
+	TokenHash  string     `db:"token_hash"`
+	ConsumedAt *time.Time `db:"consumed_at"`
+	CreatedAt  time.Time  `db:"created_at"`
+	ExpiresAt  time.Time  `db:"expires_at"`
+}
+
+func New(id string, userID uuid.UUID, email string, isRevoked bool, exp time.Time) Session {
+	now := time.Now().UTC()
 	return Session{
-		ID:           id,
-		Email:        email,
-		RefreshToken: refreshToken,
-		IsRevoked:    isRevoked,
-		CreatedAt:    time.Now().UTC(),
-		ExpiresAt:    exp,
+		ID:         id,
+		UserID:     userID,
+		Email:      email,
+		IsRevoked:  isRevoked,
+		CreatedAt:  now,
+		LastUsedAt: now,
+		ExpiresAt:  exp,
 	}
 }
+
+func NewRefreshToken(id, sessionID, tokenHash string, exp time.Time) RefreshToken {
+	return RefreshToken{
+		ID:        id,
+		SessionID: sessionID,
+		TokenHash: tokenHash,
+		CreatedAt: time.Now().UTC(),
+		ExpiresAt: exp,
+	}
+}
+
+// IsConsumed reports whether the token has already been rotated
+func (t RefreshToken) IsConsumed() bool {
+	return t.ConsumedAt != nil
+}
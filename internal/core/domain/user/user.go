@@ -7,12 +7,14 @@ import (
 )
 
 type User struct {
-	ID           uuid.UUID `db:"id"`
-	Email        string    `db:"email"`
-	PasswordHash string    `db:"password_hash"`
-	Name         string    `db:"name"`
-	IsAdmin      bool      `db:"is_admin"`
-	CreatedAt    time.Time `db:"created_at"`
+	ID           uuid.UUID  `db:"id"`
+	Email        string     `db:"email"`
+	PasswordHash string     `db:"password_hash"`
+	Name         string     `db:"name"`
+	IsAdmin      bool       `db:"is_admin"`
+	CreatedAt    time.Time  `db:"created_at"`
+	ErasedAt     *time.Time `db:"erased_at"`    // Set once the account was anonymised on request
+	SuspendedAt  *time.Time `db:"suspended_at"` // Suspended accounts cannot log in or use API keys
 }
 type Tokens struct {
 	AccessToken  string
@@ -30,3 +32,28 @@ func New(email, passwordHash, name string, isAdmin bool) User {
 		CreatedAt:    time.Now().UTC(),
 	}
 }
+
+// erasedPasswordHash is not a valid bcrypt hash, so no password ever matches it
+const erasedPasswordHash = "!erased"
+
+// Anonymize returns the user with every personal field replaced. The ID is kept
+// so orders and other financial records stay attached to the (now anonymous) account.
+func (u User) Anonymize(now time.Time) User {
+	return User{
+		ID:           u.ID,
+		Email:        "erased-" + u.ID.String() + "@erased.invalid",
+		PasswordHash: erasedPasswordHash,
+		Name:         "Erased user",
+		IsAdmin:      false,
+		CreatedAt:    u.CreatedAt,
+		ErasedAt:     &now,
+	}
+}
+
+func (u User) IsErased() bool {
+	return u.ErasedAt != nil
+}
+
+func (u User) IsSuspended() bool {
+	return u.SuspendedAt != nil
+}
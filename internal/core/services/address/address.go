@@ -5,6 +5,7 @@ import (
 
 	"github.com/frostnzx/go-ecommerce-api/internal/ports"
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
 )
 
 type API interface {
@@ -15,6 +16,8 @@ type API interface {
 	GetDefaultAddress(context.Context, GetDefaultAddressReq) (*GetDefaultAddressResp, error)
 }
 
+var tracer = otel.Tracer("github.com/frostnzx/go-ecommerce-api/internal/core/services/address")
+
 type Service struct {
 	addressRepo ports.AddressRepo
 }
@@ -28,12 +31,12 @@ func NewService(ar ports.AddressRepo) *Service {
 // Request/Response types
 
 type AddAddressReq struct {
-	UserID     uuid.UUID `json:"user_id"`
-	Line1      string    `json:"line1"`
-	City       string    `json:"city"`
-	Province   string    `json:"province"`
-	PostalCode string    `json:"postal_code"`
-	Country    string    `json:"country"`
+	UserID     uuid.UUID `json:"user_id" validate:"required"`
+	Line1      string    `json:"line1" validate:"notblank,max=200"`
+	City       string    `json:"city" validate:"notblank,max=100"`
+	Province   string    `json:"province" validate:"max=100"`
+	PostalCode string    `json:"postal_code" validate:"notblank,max=20"`
+	Country    string    `json:"country" validate:"notblank,max=100"`
 }
 
 type AddAddressResp struct {
@@ -3,25 +3,25 @@ package address
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/address"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/validation"
+	"github.com/frostnzx/go-ecommerce-api/internal/ports"
+	"github.com/google/uuid"
 )
 
 var (
-	ErrEmptyLine1   = errors.New("address line1 is required")
-	ErrEmptyCity    = errors.New("city is required")
-	ErrEmptyCountry = errors.New("country is required")
+	ErrAddressNotFound = errors.New("address not found")
+	ErrNotAddressOwner = errors.New("not authorized to modify this address")
+	ErrAddressInUse    = errors.New("address is used by an order and cannot be deleted")
 )
 
 func (s *Service) AddAddress(ctx context.Context, req AddAddressReq) (*AddAddressResp, error) {
-	if req.Line1 == "" {
-		return nil, ErrEmptyLine1
-	}
-	if req.City == "" {
-		return nil, ErrEmptyCity
-	}
-	if req.Country == "" {
-		return nil, ErrEmptyCountry
+	ctx, span := tracer.Start(ctx, "address.AddAddress")
+	defer span.End()
+	if err := validation.Struct(req); err != nil {
+		return nil, err
 	}
 
 	newAddress := address.New(req.UserID, req.Line1, req.City, req.Province, req.PostalCode, req.Country)
@@ -37,14 +37,35 @@ func (s *Service) AddAddress(ctx context.Context, req AddAddressReq) (*AddAddres
 }
 
 func (s *Service) DeleteAddress(ctx context.Context, req DeleteAddressReq) error {
+	ctx, span := tracer.Start(ctx, "address.DeleteAddress")
+	defer span.End()
 	// Verify ownership by getting the address first
-	addr, err := s.addressRepo.GetByID(ctx, req.ID)
+	addr, err := s.getAddress(ctx, req.ID)
 	if err != nil {
 		return err
 	}
 	if addr.UserID != req.UserID {
-		return errors.New("not authorized to delete this address")
+		return ErrNotAddressOwner
 	}
 
-	return s.addressRepo.DeleteById(ctx, req.ID)
+	// Orders keep referencing the address they were shipped to
+	if err := s.addressRepo.DeleteById(ctx, req.ID); err != nil {
+		if errors.Is(err, ports.ErrReference) {
+			return ErrAddressInUse
+		}
+		return err
+	}
+	return nil
+}
+
+// getAddress loads an address, reporting ErrAddressNotFound when it does not exist
+func (s *Service) getAddress(ctx context.Context, id uuid.UUID) (address.Address, error) {
+	addr, err := s.addressRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, ports.ErrNotFound) {
+			return address.Address{}, ErrAddressNotFound
+		}
+		return address.Address{}, fmt.Errorf("error getting address: %w", err)
+	}
+	return addr, nil
 }
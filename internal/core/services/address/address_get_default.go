@@ -2,12 +2,21 @@ package address
 
 import (
 	"context"
+	"errors"
+	"fmt"
+
+	"github.com/frostnzx/go-ecommerce-api/internal/ports"
 )
 
 func (s *Service) GetDefaultAddress(ctx context.Context, req GetDefaultAddressReq) (*GetDefaultAddressResp, error) {
+	ctx, span := tracer.Start(ctx, "address.GetDefaultAddress")
+	defer span.End()
 	addr, err := s.addressRepo.GetDefault(ctx, req.UserID)
 	if err != nil {
-		return nil, err
+		if errors.Is(err, ports.ErrNotFound) {
+			return nil, ErrAddressNotFound
+		}
+		return nil, fmt.Errorf("error getting default address: %w", err)
 	}
 
 	return &GetDefaultAddressResp{
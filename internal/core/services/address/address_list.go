@@ -5,6 +5,8 @@ import (
 )
 
 func (s *Service) ListAddresses(ctx context.Context, req ListAddressesReq) (*ListAddressesResp, error) {
+	ctx, span := tracer.Start(ctx, "address.ListAddresses")
+	defer span.End()
 	addresses, err := s.addressRepo.ListByUserID(ctx, req.UserID)
 	if err != nil {
 		return nil, err
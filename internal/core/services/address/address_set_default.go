@@ -2,17 +2,18 @@ package address
 
 import (
 	"context"
-	"errors"
 )
 
 func (s *Service) SetDefaultAddress(ctx context.Context, req SetDefaultAddressReq) error {
+	ctx, span := tracer.Start(ctx, "address.SetDefaultAddress")
+	defer span.End()
 	// Verify ownership
-	addr, err := s.addressRepo.GetByID(ctx, req.AddressID)
+	addr, err := s.getAddress(ctx, req.AddressID)
 	if err != nil {
 		return err
 	}
 	if addr.UserID != req.UserID {
-		return errors.New("not authorized to modify this address")
+		return ErrNotAddressOwner
 	}
 
 	return s.addressRepo.SetDefault(ctx, req.UserID, req.AddressID)
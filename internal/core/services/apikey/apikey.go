@@ -0,0 +1,31 @@
+package apikey
+
+import (
+	"context"
+
+	"github.com/frostnzx/go-ecommerce-api/internal/ports"
+	"go.opentelemetry.io/otel"
+)
+
+type API interface {
+	CreateAPIKey(context.Context, CreateAPIKeyReq) (*CreateAPIKeyResp, error)
+	ListAPIKeys(context.Context, ListAPIKeysReq) (*ListAPIKeysResp, error)
+	RevokeAPIKey(context.Context, RevokeAPIKeyReq) error
+	AuthenticateAPIKey(context.Context, AuthenticateAPIKeyReq) (*AuthenticateAPIKeyResp, error)
+}
+
+var tracer = otel.Tracer("github.com/frostnzx/go-ecommerce-api/internal/core/services/apikey")
+
+type Service struct {
+	apiKeyRepo ports.APIKeyRepo
+	userRepo   ports.UserRepo
+	auditRepo  ports.AuditRepo
+}
+
+func NewService(ar ports.APIKeyRepo, ur ports.UserRepo, aur ports.AuditRepo) *Service {
+	return &Service{
+		apiKeyRepo: ar,
+		userRepo:   ur,
+		auditRepo:  aur,
+	}
+}
@@ -0,0 +1,78 @@
+package apikey
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/apikey"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/utils"
+	"github.com/frostnzx/go-ecommerce-api/internal/ports"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidAPIKey    = errors.New("invalid or expired api key")
+	ErrAccountSuspended = errors.New("account is suspended")
+)
+
+// lastUsedResolution limits last-used tracking to one write per key per minute
+const lastUsedResolution = time.Minute
+
+type AuthenticateAPIKeyReq struct {
+	Key string
+}
+type AuthenticateAPIKeyResp struct {
+	KeyID   uuid.UUID
+	UserID  uuid.UUID
+	Email   string
+	IsAdmin bool // Only true when the owner is an admin and the key has the admin scope
+	Scopes  apikey.Scopes
+}
+
+func (s *Service) AuthenticateAPIKey(ctx context.Context, req AuthenticateAPIKeyReq) (*AuthenticateAPIKeyResp, error) {
+	ctx, span := tracer.Start(ctx, "apikey.AuthenticateAPIKey")
+	defer span.End()
+	if !strings.HasPrefix(req.Key, keyPrefix) {
+		return nil, ErrInvalidAPIKey
+	}
+
+	k, err := s.apiKeyRepo.GetByHash(ctx, utils.HashToken(req.Key))
+	if err != nil {
+		if errors.Is(err, ports.ErrNotFound) {
+			return nil, ErrInvalidAPIKey
+		}
+		return nil, fmt.Errorf("error getting api key:%w", err)
+	}
+	now := time.Now().UTC()
+	if !k.IsActive(now) {
+		return nil, ErrInvalidAPIKey
+	}
+
+	user, err := s.userRepo.GetUserByID(ctx, k.UserID)
+	if err != nil {
+		if errors.Is(err, ports.ErrNotFound) {
+			return nil, ErrInvalidAPIKey
+		}
+		return nil, fmt.Errorf("error getting user:%w", err)
+	}
+	if user.IsSuspended() {
+		return nil, ErrAccountSuspended
+	}
+
+	if k.LastUsedAt == nil || now.Sub(*k.LastUsedAt) >= lastUsedResolution {
+		if err := s.apiKeyRepo.TouchLastUsed(ctx, k.ID, now); err != nil {
+			return nil, fmt.Errorf("error updating api key:%w", err)
+		}
+	}
+
+	return &AuthenticateAPIKeyResp{
+		KeyID:   k.ID,
+		UserID:  user.ID,
+		Email:   user.Email,
+		IsAdmin: user.IsAdmin && k.Scopes.Has(apikey.ScopeAdmin),
+		Scopes:  k.Scopes,
+	}, nil
+}
@@ -0,0 +1,119 @@
+package apikey
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/apikey"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/audit"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/utils"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidName       = errors.New("api key name is required")
+	ErrInvalidScopes     = errors.New("at least one valid scope (read, write, admin) is required")
+	ErrAdminScopeDenied  = errors.New("admin scope can only be granted to admin users")
+	ErrInvalidExpiration = errors.New("api key expiration must be between 1 and 365 days")
+)
+
+const (
+	// keyPrefix marks our keys so they are easy to spot in logs and secret scanners
+	keyPrefix = "ecom_"
+
+	defaultExpiration = 90 * 24 * time.Hour
+	maxExpiration     = 365 * 24 * time.Hour
+)
+
+type CreateAPIKeyReq struct {
+	UserID        uuid.UUID
+	Name          string
+	Scopes        []string
+	ExpiresInDays int // 0 means the 90 day default
+	// CreatedBy is the admin creating the key for another user, which is audited; zero for the user's own keys
+	CreatedBy uuid.UUID
+}
+type CreateAPIKeyResp struct {
+	ID        uuid.UUID
+	Name      string
+	Key       string // The only time the plain key is returned
+	Prefix    string
+	Scopes    []string
+	ExpiresAt time.Time
+}
+
+func (s *Service) CreateAPIKey(ctx context.Context, req CreateAPIKeyReq) (*CreateAPIKeyResp, error) {
+	ctx, span := tracer.Start(ctx, "apikey.CreateAPIKey")
+	defer span.End()
+	name := strings.TrimSpace(req.Name)
+	if name == "" || len(name) > 100 {
+		return nil, ErrInvalidName
+	}
+
+	var scopes apikey.Scopes
+	for _, raw := range req.Scopes {
+		sc, err := apikey.ParseScope(raw)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidScopes, err)
+		}
+		if !scopes.Has(sc) {
+			scopes = append(scopes, sc)
+		}
+	}
+	if len(scopes) == 0 {
+		return nil, ErrInvalidScopes
+	}
+
+	expiresIn := defaultExpiration
+	if req.ExpiresInDays != 0 {
+		expiresIn = time.Duration(req.ExpiresInDays) * 24 * time.Hour
+	}
+	if expiresIn <= 0 || expiresIn > maxExpiration {
+		return nil, ErrInvalidExpiration
+	}
+
+	user, err := s.userRepo.GetUserByID(ctx, req.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting user:%w", err)
+	}
+	if scopes.Has(apikey.ScopeAdmin) && !user.IsAdmin {
+		return nil, ErrAdminScopeDenied
+	}
+
+	// <keyPrefix><public id>_<secret>; the public id is kept for display, the whole key is hashed
+	publicID, err := utils.RandomToken(6)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := utils.RandomToken(32)
+	if err != nil {
+		return nil, err
+	}
+	prefix := keyPrefix + publicID
+	key := prefix + "_" + secret
+
+	k := apikey.New(user.ID, name, prefix, utils.HashToken(key), scopes, time.Now().UTC().Add(expiresIn))
+
+	// A key for someone else lets the admin act as them, so it is never minted without a trail
+	if req.CreatedBy != uuid.Nil && req.CreatedBy != user.ID {
+		details := fmt.Sprintf("key %s (%s), scopes %s, expires %s", k.ID, k.Name, strings.Join(k.Scopes.Strings(), ","), k.ExpiresAt.Format(time.RFC3339))
+		if err := s.auditRepo.Record(ctx, audit.New(req.CreatedBy, audit.ActionCreateAPIKey, user.ID, details)); err != nil {
+			return nil, fmt.Errorf("error recording audit entry: %w", err)
+		}
+	}
+	if err := s.apiKeyRepo.Create(ctx, k); err != nil {
+		return nil, fmt.Errorf("error creating api key:%w", err)
+	}
+
+	return &CreateAPIKeyResp{
+		ID:        k.ID,
+		Name:      k.Name,
+		Key:       key,
+		Prefix:    k.Prefix,
+		Scopes:    k.Scopes.Strings(),
+		ExpiresAt: k.ExpiresAt,
+	}, nil
+}
@@ -0,0 +1,26 @@
+package apikey
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/apikey"
+	"github.com/google/uuid"
+)
+
+type ListAPIKeysReq struct {
+	UserID uuid.UUID
+}
+type ListAPIKeysResp struct {
+	APIKeys []apikey.APIKey
+}
+
+func (s *Service) ListAPIKeys(ctx context.Context, req ListAPIKeysReq) (*ListAPIKeysResp, error) {
+	ctx, span := tracer.Start(ctx, "apikey.ListAPIKeys")
+	defer span.End()
+	keys, err := s.apiKeyRepo.ListByUserID(ctx, req.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("error listing api keys:%w", err)
+	}
+	return &ListAPIKeysResp{APIKeys: keys}, nil
+}
@@ -0,0 +1,18 @@
+package apikey
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type RevokeAPIKeyReq struct {
+	ID     uuid.UUID
+	UserID uuid.UUID // Owner of the key; keys of other users are reported as not found
+}
+
+func (s *Service) RevokeAPIKey(ctx context.Context, req RevokeAPIKeyReq) error {
+	ctx, span := tracer.Start(ctx, "apikey.RevokeAPIKey")
+	defer span.End()
+	return s.apiKeyRepo.Revoke(ctx, req.ID, req.UserID)
+}
@@ -0,0 +1,237 @@
+package apikey
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/frostnzx/go-ecommerce-api/internal/adapters/secondary/memory"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/apikey"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/audit"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/user"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/utils"
+	"github.com/frostnzx/go-ecommerce-api/internal/ports"
+	"github.com/google/uuid"
+)
+
+type fixture struct {
+	svc      *Service
+	keys     *memory.APIKeyRepo
+	users    *memory.UserRepo
+	auditLog *memory.AuditRepo
+	user     user.User
+	admin    user.User
+}
+
+func newFixture(t *testing.T) fixture {
+	t.Helper()
+	s := memory.NewStore()
+	users, _ := memory.NewUserRepo(s)
+	keys, _ := memory.NewAPIKeyRepo(s)
+	auditLog, _ := memory.NewAuditRepo(s)
+	u := user.New("ada@example.com", "hash", "Ada", false)
+	admin := user.New("root@example.com", "hash", "Root", true)
+	for _, u := range []user.User{u, admin} {
+		if err := users.Create(context.Background(), u); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return fixture{svc: NewService(keys, users, auditLog), keys: keys, users: users, auditLog: auditLog, user: u, admin: admin}
+}
+
+func (f fixture) create(t *testing.T, userID uuid.UUID, scopes ...string) *CreateAPIKeyResp {
+	t.Helper()
+	res, err := f.svc.CreateAPIKey(context.Background(), CreateAPIKeyReq{UserID: userID, Name: "CI", Scopes: scopes})
+	if err != nil {
+		t.Fatalf("CreateAPIKey: %v", err)
+	}
+	return res
+}
+
+func (f fixture) authenticate(key string) (*AuthenticateAPIKeyResp, error) {
+	return f.svc.AuthenticateAPIKey(context.Background(), AuthenticateAPIKeyReq{Key: key})
+}
+
+func TestCreateAPIKeyValidatesRequest(t *testing.T) {
+	f := newFixture(t)
+	tests := []struct {
+		name string
+		req  CreateAPIKeyReq
+		want error
+	}{
+		{"blank name", CreateAPIKeyReq{Name: "  ", Scopes: []string{"read"}}, ErrInvalidName},
+		{"long name", CreateAPIKeyReq{Name: strings.Repeat("x", 101), Scopes: []string{"read"}}, ErrInvalidName},
+		{"no scopes", CreateAPIKeyReq{Name: "CI"}, ErrInvalidScopes},
+		{"unknown scope", CreateAPIKeyReq{Name: "CI", Scopes: []string{"read", "delete"}}, ErrInvalidScopes},
+		{"admin scope for a customer", CreateAPIKeyReq{Name: "CI", Scopes: []string{"admin"}}, ErrAdminScopeDenied},
+		{"negative lifetime", CreateAPIKeyReq{Name: "CI", Scopes: []string{"read"}, ExpiresInDays: -1}, ErrInvalidExpiration},
+		{"lifetime over a year", CreateAPIKeyReq{Name: "CI", Scopes: []string{"read"}, ExpiresInDays: 366}, ErrInvalidExpiration},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.req.UserID = f.user.ID
+			if _, err := f.svc.CreateAPIKey(context.Background(), tt.req); !errors.Is(err, tt.want) {
+				t.Fatalf("err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestCreateAPIKeyStoresOnlyTheHash(t *testing.T) {
+	f := newFixture(t)
+	before := time.Now()
+	res := f.create(t, f.user.ID, "read", "READ", "write")
+
+	if !strings.HasPrefix(res.Key, res.Prefix+"_") || !strings.HasPrefix(res.Prefix, keyPrefix) {
+		t.Fatalf("key %q does not start with prefix %q", res.Key, res.Prefix)
+	}
+	if len(res.Scopes) != 2 {
+		t.Fatalf("scopes = %v, want read and write once each", res.Scopes)
+	}
+	if exp := res.ExpiresAt.Sub(before); exp < defaultExpiration-time.Minute || exp > defaultExpiration+time.Minute {
+		t.Fatalf("key expires in %s, want the %s default", exp, defaultExpiration)
+	}
+	stored, err := f.keys.GetByHash(context.Background(), utils.HashToken(res.Key))
+	if err != nil {
+		t.Fatalf("key not stored by its hash: %v", err)
+	}
+	if strings.Contains(stored.KeyHash, res.Key) {
+		t.Fatal("plain key stored")
+	}
+}
+
+func TestAuthenticateAPIKey(t *testing.T) {
+	f := newFixture(t)
+	res := f.create(t, f.user.ID, "read")
+
+	got, err := f.authenticate(res.Key)
+	if err != nil {
+		t.Fatalf("AuthenticateAPIKey: %v", err)
+	}
+	if got.KeyID != res.ID || got.UserID != f.user.ID || got.IsAdmin || !got.Scopes.Has(apikey.ScopeRead) || got.Scopes.Has(apikey.ScopeWrite) {
+		t.Fatalf("got %+v", got)
+	}
+	stored, _ := f.keys.GetByHash(context.Background(), utils.HashToken(res.Key))
+	if stored.LastUsedAt == nil {
+		t.Fatal("last use not recorded")
+	}
+
+	for name, key := range map[string]string{
+		"unknown key":    keyPrefix + "abc_secret",
+		"foreign prefix": strings.TrimPrefix(res.Key, keyPrefix),
+		"truncated key":  res.Key[:len(res.Key)-1],
+	} {
+		if _, err := f.authenticate(key); !errors.Is(err, ErrInvalidAPIKey) {
+			t.Errorf("%s: err = %v, want ErrInvalidAPIKey", name, err)
+		}
+	}
+}
+
+func TestAuthenticateAPIKeyGrantsAdminOnlyWithTheAdminScope(t *testing.T) {
+	f := newFixture(t)
+	for scope, want := range map[string]bool{"write": false, "admin": true} {
+		got, err := f.authenticate(f.create(t, f.admin.ID, scope).Key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.IsAdmin != want {
+			t.Errorf("%s key of an admin: IsAdmin = %v, want %v", scope, got.IsAdmin, want)
+		}
+	}
+}
+
+func TestAuthenticateAPIKeyRejectsExpiredKeys(t *testing.T) {
+	f := newFixture(t)
+	key := keyPrefix + "old_secret"
+	k := apikey.New(f.user.ID, "old", keyPrefix+"old", utils.HashToken(key), apikey.Scopes{apikey.ScopeRead}, time.Now().Add(-time.Minute))
+	if err := f.keys.Create(context.Background(), k); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.authenticate(key); !errors.Is(err, ErrInvalidAPIKey) {
+		t.Fatalf("err = %v, want ErrInvalidAPIKey", err)
+	}
+}
+
+func TestRevokeAPIKey(t *testing.T) {
+	f := newFixture(t)
+	res := f.create(t, f.user.ID, "write")
+
+	// Another user cannot revoke it, or even learn that it exists
+	err := f.svc.RevokeAPIKey(context.Background(), RevokeAPIKeyReq{ID: res.ID, UserID: f.admin.ID})
+	if !errors.Is(err, ports.ErrAPIKeyNotFound) {
+		t.Fatalf("revoking another user's key: err = %v, want ErrAPIKeyNotFound", err)
+	}
+	if _, err := f.authenticate(res.Key); err != nil {
+		t.Fatalf("key stopped working after a refused revoke: %v", err)
+	}
+
+	if err := f.svc.RevokeAPIKey(context.Background(), RevokeAPIKeyReq{ID: res.ID, UserID: f.user.ID}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.authenticate(res.Key); !errors.Is(err, ErrInvalidAPIKey) {
+		t.Fatalf("revoked key: err = %v, want ErrInvalidAPIKey", err)
+	}
+	list, err := f.svc.ListAPIKeys(context.Background(), ListAPIKeysReq{UserID: f.user.ID})
+	if err != nil || len(list.APIKeys) != 1 || list.APIKeys[0].RevokedAt == nil {
+		t.Fatalf("list = %+v (%v), want the key listed as revoked", list, err)
+	}
+}
+
+func TestAuthenticateAPIKeyRejectsSuspendedOwner(t *testing.T) {
+	f := newFixture(t)
+	res := f.create(t, f.user.ID, "read")
+	now := time.Now()
+	if err := f.users.SetSuspended(context.Background(), f.user.ID, &now); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.authenticate(res.Key); !errors.Is(err, ErrAccountSuspended) {
+		t.Fatalf("err = %v, want ErrAccountSuspended", err)
+	}
+}
+
+func TestCreateAPIKeyForAnotherUserIsAudited(t *testing.T) {
+	f := newFixture(t)
+	ctx := context.Background()
+
+	// The user's own key leaves no audit entry
+	f.create(t, f.user.ID, "read")
+	if entries, _ := f.auditLog.List(ctx, f.user.ID, 10, 0); len(entries) != 0 {
+		t.Fatalf("own key audited: %+v", entries)
+	}
+
+	res, err := f.svc.CreateAPIKey(ctx, CreateAPIKeyReq{UserID: f.user.ID, Name: "support", Scopes: []string{"write"}, CreatedBy: f.admin.ID})
+	if err != nil {
+		t.Fatalf("CreateAPIKey: %v", err)
+	}
+	entries, err := f.auditLog.List(ctx, f.user.ID, 10, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].ActorID != f.admin.ID || entries[0].Action != audit.ActionCreateAPIKey ||
+		!strings.Contains(entries[0].Details, res.ID.String()) || !strings.Contains(entries[0].Details, "write") {
+		t.Fatalf("audit log = %+v, want the key created by the admin", entries)
+	}
+}
+
+// failingAudit refuses every entry, the way a lost database connection does
+type failingAudit struct {
+	ports.AuditRepo
+}
+
+func (failingAudit) Record(context.Context, audit.Entry) error {
+	return errors.New("connection refused")
+}
+
+func TestCreateAPIKeyForAnotherUserNeedsAnAuditEntry(t *testing.T) {
+	f := newFixture(t)
+	svc := NewService(f.keys, f.users, failingAudit{})
+	if _, err := svc.CreateAPIKey(context.Background(), CreateAPIKeyReq{UserID: f.user.ID, Name: "support", Scopes: []string{"write"}, CreatedBy: f.admin.ID}); err == nil {
+		t.Fatal("key created without an audit entry")
+	}
+	list, err := f.svc.ListAPIKeys(context.Background(), ListAPIKeysReq{UserID: f.user.ID})
+	if err != nil || len(list.APIKeys) != 0 {
+		t.Fatalf("keys = %+v (%v), want none", list, err)
+	}
+}
@@ -0,0 +1,33 @@
+package idempotency
+
+import (
+	"context"
+	"time"
+
+	"github.com/frostnzx/go-ecommerce-api/internal/ports"
+	"go.opentelemetry.io/otel"
+)
+
+type API interface {
+	BeginRequest(context.Context, BeginRequestReq) (*BeginRequestResp, error)
+	CompleteRequest(context.Context, CompleteRequestReq) error
+	ReleaseKey(context.Context, ReleaseKeyReq) error
+	PurgeExpiredKeys(ctx context.Context) (int64, error)
+}
+
+var tracer = otel.Tracer("github.com/frostnzx/go-ecommerce-api/internal/core/services/idempotency")
+
+// DefaultKeyTTL is how long a key can be replayed when nothing else is configured
+const DefaultKeyTTL = 24 * time.Hour
+
+type Service struct {
+	idempotencyRepo ports.IdempotencyRepo
+	keyTTL          time.Duration
+}
+
+func NewService(ir ports.IdempotencyRepo, keyTTL time.Duration) *Service {
+	return &Service{
+		idempotencyRepo: ir,
+		keyTTL:          keyTTL,
+	}
+}
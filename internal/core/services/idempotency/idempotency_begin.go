@@ -0,0 +1,62 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/idempotency"
+	"github.com/frostnzx/go-ecommerce-api/internal/ports"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrKeyReused         = errors.New("idempotency key was already used for a different request")
+	ErrRequestInProgress = errors.New("a request with this idempotency key is still being processed")
+)
+
+// abandonAfter is how long an unfinished request holds its key. It outlasts the
+// server's write timeout, so a record this old belongs to a request that crashed.
+const abandonAfter = 5 * time.Minute
+
+type BeginRequestReq struct {
+	UserID      uuid.UUID
+	Key         string
+	Fingerprint string
+}
+type BeginRequestResp struct {
+	Replay *idempotency.Record // The stored response when the key was used before; nil when the request should run
+}
+
+// BeginRequest claims the key for a new request, or returns the stored response
+// when the same request was already completed under it
+func (s *Service) BeginRequest(ctx context.Context, req BeginRequestReq) (*BeginRequestResp, error) {
+	ctx, span := tracer.Start(ctx, "idempotency.BeginRequest")
+	defer span.End()
+
+	r := idempotency.New(req.UserID, req.Key, req.Fingerprint, s.keyTTL)
+	err := s.idempotencyRepo.CreateKey(ctx, r, r.CreatedAt.Add(-abandonAfter))
+	if err == nil {
+		return &BeginRequestResp{}, nil
+	}
+	if !errors.Is(err, ports.ErrIdempotencyKeyExists) {
+		return nil, fmt.Errorf("error creating idempotency key:%w", err)
+	}
+
+	existing, err := s.idempotencyRepo.GetKey(ctx, req.UserID, req.Key)
+	if err != nil {
+		if errors.Is(err, ports.ErrNotFound) {
+			// Released by a failed request a moment ago; the client can simply retry
+			return nil, ErrRequestInProgress
+		}
+		return nil, fmt.Errorf("error getting idempotency key:%w", err)
+	}
+	if existing.Fingerprint != req.Fingerprint {
+		return nil, ErrKeyReused
+	}
+	if !existing.Completed() {
+		return nil, ErrRequestInProgress
+	}
+	return &BeginRequestResp{Replay: existing}, nil
+}
@@ -0,0 +1,41 @@
+package idempotency
+
+import (
+	"context"
+
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/idempotency"
+	"github.com/google/uuid"
+)
+
+type CompleteRequestReq struct {
+	UserID      uuid.UUID
+	Key         string
+	StatusCode  int
+	ContentType string
+	Body        []byte
+}
+
+// CompleteRequest stores the response so retries with the same key replay it
+func (s *Service) CompleteRequest(ctx context.Context, req CompleteRequestReq) error {
+	ctx, span := tracer.Start(ctx, "idempotency.CompleteRequest")
+	defer span.End()
+	return s.idempotencyRepo.CompleteKey(ctx, idempotency.Record{
+		UserID:      req.UserID,
+		Key:         req.Key,
+		StatusCode:  req.StatusCode,
+		ContentType: req.ContentType,
+		Body:        req.Body,
+	})
+}
+
+type ReleaseKeyReq struct {
+	UserID uuid.UUID
+	Key    string
+}
+
+// ReleaseKey frees the key of a request that failed, so it can be retried under the same key
+func (s *Service) ReleaseKey(ctx context.Context, req ReleaseKeyReq) error {
+	ctx, span := tracer.Start(ctx, "idempotency.ReleaseKey")
+	defer span.End()
+	return s.idempotencyRepo.DeleteKey(ctx, req.UserID, req.Key)
+}
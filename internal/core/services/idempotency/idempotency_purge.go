@@ -0,0 +1,13 @@
+package idempotency
+
+import (
+	"context"
+	"time"
+)
+
+// PurgeExpiredKeys deletes records that can no longer be replayed
+func (s *Service) PurgeExpiredKeys(ctx context.Context) (int64, error) {
+	ctx, span := tracer.Start(ctx, "idempotency.PurgeExpiredKeys")
+	defer span.End()
+	return s.idempotencyRepo.DeleteExpiredKeys(ctx, time.Now().UTC())
+}
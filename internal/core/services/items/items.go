@@ -5,6 +5,7 @@ import (
 
 	"github.com/frostnzx/go-ecommerce-api/internal/ports"
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
 )
 
 type API interface {
@@ -15,6 +16,8 @@ type API interface {
 	ListItemsByUser(ctx context.Context, req ListItemsByUserReq) (*ListItemsByUserResp, error)
 }
 
+var tracer = otel.Tracer("github.com/frostnzx/go-ecommerce-api/internal/core/services/items")
+
 type Service struct {
 	itemsRepo   ports.ItemsRepo
 	productRepo ports.ProductRepo
@@ -32,9 +35,10 @@ func NewService(ir ports.ItemsRepo, pr ports.ProductRepo, or ports.OrderRepo) *S
 // Request/Response types
 
 type AddItemReq struct {
-	OrderID   uuid.UUID `json:"order_id"`
-	ProductID uuid.UUID `json:"product_id"`
-	Quantity  int       `json:"quantity"`
+	OrderID   uuid.UUID `json:"order_id" validate:"required"`
+	ProductID uuid.UUID `json:"product_id" validate:"required"`
+	Quantity  int       `json:"quantity" validate:"min=1,max=1000"`
+	UserID    uuid.UUID `json:"user_id" validate:"required"` // For ownership verification
 }
 
 type AddItemResp struct {
@@ -46,7 +50,9 @@ type AddItemResp struct {
 }
 
 type GetItemReq struct {
-	ID uuid.UUID `json:"id"`
+	ID      uuid.UUID `json:"id"`
+	OrderID uuid.UUID `json:"order_id"` // For validation
+	UserID  uuid.UUID `json:"user_id"`  // For ownership verification
 }
 
 type GetItemResp struct {
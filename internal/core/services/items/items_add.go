@@ -3,12 +3,16 @@ package items
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/items"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/order"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/validation"
+	"github.com/frostnzx/go-ecommerce-api/internal/ports"
+	"github.com/google/uuid"
 )
 
 var (
-	ErrInvalidQuantity = errors.New("quantity must be greater than 0")
 	ErrProductNotFound = errors.New("product not found")
 	ErrOrderNotFound   = errors.New("order not found")
 	ErrNotOrderOwner   = errors.New("not authorized to modify this order")
@@ -16,20 +20,24 @@ var (
 )
 
 func (s *Service) AddItem(ctx context.Context, req AddItemReq) (*AddItemResp, error) {
-	if req.Quantity <= 0 {
-		return nil, ErrInvalidQuantity
+	ctx, span := tracer.Start(ctx, "items.AddItem")
+	defer span.End()
+	if err := validation.Struct(req); err != nil {
+		return nil, err
 	}
 
-	// Verify order exists
-	_, err := s.orderRepo.GetByID(ctx, req.OrderID)
-	if err != nil {
-		return nil, ErrOrderNotFound
+	// Verify order ownership
+	if _, err := s.getOwnedOrder(ctx, req.OrderID, req.UserID); err != nil {
+		return nil, err
 	}
 
 	// Get product to get current price
 	product, err := s.productRepo.GetByID(ctx, req.ProductID)
 	if err != nil {
-		return nil, ErrProductNotFound
+		if errors.Is(err, ports.ErrNotFound) {
+			return nil, ErrProductNotFound
+		}
+		return nil, fmt.Errorf("error getting product: %w", err)
 	}
 
 	// Create item with price snapshot
@@ -48,3 +56,35 @@ func (s *Service) AddItem(ctx context.Context, req AddItemReq) (*AddItemResp, er
 		UnitPriceSnapshot: created.UnitPriceSnapshot,
 	}, nil
 }
+
+// getOwnedOrder loads an order, reporting ErrOrderNotFound when it does not exist
+// and ErrNotOrderOwner when it belongs to someone else
+func (s *Service) getOwnedOrder(ctx context.Context, orderID, userID uuid.UUID) (order.Order, error) {
+	o, err := s.orderRepo.GetByID(ctx, orderID)
+	if err != nil {
+		if errors.Is(err, ports.ErrNotFound) {
+			return order.Order{}, ErrOrderNotFound
+		}
+		return order.Order{}, fmt.Errorf("error getting order: %w", err)
+	}
+	if o.UserId != userID {
+		return order.Order{}, ErrNotOrderOwner
+	}
+	return o, nil
+}
+
+// getOrderItem loads an item of the order, reporting ErrItemNotFound when the
+// item does not exist or belongs to another order
+func (s *Service) getOrderItem(ctx context.Context, orderID, id uuid.UUID) (items.Items, error) {
+	item, err := s.itemsRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, ports.ErrNotFound) {
+			return items.Items{}, ErrItemNotFound
+		}
+		return items.Items{}, fmt.Errorf("error getting item: %w", err)
+	}
+	if item.OrderID != orderID {
+		return items.Items{}, ErrItemNotFound
+	}
+	return item, nil
+}
@@ -6,22 +6,16 @@ import (
 )
 
 func (s *Service) DeleteItem(ctx context.Context, req DeleteItemReq) error {
+	ctx, span := tracer.Start(ctx, "items.DeleteItem")
+	defer span.End()
 	// Verify order ownership
-	order, err := s.orderRepo.GetByID(ctx, req.OrderID)
-	if err != nil {
-		return ErrOrderNotFound
-	}
-	if order.UserId != req.UserID {
-		return ErrNotOrderOwner
+	if _, err := s.getOwnedOrder(ctx, req.OrderID, req.UserID); err != nil {
+		return err
 	}
 
 	// Verify item exists and belongs to the order
-	item, err := s.itemsRepo.GetByID(ctx, req.ID)
-	if err != nil {
-		return ErrItemNotFound
-	}
-	if item.OrderID != req.OrderID {
-		return ErrItemNotFound
+	if _, err := s.getOrderItem(ctx, req.OrderID, req.ID); err != nil {
+		return err
 	}
 
 	return s.itemsRepo.DeleteById(ctx, req.ID)
@@ -5,9 +5,16 @@ import (
 )
 
 func (s *Service) GetItem(ctx context.Context, req GetItemReq) (*GetItemResp, error) {
-	item, err := s.itemsRepo.GetByID(ctx, req.ID)
+	ctx, span := tracer.Start(ctx, "items.GetItem")
+	defer span.End()
+	// Verify order ownership
+	if _, err := s.getOwnedOrder(ctx, req.OrderID, req.UserID); err != nil {
+		return nil, err
+	}
+
+	item, err := s.getOrderItem(ctx, req.OrderID, req.ID)
 	if err != nil {
-		return nil, ErrItemNotFound
+		return nil, err
 	}
 
 	return &GetItemResp{
@@ -20,13 +27,11 @@ func (s *Service) GetItem(ctx context.Context, req GetItemReq) (*GetItemResp, er
 }
 
 func (s *Service) ListItemsByOrder(ctx context.Context, req ListItemsByOrderReq) (*ListItemsByOrderResp, error) {
+	ctx, span := tracer.Start(ctx, "items.ListItemsByOrder")
+	defer span.End()
 	// Verify order ownership
-	order, err := s.orderRepo.GetByID(ctx, req.OrderID)
-	if err != nil {
-		return nil, ErrOrderNotFound
-	}
-	if order.UserId != req.UserID {
-		return nil, ErrNotOrderOwner
+	if _, err := s.getOwnedOrder(ctx, req.OrderID, req.UserID); err != nil {
+		return nil, err
 	}
 
 	items, err := s.itemsRepo.ListByOrderID(ctx, req.OrderID)
@@ -50,6 +55,8 @@ func (s *Service) ListItemsByOrder(ctx context.Context, req ListItemsByOrderReq)
 }
 
 func (s *Service) ListItemsByUser(ctx context.Context, req ListItemsByUserReq) (*ListItemsByUserResp, error) {
+	ctx, span := tracer.Start(ctx, "items.ListItemsByUser")
+	defer span.End()
 	items, err := s.itemsRepo.ListByUserID(ctx, req.UserID)
 	if err != nil {
 		return nil, err
@@ -6,6 +6,7 @@ import (
 
 	"github.com/frostnzx/go-ecommerce-api/internal/ports"
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
 )
 
 type API interface {
@@ -16,31 +17,38 @@ type API interface {
 	UpdateOrderStatus(context.Context, UpdateOrderStatusReq) error // Admin only
 }
 
+var tracer = otel.Tracer("github.com/frostnzx/go-ecommerce-api/internal/core/services/order")
+
 type Service struct {
 	orderRepo   ports.OrderRepo
 	itemsRepo   ports.ItemsRepo
 	productRepo ports.ProductRepo
+	addressRepo ports.AddressRepo
+	metrics     ports.BusinessMetrics
 }
 
-func NewService(or ports.OrderRepo, ir ports.ItemsRepo, pr ports.ProductRepo) *Service {
+func NewService(or ports.OrderRepo, ir ports.ItemsRepo, pr ports.ProductRepo, ar ports.AddressRepo, bm ports.BusinessMetrics) *Service {
 	return &Service{
 		orderRepo:   or,
 		itemsRepo:   ir,
 		productRepo: pr,
+		addressRepo: ar,
+		metrics:     bm,
 	}
 }
 
 // Request/Response types
 
 type OrderItemReq struct {
-	ProductID uuid.UUID `json:"product_id"`
-	Quantity  int       `json:"quantity"`
+	ProductID uuid.UUID `json:"product_id" validate:"required"`
+	Quantity  int       `json:"quantity" validate:"min=1,max=1000"`
 }
 
 type PlaceOrderReq struct {
-	UserID    uuid.UUID      `json:"user_id"`
-	AddressID uuid.UUID      `json:"address_id"`
-	Items     []OrderItemReq `json:"items"`
+	UserID    uuid.UUID      `json:"user_id" validate:"required"`
+	AddressID uuid.UUID      `json:"address_id" validate:"required"`
+	Items     []OrderItemReq `json:"items" validate:"required,min=1,max=100,dive"`
+	PlacedAt  time.Time      `json:"-"` // Backdates the order when loading generated data; zero means now
 }
 
 type PlaceOrderResp struct {
@@ -12,10 +12,12 @@ var (
 )
 
 func (s *Service) CancelOrder(ctx context.Context, req CancelOrderReq) error {
+	ctx, span := tracer.Start(ctx, "order.CancelOrder")
+	defer span.End()
 	// Get the order
-	o, err := s.orderRepo.GetByID(ctx, req.OrderID)
+	o, err := s.getOrder(ctx, req.OrderID)
 	if err != nil {
-		return ErrOrderNotFound
+		return err
 	}
 
 	// Verify ownership
@@ -28,5 +30,9 @@ func (s *Service) CancelOrder(ctx context.Context, req CancelOrderReq) error {
 		return ErrCannotCancelOrder
 	}
 
-	return s.orderRepo.UpdateStatus(ctx, req.OrderID, order.OrderCancelled)
+	if err := s.orderRepo.UpdateStatus(ctx, req.OrderID, order.OrderCancelled); err != nil {
+		return err
+	}
+	s.metrics.OrderCancelled()
+	return nil
 }
@@ -3,20 +3,26 @@ package order
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/order"
+	"github.com/frostnzx/go-ecommerce-api/internal/ports"
+	"github.com/google/uuid"
 )
 
 var (
 	ErrOrderNotFound = errors.New("order not found")
 	ErrNotOrderOwner = errors.New("not authorized to access this order")
+	ErrInvalidStatus = errors.New("status must be one of pending, paid, shipped, cancelled")
 )
 
 func (s *Service) GetOrder(ctx context.Context, req GetOrderReq) (*GetOrderResp, error) {
+	ctx, span := tracer.Start(ctx, "order.GetOrder")
+	defer span.End()
 	// Get the order
-	o, err := s.orderRepo.GetByID(ctx, req.OrderID)
+	o, err := s.getOrder(ctx, req.OrderID)
 	if err != nil {
-		return nil, ErrOrderNotFound
+		return nil, err
 	}
 
 	// Verify ownership
@@ -51,6 +57,8 @@ func (s *Service) GetOrder(ctx context.Context, req GetOrderReq) (*GetOrderResp,
 }
 
 func (s *Service) ListOrders(ctx context.Context, req ListOrdersReq) (*ListOrdersResp, error) {
+	ctx, span := tracer.Start(ctx, "order.ListOrders")
+	defer span.End()
 	orders, err := s.orderRepo.ListByUserID(ctx, req.UserID)
 	if err != nil {
 		return nil, err
@@ -72,11 +80,36 @@ func (s *Service) ListOrders(ctx context.Context, req ListOrdersReq) (*ListOrder
 }
 
 func (s *Service) UpdateOrderStatus(ctx context.Context, req UpdateOrderStatusReq) error {
+	ctx, span := tracer.Start(ctx, "order.UpdateOrderStatus")
+	defer span.End()
+	status := order.OrderStatus(req.Status)
+	if !status.IsValid() {
+		return ErrInvalidStatus
+	}
+
 	// Verify the order exists
-	_, err := s.orderRepo.GetByID(ctx, req.OrderID)
+	o, err := s.getOrder(ctx, req.OrderID)
 	if err != nil {
-		return ErrOrderNotFound
+		return err
 	}
 
-	return s.orderRepo.UpdateStatus(ctx, req.OrderID, order.OrderStatus(req.Status))
+	if err := s.orderRepo.UpdateStatus(ctx, req.OrderID, status); err != nil {
+		return err
+	}
+	if status == order.OrderCancelled && o.Status != order.OrderCancelled {
+		s.metrics.OrderCancelled()
+	}
+	return nil
+}
+
+// getOrder loads an order, reporting ErrOrderNotFound when it does not exist
+func (s *Service) getOrder(ctx context.Context, id uuid.UUID) (order.Order, error) {
+	o, err := s.orderRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, ports.ErrNotFound) {
+			return order.Order{}, ErrOrderNotFound
+		}
+		return order.Order{}, fmt.Errorf("error getting order: %w", err)
+	}
+	return o, nil
 }
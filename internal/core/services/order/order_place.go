@@ -3,22 +3,38 @@ package order
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/items"
 	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/order"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/validation"
+	"github.com/frostnzx/go-ecommerce-api/internal/ports"
 	"github.com/google/uuid"
 )
 
 var (
-	ErrEmptyOrder        = errors.New("order must have at least one item")
-	ErrInvalidQuantity   = errors.New("quantity must be greater than 0")
 	ErrProductNotFound   = errors.New("product not found")
 	ErrInsufficientStock = errors.New("insufficient stock")
+	ErrAddressNotFound   = errors.New("address not found")
 )
 
 func (s *Service) PlaceOrder(ctx context.Context, req PlaceOrderReq) (*PlaceOrderResp, error) {
-	if len(req.Items) == 0 {
-		return nil, ErrEmptyOrder
+	ctx, span := tracer.Start(ctx, "order.PlaceOrder")
+	defer span.End()
+	if err := validation.Struct(req); err != nil {
+		return nil, err
+	}
+
+	// Someone else's address is reported as missing so its existence is not revealed
+	addr, err := s.addressRepo.GetByID(ctx, req.AddressID)
+	if err != nil {
+		if errors.Is(err, ports.ErrNotFound) {
+			return nil, ErrAddressNotFound
+		}
+		return nil, fmt.Errorf("error getting address: %w", err)
+	}
+	if addr.UserID != req.UserID {
+		return nil, ErrAddressNotFound
 	}
 
 	// Calculate total amount and validate products
@@ -31,14 +47,13 @@ func (s *Service) PlaceOrder(ctx context.Context, req PlaceOrderReq) (*PlaceOrde
 	var itemsWithPrices []itemWithPrice
 
 	for _, item := range req.Items {
-		if item.Quantity <= 0 {
-			return nil, ErrInvalidQuantity
-		}
-
 		// Get product to validate and get price
 		product, err := s.productRepo.GetByID(ctx, item.ProductID)
 		if err != nil {
-			return nil, ErrProductNotFound
+			if errors.Is(err, ports.ErrNotFound) {
+				return nil, ErrProductNotFound
+			}
+			return nil, fmt.Errorf("error getting product: %w", err)
 		}
 
 		// Check stock
@@ -57,8 +72,15 @@ func (s *Service) PlaceOrder(ctx context.Context, req PlaceOrderReq) (*PlaceOrde
 
 	// Create the order
 	newOrder := order.New(req.UserID, req.AddressID, order.OrderPending, totalAmount)
+	if !req.PlacedAt.IsZero() {
+		newOrder.CreatedAt = req.PlacedAt.UTC()
+	}
 	createdOrder, err := s.orderRepo.Create(ctx, newOrder)
 	if err != nil {
+		// The address was deleted after it was checked
+		if errors.Is(err, ports.ErrReference) {
+			return nil, ErrAddressNotFound
+		}
 		return nil, err
 	}
 
@@ -70,6 +92,7 @@ func (s *Service) PlaceOrder(ctx context.Context, req PlaceOrderReq) (*PlaceOrde
 			return nil, err
 		}
 	}
+	s.metrics.OrderPlaced(createdOrder.TotalAmount)
 
 	return &PlaceOrderResp{
 		ID:          createdOrder.ID,
@@ -0,0 +1,171 @@
+package order
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/frostnzx/go-ecommerce-api/internal/adapters/secondary/memory"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/address"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/order"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/product"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/user"
+	"github.com/frostnzx/go-ecommerce-api/internal/ports"
+	"github.com/google/uuid"
+)
+
+type nopMetrics struct{}
+
+func (nopMetrics) OrderPlaced(float64) {}
+func (nopMetrics) OrderCancelled()     {}
+func (nopMetrics) LoginFailed(string)  {}
+
+// unreachableOrders fails every lookup the way a lost database connection does
+type unreachableOrders struct {
+	ports.OrderRepo
+}
+
+func (unreachableOrders) GetByID(context.Context, uuid.UUID) (order.Order, error) {
+	return order.Order{}, errors.New("connection refused")
+}
+
+type fixture struct {
+	svc     *Service
+	users   ports.UserRepo
+	user    user.User
+	address address.Address
+	product product.Product
+}
+
+func newFixture(t *testing.T) fixture {
+	t.Helper()
+	ctx := context.Background()
+	s := memory.NewStore()
+	users, _ := memory.NewUserRepo(s)
+	addresses, _ := memory.NewAddressRepo(s)
+	orders, _ := memory.NewOrderRepo(s)
+	items, _ := memory.NewItemsRepo(s)
+	products, _ := memory.NewProductRepo(s)
+
+	u := user.New("ada@example.com", "hash", "Ada", false)
+	if err := users.Create(ctx, u); err != nil {
+		t.Fatal(err)
+	}
+	a, err := addresses.Create(ctx, address.New(u.ID, "1 Main Street", "Springfield", "IL", "62701", "United States"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	p, err := products.Create(ctx, product.New("SKU-1", "Widget", "", 4.25, 3))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return fixture{svc: NewService(orders, items, products, addresses, nopMetrics{}), users: users, user: u, address: a, product: p}
+}
+
+func (f fixture) place(t *testing.T, qty int) (*PlaceOrderResp, error) {
+	t.Helper()
+	return f.svc.PlaceOrder(context.Background(), PlaceOrderReq{
+		UserID:    f.user.ID,
+		AddressID: f.address.ID,
+		Items:     []OrderItemReq{{ProductID: f.product.ID, Quantity: qty}},
+	})
+}
+
+func TestPlaceOrderPricesItemsFromCatalog(t *testing.T) {
+	f := newFixture(t)
+	resp, err := f.place(t, 2)
+	if err != nil {
+		t.Fatalf("PlaceOrder: %v", err)
+	}
+	if resp.TotalAmount != 8.5 || resp.Status != string(order.OrderPending) {
+		t.Fatalf("got %+v, want a pending order totalling 8.50", resp)
+	}
+
+	got, err := f.svc.GetOrder(context.Background(), GetOrderReq{OrderID: resp.ID, UserID: f.user.ID})
+	if err != nil {
+		t.Fatalf("GetOrder: %v", err)
+	}
+	if len(got.Items) != 1 || got.Items[0].Quantity != 2 || got.Items[0].UnitPrice != 4.25 {
+		t.Fatalf("items = %+v", got.Items)
+	}
+}
+
+func TestPlaceOrderBackdatesGeneratedOrders(t *testing.T) {
+	f := newFixture(t)
+	placedAt := time.Date(2025, time.March, 14, 9, 30, 0, 0, time.FixedZone("CET", 3600))
+	resp, err := f.svc.PlaceOrder(context.Background(), PlaceOrderReq{
+		UserID:    f.user.ID,
+		AddressID: f.address.ID,
+		Items:     []OrderItemReq{{ProductID: f.product.ID, Quantity: 1}},
+		PlacedAt:  placedAt,
+	})
+	if err != nil {
+		t.Fatalf("PlaceOrder: %v", err)
+	}
+	if !resp.CreatedAt.Equal(placedAt) || resp.CreatedAt.Location() != time.UTC {
+		t.Fatalf("CreatedAt = %s, want %s in UTC", resp.CreatedAt, placedAt)
+	}
+}
+
+func TestPlaceOrderRejectsUnknownProductAndShortStock(t *testing.T) {
+	f := newFixture(t)
+	if _, err := f.place(t, 4); !errors.Is(err, ErrInsufficientStock) {
+		t.Fatalf("err = %v, want ErrInsufficientStock", err)
+	}
+	f.product.ID = uuid.New()
+	if _, err := f.place(t, 1); !errors.Is(err, ErrProductNotFound) {
+		t.Fatalf("err = %v, want ErrProductNotFound", err)
+	}
+}
+
+func TestCancelOrder(t *testing.T) {
+	f := newFixture(t)
+	resp, err := f.place(t, 1)
+	if err != nil {
+		t.Fatalf("PlaceOrder: %v", err)
+	}
+	ctx := context.Background()
+
+	if err := f.svc.CancelOrder(ctx, CancelOrderReq{OrderID: resp.ID, UserID: uuid.New()}); !errors.Is(err, ErrNotOrderOwner) {
+		t.Fatalf("cancel by another user: err = %v, want ErrNotOrderOwner", err)
+	}
+	if err := f.svc.CancelOrder(ctx, CancelOrderReq{OrderID: uuid.New(), UserID: f.user.ID}); !errors.Is(err, ErrOrderNotFound) {
+		t.Fatalf("cancel of a missing order: err = %v, want ErrOrderNotFound", err)
+	}
+	if err := f.svc.CancelOrder(ctx, CancelOrderReq{OrderID: resp.ID, UserID: f.user.ID}); err != nil {
+		t.Fatalf("CancelOrder: %v", err)
+	}
+	if err := f.svc.CancelOrder(ctx, CancelOrderReq{OrderID: resp.ID, UserID: f.user.ID}); !errors.Is(err, ErrCannotCancelOrder) {
+		t.Fatalf("second cancel: err = %v, want ErrCannotCancelOrder", err)
+	}
+}
+
+func TestPlaceOrderRejectsUnknownAddress(t *testing.T) {
+	f := newFixture(t)
+	f.address.ID = uuid.New()
+	if _, err := f.place(t, 1); !errors.Is(err, ErrAddressNotFound) {
+		t.Fatalf("err = %v, want ErrAddressNotFound", err)
+	}
+}
+
+func TestPlaceOrderRejectsAnotherUsersAddress(t *testing.T) {
+	f := newFixture(t)
+	mallory := user.New("mallory@example.com", "hash", "Mallory", false)
+	if err := f.users.Create(context.Background(), mallory); err != nil {
+		t.Fatal(err)
+	}
+	f.user = mallory
+	if _, err := f.place(t, 1); !errors.Is(err, ErrAddressNotFound) {
+		t.Fatalf("err = %v, want ErrAddressNotFound", err)
+	}
+}
+
+func TestCancelOrderDoesNotReportFailuresAsNotFound(t *testing.T) {
+	f := newFixture(t)
+	f.svc.orderRepo = unreachableOrders{f.svc.orderRepo}
+	err := f.svc.CancelOrder(context.Background(), CancelOrderReq{OrderID: uuid.New(), UserID: f.user.ID})
+	if err == nil || errors.Is(err, ErrOrderNotFound) {
+		t.Fatalf("err = %v, want the lookup failure", err)
+	}
+}
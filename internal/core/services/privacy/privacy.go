@@ -0,0 +1,39 @@
+package privacy
+
+import (
+	"context"
+
+	"github.com/frostnzx/go-ecommerce-api/internal/core/services/session"
+	"github.com/frostnzx/go-ecommerce-api/internal/ports"
+	"go.opentelemetry.io/otel"
+)
+
+// API covers the data subject rights: access to (export) and erasure of personal data
+type API interface {
+	ExportData(context.Context, ExportDataReq) (*ExportDataResp, error)
+	EraseAccount(context.Context, EraseAccountReq) error
+}
+
+var tracer = otel.Tracer("github.com/frostnzx/go-ecommerce-api/internal/core/services/privacy")
+
+type Service struct {
+	userRepo       ports.UserRepo
+	addressRepo    ports.AddressRepo
+	orderRepo      ports.OrderRepo
+	itemsRepo      ports.ItemsRepo
+	identityRepo   ports.IdentityRepo
+	privacyRepo    ports.PrivacyRepo
+	sessionService session.API
+}
+
+func NewService(ur ports.UserRepo, ar ports.AddressRepo, or ports.OrderRepo, ir ports.ItemsRepo, idr ports.IdentityRepo, pr ports.PrivacyRepo, ss session.API) *Service {
+	return &Service{
+		userRepo:       ur,
+		addressRepo:    ar,
+		orderRepo:      or,
+		itemsRepo:      ir,
+		identityRepo:   idr,
+		privacyRepo:    pr,
+		sessionService: ss,
+	}
+}
@@ -0,0 +1,40 @@
+package privacy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrAlreadyErased = errors.New("account has already been erased")
+)
+
+type EraseAccountReq struct {
+	UserID uuid.UUID
+}
+
+// EraseAccount anonymises the account instead of deleting it, so orders, items and
+// totals survive for accounting while nothing identifying the person is left
+func (s *Service) EraseAccount(ctx context.Context, req EraseAccountReq) error {
+	ctx, span := tracer.Start(ctx, "privacy.EraseAccount")
+	defer span.End()
+	u, err := s.userRepo.GetUserByID(ctx, req.UserID)
+	if err != nil {
+		return fmt.Errorf("error getting user:%w", err)
+	}
+	if u.IsErased() {
+		return ErrAlreadyErased
+	}
+
+	if err := s.privacyRepo.EraseUser(ctx, u.Anonymize(time.Now().UTC())); err != nil {
+		return fmt.Errorf("error erasing user:%w", err)
+	}
+
+	// The sessions are gone from the database; drop cached state so outstanding access tokens stop working now
+	s.sessionService.InvalidateUserSessions(u.ID)
+	return nil
+}
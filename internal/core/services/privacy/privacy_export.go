@@ -0,0 +1,76 @@
+package privacy
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/address"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/identity"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/items"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/order"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/session"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/user"
+	"github.com/google/uuid"
+)
+
+type ExportDataReq struct {
+	UserID uuid.UUID
+}
+
+type OrderExport struct {
+	Order order.Order
+	Items []items.Items
+}
+
+// ExportDataResp is everything we hold about the user
+type ExportDataResp struct {
+	ExportedAt time.Time
+	Profile    user.User
+	Addresses  []address.Address
+	Orders     []OrderExport
+	Sessions   []session.Session
+	Identities []identity.Identity
+}
+
+func (s *Service) ExportData(ctx context.Context, req ExportDataReq) (*ExportDataResp, error) {
+	ctx, span := tracer.Start(ctx, "privacy.ExportData")
+	defer span.End()
+	u, err := s.userRepo.GetUserByID(ctx, req.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting user:%w", err)
+	}
+	addresses, err := s.addressRepo.ListByUserID(ctx, req.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("error listing addresses:%w", err)
+	}
+	orders, err := s.orderRepo.ListByUserID(ctx, req.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("error listing orders:%w", err)
+	}
+	orderExports := make([]OrderExport, 0, len(orders))
+	for _, o := range orders {
+		its, err := s.itemsRepo.ListByOrderID(ctx, o.ID)
+		if err != nil {
+			return nil, fmt.Errorf("error listing items of order %s:%w", o.ID, err)
+		}
+		orderExports = append(orderExports, OrderExport{Order: o, Items: its})
+	}
+	sessions, err := s.sessionService.ListUserSessions(ctx, req.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("error listing sessions:%w", err)
+	}
+	identities, err := s.identityRepo.ListByUserID(ctx, req.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("error listing identities:%w", err)
+	}
+
+	return &ExportDataResp{
+		ExportedAt: time.Now().UTC(),
+		Profile:    *u,
+		Addresses:  addresses,
+		Orders:     orderExports,
+		Sessions:   sessions,
+		Identities: identities,
+	}, nil
+}
@@ -0,0 +1,167 @@
+package privacy
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/frostnzx/go-ecommerce-api/internal/adapters/secondary/memory"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/address"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/identity"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/items"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/order"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/product"
+	domainsession "github.com/frostnzx/go-ecommerce-api/internal/core/domain/session"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/user"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/services/session"
+	"github.com/google/uuid"
+)
+
+type fixture struct {
+	svc        *Service
+	sessions   *session.Service
+	users      *memory.UserRepo
+	addresses  *memory.AddressRepo
+	orders     *memory.OrderRepo
+	items      *memory.ItemsRepo
+	products   *memory.ProductRepo
+	identities *memory.IdentityRepo
+	sessionDB  *memory.SessionRepo
+}
+
+func newFixture(t *testing.T) fixture {
+	t.Helper()
+	s := memory.NewStore()
+	f := fixture{}
+	f.users, _ = memory.NewUserRepo(s)
+	f.addresses, _ = memory.NewAddressRepo(s)
+	f.orders, _ = memory.NewOrderRepo(s)
+	f.items, _ = memory.NewItemsRepo(s)
+	f.products, _ = memory.NewProductRepo(s)
+	f.identities, _ = memory.NewIdentityRepo(s)
+	f.sessionDB, _ = memory.NewSessionRepo(s)
+	privacy, _ := memory.NewPrivacyRepo(s)
+	f.sessions = session.NewService(f.sessionDB)
+	f.svc = NewService(f.users, f.addresses, f.orders, f.items, f.identities, privacy, f.sessions)
+	return f
+}
+
+// customer creates a user who has placed one order and is logged in, and returns the session ID
+func (f fixture) customer(t *testing.T, email string) (user.User, string) {
+	t.Helper()
+	ctx := context.Background()
+	u := user.New(email, "hash", "Name of "+email, false)
+	if err := f.users.Create(ctx, u); err != nil {
+		t.Fatal(err)
+	}
+	a, err := f.addresses.Create(ctx, address.New(u.ID, "1 Main Street", "Springfield", "IL", "62701", "United States"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	p, err := f.products.Create(ctx, product.New("SKU-"+email, "Widget", "A widget", 10, 5))
+	if err != nil {
+		t.Fatal(err)
+	}
+	o, err := f.orders.Create(ctx, order.New(u.ID, a.ID, order.OrderPending, 20))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.items.Create(ctx, items.New(o.ID, p.ID, 2, 10)); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.identities.Create(ctx, identity.New(u.ID, "google", "sub-"+email, email)); err != nil {
+		t.Fatal(err)
+	}
+	sess := domainsession.New(uuid.NewString(), u.ID, u.Email, false, time.Now().Add(time.Hour))
+	if _, err := f.sessionDB.CreateSession(ctx, &sess); err != nil {
+		t.Fatal(err)
+	}
+	return u, sess.ID
+}
+
+func TestEraseAccount(t *testing.T) {
+	f := newFixture(t)
+	ctx := context.Background()
+	ada, sessionID := f.customer(t, "ada@example.com")
+	f.customer(t, "grace@example.com")
+
+	// Cache the session as active, the way the auth middleware does on every request
+	if err := f.sessions.ValidateSession(ctx, sessionID); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.svc.EraseAccount(ctx, EraseAccountReq{UserID: ada.ID}); err != nil {
+		t.Fatalf("EraseAccount: %v", err)
+	}
+
+	got, err := f.users.GetUserByID(ctx, ada.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.IsErased() || got.Email == ada.Email || got.Name == ada.Name || got.PasswordHash == ada.PasswordHash {
+		t.Fatalf("user = %+v, want it anonymised", got)
+	}
+	// The outstanding access token stops working at once, not when the cache expires
+	if err := f.sessions.ValidateSession(ctx, sessionID); !errors.Is(err, session.ErrSessionNotActive) {
+		t.Fatalf("ValidateSession = %v, want ErrSessionNotActive", err)
+	}
+
+	export, err := f.svc.ExportData(ctx, ExportDataReq{UserID: ada.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(export.Orders) != 1 || len(export.Orders[0].Items) != 1 || export.Orders[0].Order.TotalAmount != 20 {
+		t.Fatalf("orders = %+v, want the order and its items kept", export.Orders)
+	}
+	if len(export.Addresses) != 1 || export.Addresses[0].Line1 != "" || export.Addresses[0].PostalCode != "" {
+		t.Fatalf("addresses = %+v, want the shipping address without street and postal code", export.Addresses)
+	}
+	if len(export.Sessions) != 0 || len(export.Identities) != 0 {
+		t.Fatalf("%d sessions and %d identities left, want none", len(export.Sessions), len(export.Identities))
+	}
+
+	if err := f.svc.EraseAccount(ctx, EraseAccountReq{UserID: ada.ID}); !errors.Is(err, ErrAlreadyErased) {
+		t.Fatalf("second erasure = %v, want ErrAlreadyErased", err)
+	}
+}
+
+func TestExportDataContainsOnlyTheCallersData(t *testing.T) {
+	f := newFixture(t)
+	ada, sessionID := f.customer(t, "ada@example.com")
+	grace, _ := f.customer(t, "grace@example.com")
+
+	export, err := f.svc.ExportData(context.Background(), ExportDataReq{UserID: ada.ID})
+	if err != nil {
+		t.Fatalf("ExportData: %v", err)
+	}
+	if export.Profile.ID != ada.ID || export.Profile.Email != ada.Email {
+		t.Fatalf("profile = %+v, want ada", export.Profile)
+	}
+	if len(export.Addresses) != 1 || len(export.Orders) != 1 || len(export.Orders[0].Items) != 1 ||
+		len(export.Sessions) != 1 || len(export.Identities) != 1 {
+		t.Fatalf("got %d addresses, %d orders, %d sessions and %d identities, want one of each",
+			len(export.Addresses), len(export.Orders), len(export.Sessions), len(export.Identities))
+	}
+	if export.Sessions[0].ID != sessionID {
+		t.Fatalf("exported session %s, want %s", export.Sessions[0].ID, sessionID)
+	}
+	for _, a := range export.Addresses {
+		if a.UserID != ada.ID {
+			t.Errorf("address %s belongs to another user", a.ID)
+		}
+	}
+	for _, o := range export.Orders {
+		if o.Order.UserId != ada.ID {
+			t.Errorf("order %s belongs to another user", o.Order.ID)
+		}
+	}
+	for _, i := range export.Identities {
+		if i.UserID != ada.ID || i.Subject == "sub-"+grace.Email {
+			t.Errorf("identity %s belongs to another user", i.ID)
+		}
+	}
+
+	if _, err := f.svc.ExportData(context.Background(), ExportDataReq{UserID: uuid.New()}); err == nil {
+		t.Fatal("export of an unknown user succeeded")
+	}
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/frostnzx/go-ecommerce-api/internal/ports"
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
 )
 
 type API interface {
@@ -14,8 +15,13 @@ type API interface {
 	ListProducts(context.Context) (*ListProductsResp, error)
 	EditProduct(context.Context, EditProductReq) (*EditProductResp, error)
 	DeleteProduct(context.Context, DeleteProductReq) error
+	GetProductBySKU(context.Context, GetProductBySKUReq) (*GetProductResp, error)
+	AdjustStock(context.Context, AdjustStockReq) (*AdjustStockResp, error)
+	ImportProducts(context.Context, ImportProductsReq) (*ImportProductsResp, error)
 }
 
+var tracer = otel.Tracer("github.com/frostnzx/go-ecommerce-api/internal/core/services/product")
+
 type Service struct {
 	productRepo ports.ProductRepo
 }
@@ -29,11 +35,11 @@ func NewService(pr ports.ProductRepo) *Service {
 // Request/Response types
 
 type AddProductReq struct {
-	SKU         string  `json:"sku"`
-	Name        string  `json:"name"`
-	Description string  `json:"description"`
-	Price       float64 `json:"price"`
-	StockQty    int     `json:"stock_qty"`
+	SKU         string  `json:"sku" validate:"notblank,max=64"`
+	Name        string  `json:"name" validate:"notblank,max=200"`
+	Description string  `json:"description" validate:"max=2000"`
+	Price       float64 `json:"price" validate:"gt=0"`
+	StockQty    int     `json:"stock_qty" validate:"min=0"`
 }
 
 type AddProductResp struct {
@@ -73,12 +79,12 @@ type ListProductsResp struct {
 }
 
 type EditProductReq struct {
-	ID          uuid.UUID `json:"id"`
-	SKU         string    `json:"sku"`
-	Name        string    `json:"name"`
-	Description string    `json:"description"`
-	Price       float64   `json:"price"`
-	StockQty    int       `json:"stock_qty"`
+	ID          uuid.UUID `json:"id" validate:"required"`
+	SKU         string    `json:"sku" validate:"notblank,max=64"`
+	Name        string    `json:"name" validate:"notblank,max=200"`
+	Description string    `json:"description" validate:"max=2000"`
+	Price       float64   `json:"price" validate:"gt=0"`
+	StockQty    int       `json:"stock_qty" validate:"min=0"`
 	Active      bool      `json:"active"`
 }
 
@@ -95,3 +101,27 @@ type EditProductResp struct {
 type DeleteProductReq struct {
 	ID uuid.UUID `json:"id"`
 }
+
+type GetProductBySKUReq struct {
+	SKU string `json:"sku"`
+}
+
+type AdjustStockReq struct {
+	ID    uuid.UUID `json:"id" validate:"required"`
+	Delta int       `json:"delta"` // Negative to remove stock
+}
+
+type AdjustStockResp struct {
+	ID       uuid.UUID `json:"id"`
+	SKU      string    `json:"sku"`
+	StockQty int       `json:"stock_qty"`
+}
+
+type ImportProductsReq struct {
+	Products []AddProductReq `json:"products" validate:"required,min=1,max=10000,dive"`
+}
+
+type ImportProductsResp struct {
+	Created int `json:"created"`
+	Updated int `json:"updated"`
+}
@@ -5,24 +5,19 @@ import (
 	"errors"
 
 	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/product"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/validation"
+	"github.com/frostnzx/go-ecommerce-api/internal/ports"
 )
 
 var (
-	ErrInvalidSKU   = errors.New("SKU is required")
-	ErrInvalidName  = errors.New("name is required")
-	ErrInvalidPrice = errors.New("price must be greater than 0")
+	ErrSKUTaken = errors.New("sku is already used by another product")
 )
 
 func (s *Service) AddProduct(ctx context.Context, req AddProductReq) (*AddProductResp, error) {
-	// Validate input
-	if req.SKU == "" {
-		return nil, ErrInvalidSKU
-	}
-	if req.Name == "" {
-		return nil, ErrInvalidName
-	}
-	if req.Price <= 0 {
-		return nil, ErrInvalidPrice
+	ctx, span := tracer.Start(ctx, "product.AddProduct")
+	defer span.End()
+	if err := validation.Struct(req); err != nil {
+		return nil, err
 	}
 
 	// Create product
@@ -30,6 +25,9 @@ func (s *Service) AddProduct(ctx context.Context, req AddProductReq) (*AddProduc
 
 	created, err := s.productRepo.Create(ctx, newProduct)
 	if err != nil {
+		if errors.Is(err, ports.ErrConflict) {
+			return nil, ErrSKUTaken
+		}
 		return nil, err
 	}
 
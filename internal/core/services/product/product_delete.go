@@ -2,14 +2,29 @@ package product
 
 import (
 	"context"
+	"errors"
+
+	"github.com/frostnzx/go-ecommerce-api/internal/ports"
+)
+
+var (
+	ErrProductInUse = errors.New("product has been ordered and cannot be deleted")
 )
 
 func (s *Service) DeleteProduct(ctx context.Context, req DeleteProductReq) error {
+	ctx, span := tracer.Start(ctx, "product.DeleteProduct")
+	defer span.End()
 	// Check if product exists
-	_, err := s.productRepo.GetByID(ctx, req.ID)
-	if err != nil {
-		return ErrProductNotFound
+	if _, err := s.getProduct(ctx, req.ID); err != nil {
+		return err
 	}
 
-	return s.productRepo.DeleteById(ctx, req.ID)
+	// Order items keep referencing the product they were sold as
+	if err := s.productRepo.DeleteById(ctx, req.ID); err != nil {
+		if errors.Is(err, ports.ErrReference) {
+			return ErrProductInUse
+		}
+		return err
+	}
+	return nil
 }
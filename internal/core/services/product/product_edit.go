@@ -2,26 +2,24 @@ package product
 
 import (
 	"context"
+	"errors"
 
 	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/product"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/validation"
+	"github.com/frostnzx/go-ecommerce-api/internal/ports"
 )
 
 func (s *Service) EditProduct(ctx context.Context, req EditProductReq) (*EditProductResp, error) {
-	// Validate input
-	if req.SKU == "" {
-		return nil, ErrInvalidSKU
-	}
-	if req.Name == "" {
-		return nil, ErrInvalidName
-	}
-	if req.Price <= 0 {
-		return nil, ErrInvalidPrice
+	ctx, span := tracer.Start(ctx, "product.EditProduct")
+	defer span.End()
+	if err := validation.Struct(req); err != nil {
+		return nil, err
 	}
 
 	// Check if product exists
-	existing, err := s.productRepo.GetByID(ctx, req.ID)
+	existing, err := s.getProduct(ctx, req.ID)
 	if err != nil {
-		return nil, ErrProductNotFound
+		return nil, err
 	}
 
 	// Update product
@@ -38,6 +36,12 @@ func (s *Service) EditProduct(ctx context.Context, req EditProductReq) (*EditPro
 
 	result, err := s.productRepo.UpdateById(ctx, updated)
 	if err != nil {
+		switch {
+		case errors.Is(err, ports.ErrConflict):
+			return nil, ErrSKUTaken
+		case errors.Is(err, ports.ErrNotFound):
+			return nil, ErrProductNotFound
+		}
 		return nil, err
 	}
 
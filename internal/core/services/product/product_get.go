@@ -3,6 +3,11 @@ package product
 import (
 	"context"
 	"errors"
+	"fmt"
+
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/product"
+	"github.com/frostnzx/go-ecommerce-api/internal/ports"
+	"github.com/google/uuid"
 )
 
 var (
@@ -10,9 +15,34 @@ var (
 )
 
 func (s *Service) GetProduct(ctx context.Context, req GetProductReq) (*GetProductResp, error) {
-	p, err := s.productRepo.GetByID(ctx, req.ID)
+	ctx, span := tracer.Start(ctx, "product.GetProduct")
+	defer span.End()
+	p, err := s.getProduct(ctx, req.ID)
 	if err != nil {
-		return nil, ErrProductNotFound
+		return nil, err
+	}
+
+	return &GetProductResp{
+		ID:          p.ID,
+		SKU:         p.SKU,
+		Name:        p.Name,
+		Description: p.Description,
+		Price:       p.Price,
+		StockQty:    p.StockQty,
+		Active:      p.Active,
+		CreatedAt:   p.CreatedAt,
+	}, nil
+}
+
+func (s *Service) GetProductBySKU(ctx context.Context, req GetProductBySKUReq) (*GetProductResp, error) {
+	ctx, span := tracer.Start(ctx, "product.GetProductBySKU")
+	defer span.End()
+	p, err := s.productRepo.GetBySKU(ctx, req.SKU)
+	if err != nil {
+		if errors.Is(err, ports.ErrNotFound) {
+			return nil, ErrProductNotFound
+		}
+		return nil, fmt.Errorf("error getting product: %w", err)
 	}
 
 	return &GetProductResp{
@@ -28,6 +58,8 @@ func (s *Service) GetProduct(ctx context.Context, req GetProductReq) (*GetProduc
 }
 
 func (s *Service) ListProducts(ctx context.Context) (*ListProductsResp, error) {
+	ctx, span := tracer.Start(ctx, "product.ListProducts")
+	defer span.End()
 	products, err := s.productRepo.List(ctx)
 	if err != nil {
 		return nil, err
@@ -50,3 +82,15 @@ func (s *Service) ListProducts(ctx context.Context) (*ListProductsResp, error) {
 		Products: productInfos,
 	}, nil
 }
+
+// getProduct loads a product, reporting ErrProductNotFound when it does not exist
+func (s *Service) getProduct(ctx context.Context, id uuid.UUID) (product.Product, error) {
+	p, err := s.productRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, ports.ErrNotFound) {
+			return product.Product{}, ErrProductNotFound
+		}
+		return product.Product{}, fmt.Errorf("error getting product: %w", err)
+	}
+	return p, nil
+}
@@ -0,0 +1,45 @@
+package product
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/product"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/validation"
+)
+
+var (
+	ErrDuplicateSKU = errors.New("duplicate sku in import")
+)
+
+// ImportProducts loads a catalog in one go: products are matched on SKU, new ones are created
+// and existing ones take the imported name, description, price and stock. Nothing is written
+// unless every entry is valid.
+func (s *Service) ImportProducts(ctx context.Context, req ImportProductsReq) (*ImportProductsResp, error) {
+	ctx, span := tracer.Start(ctx, "product.ImportProducts")
+	defer span.End()
+	if err := validation.Struct(req); err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool, len(req.Products))
+	products := make([]product.Product, 0, len(req.Products))
+	for _, p := range req.Products {
+		if seen[p.SKU] {
+			return nil, fmt.Errorf("%w: %s", ErrDuplicateSKU, p.SKU)
+		}
+		seen[p.SKU] = true
+		products = append(products, product.New(p.SKU, p.Name, p.Description, p.Price, p.StockQty))
+	}
+
+	created, err := s.productRepo.UpsertBySKU(ctx, products)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ImportProductsResp{
+		Created: created,
+		Updated: len(products) - created,
+	}, nil
+}
@@ -0,0 +1,43 @@
+package product
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/frostnzx/go-ecommerce-api/internal/core/validation"
+	"github.com/frostnzx/go-ecommerce-api/internal/ports"
+)
+
+var (
+	ErrNegativeStock = errors.New("stock cannot go below zero")
+)
+
+// AdjustStock adds Delta to the stock in a single update, so concurrent adjustments do not
+// overwrite each other
+func (s *Service) AdjustStock(ctx context.Context, req AdjustStockReq) (*AdjustStockResp, error) {
+	ctx, span := tracer.Start(ctx, "product.AdjustStock")
+	defer span.End()
+	if err := validation.Struct(req); err != nil {
+		return nil, err
+	}
+
+	p, err := s.getProduct(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	quantity, err := s.productRepo.AdjustStock(ctx, p.ID, req.Delta)
+	if err != nil {
+		if errors.Is(err, ports.ErrNotFound) {
+			return nil, ErrNegativeStock
+		}
+		return nil, fmt.Errorf("error adjusting stock: %w", err)
+	}
+
+	return &AdjustStockResp{
+		ID:       p.ID,
+		SKU:      p.SKU,
+		StockQty: quantity,
+	}, nil
+}
@@ -5,6 +5,8 @@ import (
 
 	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/session"
 	"github.com/frostnzx/go-ecommerce-api/internal/ports"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
 )
 
 type API interface {
@@ -12,17 +14,33 @@ type API interface {
 	GetSession(ctx context.Context, id string) (*session.Session, error)
 	RevokeSession(ctx context.Context, id string) error
 	DeleteSession(ctx context.Context, id string) error
+	DeleteUserSessions(ctx context.Context, email string) error
+	TouchSession(ctx context.Context, id string) error
+	PurgeExpiredSessions(ctx context.Context) (int64, error)
+
+	ListUserSessions(ctx context.Context, userID uuid.UUID) ([]session.Session, error)
+	RevokeUserSession(ctx context.Context, userID uuid.UUID, sessionID string) error
+	RevokeUserSessions(ctx context.Context, userID uuid.UUID, exceptSessionID string) (int, error)
+
+	CreateRefreshToken(ctx context.Context, t *session.RefreshToken) error
+	GetRefreshToken(ctx context.Context, tokenHash string) (*session.RefreshToken, error)
+	RotateRefreshToken(ctx context.Context, consumedID string, next *session.RefreshToken) error
+
+	ValidateSession(ctx context.Context, id string) error
+	InvalidateSession(id string)
+	InvalidateUserSessions(userID uuid.UUID)
 }
 
+var tracer = otel.Tracer("github.com/frostnzx/go-ecommerce-api/internal/core/services/session")
+
 type Service struct {
 	sessionRepo ports.SessionRepo
+	cache       *stateCache
 }
 
 func NewService(sr ports.SessionRepo) *Service {
 	return &Service{
 		sessionRepo: sr,
+		cache:       newStateCache(defaultCacheTTL),
 	}
 }
-
-
-
@@ -0,0 +1,112 @@
+package session
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// defaultCacheTTL bounds how long a cached session state is trusted before
+// it is re-read from the repository. In-process revocations invalidate the
+// entry immediately; the TTL only matters for changes made elsewhere.
+const defaultCacheTTL = 30 * time.Second
+
+// maxCacheEntries is the number of entries above which expired entries are
+// dropped on insert. If every entry is still live, arbitrary ones are evicted
+// instead, so the cache never grows without bound; a miss only costs a read.
+const maxCacheEntries = 10000
+
+type cacheEntry struct {
+	active    bool
+	userID    uuid.UUID
+	expiresAt time.Time
+}
+
+// stateCache is a small TTL cache of session liveness keyed by session ID
+type stateCache struct {
+	mu         sync.RWMutex
+	ttl        time.Duration
+	maxEntries int
+	entries    map[string]cacheEntry
+	now        func() time.Time
+	// gen is bumped by every invalidation, so a lookup that read the repository
+	// before a revocation cannot write its stale "active" result back afterwards
+	gen uint64
+}
+
+func newStateCache(ttl time.Duration) *stateCache {
+	return &stateCache{
+		ttl:        ttl,
+		maxEntries: maxCacheEntries,
+		entries:    make(map[string]cacheEntry),
+		now:        time.Now,
+	}
+}
+
+func (c *stateCache) get(id string) (cacheEntry, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	e, ok := c.entries[id]
+	if !ok || c.now().After(e.expiresAt) {
+		return cacheEntry{}, false
+	}
+	return e, true
+}
+
+// generation is taken before reading the repository and passed to set
+func (c *stateCache) generation() uint64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.gen
+}
+
+// set caches the state read at generation gen, unless an invalidation has happened since
+func (c *stateCache) set(id string, userID uuid.UUID, active bool, gen uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if gen != c.gen {
+		return
+	}
+	now := c.now()
+	if _, ok := c.entries[id]; !ok && len(c.entries) >= c.maxEntries {
+		for k, e := range c.entries {
+			if now.After(e.expiresAt) {
+				delete(c.entries, k)
+			}
+		}
+		for k := range c.entries {
+			if len(c.entries) < c.maxEntries {
+				break
+			}
+			delete(c.entries, k)
+		}
+	}
+	c.entries[id] = cacheEntry{active: active, userID: userID, expiresAt: now.Add(c.ttl)}
+}
+
+func (c *stateCache) invalidate(id string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, id)
+	c.gen++
+}
+
+// invalidateUser is keyed by user ID rather than email, which changes and is erased
+func (c *stateCache) invalidateUser(userID uuid.UUID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for k, e := range c.entries {
+		if e.userID == userID {
+			delete(c.entries, k)
+		}
+	}
+	c.gen++
+}
+
+func (c *stateCache) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	clear(c.entries)
+	c.gen++
+}
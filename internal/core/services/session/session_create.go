@@ -7,5 +7,7 @@ import (
 )
 
 func (s *Service) CreateSession(ctx context.Context, sess *session.Session) (*session.Session, error) {
+	ctx, span := tracer.Start(ctx, "session.CreateSession")
+	defer span.End()
 	return s.sessionRepo.CreateSession(ctx, sess)
 }
@@ -2,8 +2,34 @@ package session
 
 import (
 	"context"
+	"time"
 )
 
 func (s *Service) DeleteSession(ctx context.Context, id string) error {
-	return s.sessionRepo.DeleteSession(ctx, id)
+	ctx, span := tracer.Start(ctx, "session.DeleteSession")
+	defer span.End()
+	if err := s.sessionRepo.DeleteSession(ctx, id); err != nil {
+		return err
+	}
+	s.InvalidateSession(id)
+	return nil
+}
+
+func (s *Service) DeleteUserSessions(ctx context.Context, email string) error {
+	ctx, span := tracer.Start(ctx, "session.DeleteUserSessions")
+	defer span.End()
+	if err := s.sessionRepo.DeleteSessionsByEmail(ctx, email); err != nil {
+		return err
+	}
+	// The cache does not track emails, which can change, so drop every entry
+	s.cache.clear()
+	return nil
+}
+
+// PurgeExpiredSessions deletes sessions whose refresh tokens can no longer be
+// used. Expired sessions already fail validation, so no cache entry needs dropping.
+func (s *Service) PurgeExpiredSessions(ctx context.Context) (int64, error) {
+	ctx, span := tracer.Start(ctx, "session.PurgeExpiredSessions")
+	defer span.End()
+	return s.sessionRepo.DeleteExpiredSessions(ctx, time.Now().UTC())
 }
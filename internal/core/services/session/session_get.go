@@ -7,5 +7,7 @@ import (
 )
 
 func (s *Service) GetSession(ctx context.Context, id string) (*session.Session, error) {
+	ctx, span := tracer.Start(ctx, "session.GetSession")
+	defer span.End()
 	return s.sessionRepo.GetSession(ctx, id)
 }
@@ -0,0 +1,25 @@
+package session
+
+import (
+	"context"
+
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/session"
+)
+
+func (s *Service) CreateRefreshToken(ctx context.Context, t *session.RefreshToken) error {
+	ctx, span := tracer.Start(ctx, "session.CreateRefreshToken")
+	defer span.End()
+	return s.sessionRepo.CreateRefreshToken(ctx, t)
+}
+
+func (s *Service) GetRefreshToken(ctx context.Context, tokenHash string) (*session.RefreshToken, error) {
+	ctx, span := tracer.Start(ctx, "session.GetRefreshToken")
+	defer span.End()
+	return s.sessionRepo.GetRefreshToken(ctx, tokenHash)
+}
+
+func (s *Service) RotateRefreshToken(ctx context.Context, consumedID string, next *session.RefreshToken) error {
+	ctx, span := tracer.Start(ctx, "session.RotateRefreshToken")
+	defer span.End()
+	return s.sessionRepo.RotateRefreshToken(ctx, consumedID, next)
+}
@@ -5,5 +5,11 @@ import (
 )
 
 func (s *Service) RevokeSession(ctx context.Context, id string) error {
-	return s.sessionRepo.RevokeSession(ctx, id)
+	ctx, span := tracer.Start(ctx, "session.RevokeSession")
+	defer span.End()
+	if err := s.sessionRepo.RevokeSession(ctx, id); err != nil {
+		return err
+	}
+	s.InvalidateSession(id)
+	return nil
 }
@@ -0,0 +1,196 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/frostnzx/go-ecommerce-api/internal/adapters/secondary/memory"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/session"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/user"
+	"github.com/frostnzx/go-ecommerce-api/internal/ports"
+	"github.com/google/uuid"
+)
+
+// fakeClock is a stateCache clock moved by hand
+type fakeClock struct{ t time.Time }
+
+func (c *fakeClock) now() time.Time          { return c.t }
+func (c *fakeClock) advance(d time.Duration) { c.t = c.t.Add(d) }
+
+type fixture struct {
+	svc   *Service
+	repo  ports.SessionRepo
+	clock *fakeClock
+	user  user.User
+}
+
+func newFixture(t *testing.T) fixture {
+	t.Helper()
+	s := memory.NewStore()
+	users, _ := memory.NewUserRepo(s)
+	repo, _ := memory.NewSessionRepo(s)
+	u := user.New("ada@example.com", "hash", "Ada", false)
+	if err := users.Create(context.Background(), u); err != nil {
+		t.Fatal(err)
+	}
+
+	clock := &fakeClock{t: time.Now()}
+	svc := NewService(repo)
+	svc.cache.now = clock.now
+	return fixture{svc: svc, repo: repo, clock: clock, user: u}
+}
+
+func (f fixture) createSession(t *testing.T, exp time.Time) string {
+	t.Helper()
+	s := session.New(uuid.NewString(), f.user.ID, f.user.Email, false, exp)
+	if _, err := f.repo.CreateSession(context.Background(), &s); err != nil {
+		t.Fatal(err)
+	}
+	return s.ID
+}
+
+func (f fixture) mustBeActive(t *testing.T, id string) {
+	t.Helper()
+	if err := f.svc.ValidateSession(context.Background(), id); err != nil {
+		t.Fatalf("ValidateSession = %v, want the session active", err)
+	}
+}
+
+func (f fixture) mustBeRejected(t *testing.T, id string) {
+	t.Helper()
+	if err := f.svc.ValidateSession(context.Background(), id); !errors.Is(err, ErrSessionNotActive) {
+		t.Fatalf("ValidateSession = %v, want ErrSessionNotActive", err)
+	}
+}
+
+func TestValidateSessionRechecksRevocationAfterTTL(t *testing.T) {
+	f := newFixture(t)
+	id := f.createSession(t, time.Now().Add(time.Hour))
+	f.mustBeActive(t, id)
+
+	// Revoked behind the service's back, e.g. by another instance
+	if err := f.repo.RevokeSession(context.Background(), id); err != nil {
+		t.Fatal(err)
+	}
+	f.mustBeActive(t, id)
+	f.clock.advance(defaultCacheTTL + time.Second)
+	f.mustBeRejected(t, id)
+}
+
+func TestRevokingSessionsInvalidatesTheCache(t *testing.T) {
+	ctx := context.Background()
+	f := newFixture(t)
+	one := f.createSession(t, time.Now().Add(time.Hour))
+	current := f.createSession(t, time.Now().Add(time.Hour))
+	other := f.createSession(t, time.Now().Add(time.Hour))
+	for _, id := range []string{one, current, other} {
+		f.mustBeActive(t, id)
+	}
+
+	if err := f.svc.RevokeUserSession(ctx, f.user.ID, one); err != nil {
+		t.Fatal(err)
+	}
+	f.mustBeRejected(t, one)
+
+	// Logging out everywhere else keeps the current session
+	if n, err := f.svc.RevokeUserSessions(ctx, f.user.ID, current); err != nil || n != 1 {
+		t.Fatalf("RevokeUserSessions = %d, %v, want 1 revoked", n, err)
+	}
+	f.mustBeRejected(t, other)
+	f.mustBeActive(t, current)
+
+	if err := f.repo.RevokeSession(ctx, current); err != nil {
+		t.Fatal(err)
+	}
+	f.svc.InvalidateUserSessions(f.user.ID)
+	f.mustBeRejected(t, current)
+}
+
+func TestInvalidateUserSessionsIgnoresTheSessionEmail(t *testing.T) {
+	f := newFixture(t)
+	// Sessions keep the email they were created with, which the user may have changed or erased since
+	s := session.New(uuid.NewString(), f.user.ID, "old@example.com", false, time.Now().Add(time.Hour))
+	if _, err := f.repo.CreateSession(context.Background(), &s); err != nil {
+		t.Fatal(err)
+	}
+	f.mustBeActive(t, s.ID)
+
+	if err := f.repo.RevokeSession(context.Background(), s.ID); err != nil {
+		t.Fatal(err)
+	}
+	f.svc.InvalidateUserSessions(f.user.ID)
+	f.mustBeRejected(t, s.ID)
+}
+
+// pausingRepo holds GetSession after the read until release is closed
+type pausingRepo struct {
+	ports.SessionRepo
+	read    chan struct{}
+	release chan struct{}
+}
+
+func (r *pausingRepo) GetSession(ctx context.Context, id string) (*session.Session, error) {
+	s, err := r.SessionRepo.GetSession(ctx, id)
+	close(r.read)
+	<-r.release
+	return s, err
+}
+
+func TestRevokeDuringValidationIsNotUndone(t *testing.T) {
+	f := newFixture(t)
+	id := f.createSession(t, time.Now().Add(time.Hour))
+	repo := &pausingRepo{SessionRepo: f.repo, read: make(chan struct{}), release: make(chan struct{})}
+	f.svc.sessionRepo = repo
+
+	validated := make(chan error)
+	go func() { validated <- f.svc.ValidateSession(context.Background(), id) }()
+	<-repo.read
+	// The lookup has seen the session active; revoke it before the result is cached
+	if err := f.svc.RevokeSession(context.Background(), id); err != nil {
+		t.Fatal(err)
+	}
+	close(repo.release)
+	if err := <-validated; err != nil {
+		t.Fatalf("in-flight ValidateSession = %v, want the state it read", err)
+	}
+
+	f.svc.sessionRepo = f.repo
+	f.mustBeRejected(t, id)
+}
+
+func TestValidateSessionRejectsExpiredAndUnknownSessions(t *testing.T) {
+	f := newFixture(t)
+	f.mustBeRejected(t, f.createSession(t, time.Now().Add(-time.Minute)))
+	f.mustBeRejected(t, uuid.NewString())
+}
+
+func TestStateCacheStaysBounded(t *testing.T) {
+	clock := &fakeClock{t: time.Now()}
+	c := newStateCache(time.Minute)
+	c.now = clock.now
+	c.maxEntries = 3
+	owner := uuid.New()
+
+	for i := range 3 {
+		c.set("stale-"+strconv.Itoa(i), owner, true, c.generation())
+	}
+	clock.advance(2 * time.Minute)
+	c.set("fresh", owner, true, c.generation())
+	if len(c.entries) != 1 {
+		t.Fatalf("got %d entries, want the expired ones swept", len(c.entries))
+	}
+
+	// Live entries are evicted once the cache is full
+	for i := range 10 {
+		c.set("live-"+strconv.Itoa(i), owner, true, c.generation())
+		if len(c.entries) > c.maxEntries {
+			t.Fatalf("got %d entries, want at most %d", len(c.entries), c.maxEntries)
+		}
+	}
+	if _, ok := c.get("live-9"); !ok {
+		t.Fatal("newest entry was evicted")
+	}
+}
@@ -0,0 +1,61 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/session"
+	"github.com/frostnzx/go-ecommerce-api/internal/ports"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrSessionNotFound = errors.New("session not found")
+)
+
+func (s *Service) TouchSession(ctx context.Context, id string) error {
+	ctx, span := tracer.Start(ctx, "session.TouchSession")
+	defer span.End()
+	return s.sessionRepo.TouchSession(ctx, id)
+}
+
+// ListUserSessions returns the user's active sessions, most recently used first
+func (s *Service) ListUserSessions(ctx context.Context, userID uuid.UUID) ([]session.Session, error) {
+	ctx, span := tracer.Start(ctx, "session.ListUserSessions")
+	defer span.End()
+	return s.sessionRepo.ListSessionsByUserID(ctx, userID)
+}
+
+// RevokeUserSession revokes one of the user's sessions, refusing sessions owned by someone else
+func (s *Service) RevokeUserSession(ctx context.Context, userID uuid.UUID, sessionID string) error {
+	ctx, span := tracer.Start(ctx, "session.RevokeUserSession")
+	defer span.End()
+	sess, err := s.sessionRepo.GetSession(ctx, sessionID)
+	if err != nil {
+		if errors.Is(err, ports.ErrNotFound) {
+			return ErrSessionNotFound
+		}
+		return fmt.Errorf("error getting session: %w", err)
+	}
+	// Do not reveal whether another user's session exists
+	if sess.UserID != userID {
+		return ErrSessionNotFound
+	}
+	return s.RevokeSession(ctx, sessionID)
+}
+
+// RevokeUserSessions revokes every active session of the user except exceptSessionID
+// (empty to revoke all of them) and returns how many were revoked
+func (s *Service) RevokeUserSessions(ctx context.Context, userID uuid.UUID, exceptSessionID string) (int, error) {
+	ctx, span := tracer.Start(ctx, "session.RevokeUserSessions")
+	defer span.End()
+	ids, err := s.sessionRepo.RevokeSessionsByUserID(ctx, userID, exceptSessionID)
+	if err != nil {
+		return 0, fmt.Errorf("error revoking sessions: %w", err)
+	}
+	for _, id := range ids {
+		s.InvalidateSession(id)
+	}
+	return len(ids), nil
+}
@@ -0,0 +1,55 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/frostnzx/go-ecommerce-api/internal/ports"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrSessionNotActive = errors.New("session is no longer active")
+)
+
+// ValidateSession reports whether the session behind an access token is still usable.
+// Results are cached for a short TTL so the auth middleware does not hit the database on every request.
+func (s *Service) ValidateSession(ctx context.Context, id string) error {
+	ctx, span := tracer.Start(ctx, "session.ValidateSession")
+	defer span.End()
+	if e, ok := s.cache.get(id); ok {
+		if !e.active {
+			return ErrSessionNotActive
+		}
+		return nil
+	}
+
+	gen := s.cache.generation()
+	sess, err := s.sessionRepo.GetSession(ctx, id)
+	if err != nil {
+		if errors.Is(err, ports.ErrNotFound) {
+			s.cache.set(id, uuid.Nil, false, gen)
+			return ErrSessionNotActive
+		}
+		return fmt.Errorf("error validating session: %w", err)
+	}
+
+	active := !sess.IsRevoked && time.Now().Before(sess.ExpiresAt)
+	s.cache.set(id, sess.UserID, active, gen)
+	if !active {
+		return ErrSessionNotActive
+	}
+	return nil
+}
+
+// InvalidateSession drops any cached state for the session so the next check reads it again
+func (s *Service) InvalidateSession(id string) {
+	s.cache.invalidate(id)
+}
+
+// InvalidateUserSessions drops cached state for every session belonging to the user
+func (s *Service) InvalidateUserSessions(userID uuid.UUID) {
+	s.cache.invalidateUser(userID)
+}
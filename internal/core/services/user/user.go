@@ -2,9 +2,13 @@ package user
 
 import (
 	"context"
+	"time"
 
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/lockout"
 	"github.com/frostnzx/go-ecommerce-api/internal/core/services/session"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/utils"
 	"github.com/frostnzx/go-ecommerce-api/internal/ports"
+	"go.opentelemetry.io/otel"
 )
 
 type API interface {
@@ -12,21 +16,73 @@ type API interface {
 	GetUserProfile(context.Context, GetUserProfileReq) (*GetUserProfileResp, error)
 	UpdateUserProfile(context.Context, UpdateUserProfileReq) error
 	ChangePassword(context.Context, ChangePasswordProfileReq) error
-	DeleteAccount(context.Context, DeleteAccountReq) error
 	LoginUser(context.Context, LoginUserReq) (*LoginUserResp, error)
 	LogoutUser(context.Context, LogoutUserReq) error
 	RenewAccessToken(context.Context, RenewAccessTokenReq) (*RenewAccessTokenResp, error)
-	ListUsers(context.Context) (*ListUsersResp, error) // Admin only
+	StartOIDCLogin(context.Context, StartOIDCLoginReq) (*StartOIDCLoginResp, error)
+	CompleteOIDCLogin(context.Context, CompleteOIDCLoginReq) (*LoginUserResp, error)
+
+	// Admin only
+	ListUsers(context.Context, ListUsersReq) (*ListUsersResp, error)
+	GetUserByEmail(context.Context, GetUserByEmailReq) (*GetUserProfileResp, error)
+	UnlockUser(context.Context, UnlockUserReq) error
+	SuspendUser(context.Context, SuspendUserReq) error
+	ReactivateUser(context.Context, ReactivateUserReq) error
+	ResetUserPassword(context.Context, ResetUserPasswordReq) (*ResetUserPasswordResp, error)
+	ImpersonateUser(context.Context, ImpersonateUserReq) (*ImpersonateUserResp, error)
+	RecordImpersonatedRequest(context.Context, RecordImpersonatedRequestReq) error
+	ListAuditLog(context.Context, ListAuditLogReq) (*ListAuditLogResp, error)
 }
 
+var tracer = otel.Tracer("github.com/frostnzx/go-ecommerce-api/internal/core/services/user")
+
 type Service struct {
-	userRepo       ports.UserRepo
-	sessionService session.API
+	userRepo         ports.UserRepo
+	sessionService   session.API
+	tokenMaker       *utils.JWTMaker
+	loginAttemptRepo ports.LoginAttemptRepo
+	lockoutPolicy    lockout.Policy
+	auditRepo        ports.AuditRepo
+	tokenLifetimes   TokenLifetimes
+	metrics          ports.BusinessMetrics
+
+	// Social login; the OIDC endpoints report ErrOIDCLoginNotAvailable until EnableOIDC is called
+	identityRepo      ports.IdentityRepo
+	oidcStateRepo     ports.OIDCStateRepo
+	identityProviders []ports.IdentityProvider
+}
+
+// TokenLifetimes controls how long issued tokens stay valid
+type TokenLifetimes struct {
+	Access        time.Duration
+	Refresh       time.Duration // Also the lifetime of the session
+	Impersonation time.Duration // Impersonation tokens have no refresh token and cannot be renewed
 }
 
-func NewService(ur ports.UserRepo, ss session.API) *Service {
+func DefaultTokenLifetimes() TokenLifetimes {
+	return TokenLifetimes{
+		Access:        15 * time.Minute,
+		Refresh:       7 * 24 * time.Hour,
+		Impersonation: 15 * time.Minute,
+	}
+}
+
+func NewService(ur ports.UserRepo, ss session.API, tm *utils.JWTMaker, lr ports.LoginAttemptRepo, lp lockout.Policy, ar ports.AuditRepo, tl TokenLifetimes, bm ports.BusinessMetrics) *Service {
 	return &Service{
-		userRepo:       ur,
-		sessionService: ss,
+		userRepo:         ur,
+		sessionService:   ss,
+		tokenMaker:       tm,
+		loginAttemptRepo: lr,
+		lockoutPolicy:    lp,
+		auditRepo:        ar,
+		tokenLifetimes:   tl,
+		metrics:          bm,
 	}
 }
+
+// EnableOIDC turns on login through the given OpenID Connect providers
+func (s *Service) EnableOIDC(ir ports.IdentityRepo, sr ports.OIDCStateRepo, providers ...ports.IdentityProvider) {
+	s.identityRepo = ir
+	s.oidcStateRepo = sr
+	s.identityProviders = providers
+}
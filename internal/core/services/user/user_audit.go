@@ -0,0 +1,31 @@
+package user
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/audit"
+	"github.com/google/uuid"
+)
+
+type ListAuditLogReq struct {
+	UserID uuid.UUID // Only entries about this user, uuid.Nil for all
+	Limit  int
+	Offset int
+}
+type ListAuditLogResp struct {
+	Entries []audit.Entry
+	Limit   int
+	Offset  int
+}
+
+func (s *Service) ListAuditLog(ctx context.Context, req ListAuditLogReq) (*ListAuditLogResp, error) {
+	ctx, span := tracer.Start(ctx, "user.ListAuditLog")
+	defer span.End()
+	limit, offset := pageBounds(req.Limit, req.Offset)
+	entries, err := s.auditRepo.List(ctx, req.UserID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("error listing audit log: %w", err)
+	}
+	return &ListAuditLogResp{Entries: entries, Limit: limit, Offset: offset}, nil
+}
@@ -2,26 +2,35 @@ package user
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
+	"github.com/frostnzx/go-ecommerce-api/internal/core/validation"
 	"github.com/google/uuid"
 	"golang.org/x/crypto/bcrypt"
 )
 
+var ErrIncorrectPassword = errors.New("current password is incorrect")
+
 type ChangePasswordProfileReq struct {
-	ID              uuid.UUID // Extracted from JWT claims in handler/middleware
-	CurrentPassword string
-	NewPassword     string
+	ID              uuid.UUID // Resolved and authorized by the handler
+	CurrentPassword string    `validate:"required"`
+	NewPassword     string    `validate:"required,password"`
 }
 
 func (s *Service) ChangePassword(ctx context.Context, req ChangePasswordProfileReq) error {
-	user, err := s.userRepo.GetUserByID(ctx, req.ID)
+	ctx, span := tracer.Start(ctx, "user.ChangePassword")
+	defer span.End()
+	if err := validation.Struct(req); err != nil {
+		return err
+	}
+	user, err := s.getUser(ctx, req.ID)
 	if err != nil {
-		return fmt.Errorf("error getting user: %w", err)
+		return err
 	}
 
 	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(req.CurrentPassword)); err != nil {
-		return fmt.Errorf("current password is incorrect")
+		return ErrIncorrectPassword
 	}
 
 	newHashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.NewPassword), bcrypt.DefaultCost)
@@ -29,8 +38,7 @@ func (s *Service) ChangePassword(ctx context.Context, req ChangePasswordProfileR
 		return fmt.Errorf("error hashing new password: %w", err)
 	}
 
-	user.PasswordHash = string(newHashedPassword)
-	err = s.userRepo.UpdateUser(ctx, *user)
+	err = s.userRepo.UpdatePasswordHash(ctx, user.ID, string(newHashedPassword))
 	if err != nil {
 		return fmt.Errorf("error updating password: %w", err)
 	}
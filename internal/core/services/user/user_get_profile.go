@@ -2,13 +2,20 @@ package user
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
+	"github.com/frostnzx/go-ecommerce-api/internal/ports"
+
 	"github.com/google/uuid"
 )
 
 type GetUserProfileReq struct {
-	ID uuid.UUID // Extracted from JWT claims in handler/middleware
+	ID uuid.UUID // Resolved and authorized by the handler
+}
+
+type GetUserByEmailReq struct {
+	Email string
 }
 
 type GetUserProfileResp struct {
@@ -19,9 +26,30 @@ type GetUserProfileResp struct {
 }
 
 func (s *Service) GetUserProfile(ctx context.Context, req GetUserProfileReq) (*GetUserProfileResp, error) {
-	user, err := s.userRepo.GetUserByID(ctx, req.ID)
+	ctx, span := tracer.Start(ctx, "user.GetUserProfile")
+	defer span.End()
+	user, err := s.getUser(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GetUserProfileResp{
+		ID:      user.ID,
+		Name:    user.Name,
+		Email:   user.Email,
+		IsAdmin: user.IsAdmin,
+	}, nil
+}
+
+func (s *Service) GetUserByEmail(ctx context.Context, req GetUserByEmailReq) (*GetUserProfileResp, error) {
+	ctx, span := tracer.Start(ctx, "user.GetUserByEmail")
+	defer span.End()
+	user, err := s.userRepo.GetUser(ctx, req.Email)
 	if err != nil {
-		return nil, fmt.Errorf("error getting user: %w", err)
+		if errors.Is(err, ports.ErrNotFound) {
+			return nil, ports.ErrUserNotFound
+		}
+		return nil, fmt.Errorf("error getting user:%w", err)
 	}
 
 	return &GetUserProfileResp{
@@ -3,36 +3,78 @@ package user
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
+	"github.com/frostnzx/go-ecommerce-api/internal/ports"
 	"github.com/google/uuid"
 )
 
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+type ListUsersReq struct {
+	Query  string // Matches email or name, empty lists everyone
+	Limit  int
+	Offset int
+}
+
 type ListUsersResp struct {
-	Users []UserInfo
+	Users  []UserInfo
+	Total  int
+	Limit  int
+	Offset int
 }
 
 type UserInfo struct {
-	ID      uuid.UUID
-	Name    string
-	Email   string
-	IsAdmin bool
+	ID          uuid.UUID
+	Name        string
+	Email       string
+	IsAdmin     bool
+	CreatedAt   time.Time
+	SuspendedAt *time.Time
 }
 
-func (s *Service) ListUsers(ctx context.Context) (*ListUsersResp, error) {
-	users, err := s.userRepo.ListUsers(ctx)
+func (s *Service) ListUsers(ctx context.Context, req ListUsersReq) (*ListUsersResp, error) {
+	ctx, span := tracer.Start(ctx, "user.ListUsers")
+	defer span.End()
+	limit, offset := pageBounds(req.Limit, req.Offset)
+	users, total, err := s.userRepo.SearchUsers(ctx, ports.UserFilter{
+		Query:  strings.TrimSpace(req.Query),
+		Limit:  limit,
+		Offset: offset,
+	})
 	if err != nil {
 		return nil, fmt.Errorf("error listing users: %w", err)
 	}
 
-	var userInfos []UserInfo
+	userInfos := make([]UserInfo, 0, len(users))
 	for _, u := range users {
 		userInfos = append(userInfos, UserInfo{
-			ID:      u.ID,
-			Name:    u.Name,
-			Email:   u.Email,
-			IsAdmin: u.IsAdmin,
+			ID:          u.ID,
+			Name:        u.Name,
+			Email:       u.Email,
+			IsAdmin:     u.IsAdmin,
+			CreatedAt:   u.CreatedAt,
+			SuspendedAt: u.SuspendedAt,
 		})
 	}
 
-	return &ListUsersResp{Users: userInfos}, nil
+	return &ListUsersResp{Users: userInfos, Total: total, Limit: limit, Offset: offset}, nil
+}
+
+// pageBounds applies the default and maximum page size
+func pageBounds(limit, offset int) (int, int) {
+	if limit <= 0 {
+		limit = defaultPageSize
+	}
+	if limit > maxPageSize {
+		limit = maxPageSize
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	return limit, offset
 }
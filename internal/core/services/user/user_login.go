@@ -2,19 +2,23 @@ package user
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"os"
 	"time"
 
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/lockout"
 	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/session"
-	"github.com/frostnzx/go-ecommerce-api/internal/core/utils"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/user"
+	"github.com/frostnzx/go-ecommerce-api/internal/ports"
 	"github.com/google/uuid"
 	"golang.org/x/crypto/bcrypt"
 )
 
 type LoginUserReq struct {
-	Email    string
-	Password string
+	Email     string
+	Password  string
+	UserAgent string // Recorded on the session so users can recognise their devices
+	IPAddress string
 }
 type LoginUserResp struct {
 	SessionID             string
@@ -28,25 +32,70 @@ type LoginUserResp struct {
 }
 
 func (s *Service) LoginUser(ctx context.Context, req LoginUserReq) (*LoginUserResp, error) {
+	ctx, span := tracer.Start(ctx, "user.LoginUser")
+	defer span.End()
+	now := time.Now().UTC()
+	// The client IP is only checked once the password has failed, so a locked
+	// IP shared with an attacker never stops a user who knows their password
+	if err := s.checkLoginThrottle(ctx, accountSubject(req), now); err != nil {
+		if errors.As(err, new(*LoginThrottledError)) {
+			s.metrics.LoginFailed("throttled")
+		}
+		return nil, err
+	}
+
+	// Unknown emails and wrong passwords fail the same way so the response does not reveal which accounts exist
 	user, err := s.userRepo.GetUser(ctx, req.Email)
 	if err != nil {
-		return nil, fmt.Errorf("Error getting user:%w", err)
+		if !errors.Is(err, ports.ErrNotFound) {
+			return nil, fmt.Errorf("Error getting user:%w", err)
+		}
+		bcrypt.CompareHashAndPassword([]byte(dummyPasswordHash), []byte(req.Password))
+		return nil, s.failLogin(ctx, req, now)
 	}
 	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(req.Password)); err != nil { // wrong password
-		return nil, fmt.Errorf("Error password not matching:%w", err)
+		return nil, s.failLogin(ctx, req, now)
+	}
+	if err := s.loginAttemptRepo.Reset(ctx, lockout.ScopeAccount, normalizeEmail(req.Email)); err != nil {
+		return nil, fmt.Errorf("Error resetting login attempts:%w", err)
 	}
-	var secretKey = os.Getenv("JWT_SECRET")
-	tokenMaker := utils.NewJWTMaker(secretKey)
+	resp, err := s.issueSession(ctx, user, req.UserAgent, req.IPAddress, now)
+	if errors.Is(err, ErrAccountSuspended) {
+		s.metrics.LoginFailed("suspended")
+	}
+	return resp, err
+}
 
+// failLogin records the failed attempt and returns the uniform credentials error,
+// or the throttled error once the client IP is locked
+func (s *Service) failLogin(ctx context.Context, req LoginUserReq, now time.Time) error {
+	if err := s.recordLoginFailure(ctx, req, now); err != nil {
+		return err
+	}
+	if err := s.checkLoginThrottle(ctx, ipSubject(req), now); err != nil {
+		if errors.As(err, new(*LoginThrottledError)) {
+			s.metrics.LoginFailed("throttled")
+		}
+		return err
+	}
+	s.metrics.LoginFailed("invalid_credentials")
+	return ErrInvalidCredentials
+}
+
+// issueSession starts a new session for an authenticated user and returns its token pair
+func (s *Service) issueSession(ctx context.Context, u *user.User, userAgent, ipAddress string, now time.Time) (*LoginUserResp, error) {
+	if u.IsSuspended() {
+		return nil, ErrAccountSuspended
+	}
 	sessionID, err := uuid.NewRandom()
 	if err != nil {
 		return nil, fmt.Errorf("error generating session ID:%w", err)
 	}
-	refreshToken, refreshClaims, err := tokenMaker.CreateToken(sessionID.String(), user.ID, user.Email, user.IsAdmin, 7*24*time.Hour)
+	refreshToken, refreshClaims, err := s.tokenMaker.CreateRefreshToken(sessionID.String(), u.ID, u.Email, u.IsAdmin, s.tokenLifetimes.Refresh)
 	if err != nil {
 		return nil, fmt.Errorf("Error creating refreshToken:%w", err)
 	}
-	accessToken, accessClaims, err := tokenMaker.CreateToken(sessionID.String(), user.ID, user.Email, user.IsAdmin, 15*time.Minute)
+	accessToken, accessClaims, err := s.tokenMaker.CreateToken(sessionID.String(), u.ID, u.Email, u.IsAdmin, s.tokenLifetimes.Access)
 	if err != nil {
 		return nil, fmt.Errorf("Error creating accessToken:%w", err)
 	}
@@ -54,17 +103,24 @@ func (s *Service) LoginUser(ctx context.Context, req LoginUserReq) (*LoginUserRe
 	// Create session using the session service
 	// Session ID matches both tokens' SessionID field
 	sess := &session.Session{
-		ID:           refreshClaims.SessionID,
-		Email:        user.Email,
-		RefreshToken: refreshToken,
-		IsRevoked:    false,
-		CreatedAt:    time.Now().UTC(),
-		ExpiresAt:    refreshClaims.RegisteredClaims.ExpiresAt.Time,
+		ID:         refreshClaims.SessionID,
+		UserID:     u.ID,
+		Email:      u.Email,
+		UserAgent:  userAgent,
+		IPAddress:  ipAddress,
+		IsRevoked:  false,
+		CreatedAt:  now,
+		LastUsedAt: now,
+		ExpiresAt:  refreshClaims.RegisteredClaims.ExpiresAt.Time,
 	}
 	createdSession, err := s.sessionService.CreateSession(ctx, sess)
 	if err != nil {
 		return nil, fmt.Errorf("Error creating session:%w", err)
 	}
+	// Only the hash of the refresh token is stored; it starts the session's rotation chain
+	if err := s.storeRefreshToken(ctx, refreshToken, refreshClaims); err != nil {
+		return nil, err
+	}
 
 	res := LoginUserResp{
 		SessionID:             createdSession.ID,
@@ -72,9 +128,9 @@ func (s *Service) LoginUser(ctx context.Context, req LoginUserReq) (*LoginUserRe
 		RefreshToken:          refreshToken,
 		AccessTokenExpiresAt:  accessClaims.RegisteredClaims.ExpiresAt.Time,
 		RefreshTokenExpiresAt: refreshClaims.RegisteredClaims.ExpiresAt.Time,
-		Name:                  user.Name,
-		Email:                 user.Email,
-		IsAdmin:               user.IsAdmin,
+		Name:                  u.Name,
+		Email:                 u.Email,
+		IsAdmin:               u.IsAdmin,
 	}
 	return &res, nil
 }
@@ -10,6 +10,8 @@ type LogoutUserReq struct {
 }
 
 func (s *Service) LogoutUser(ctx context.Context, req LogoutUserReq) error {
+	ctx, span := tracer.Start(ctx, "user.LogoutUser")
+	defer span.End()
 	id := req.SessionID // SessionID passed down in req by middleware layer
 	err := s.sessionService.DeleteSession(ctx, id)
 	if err != nil {
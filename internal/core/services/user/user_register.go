@@ -2,17 +2,24 @@ package user
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/user"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/validation"
+	"github.com/frostnzx/go-ecommerce-api/internal/ports"
 	"github.com/google/uuid"
 	"golang.org/x/crypto/bcrypt"
 )
 
+var (
+	ErrEmailTaken = errors.New("email is already registered")
+)
+
 type RegisterUserReq struct {
-	Name     string
-	Email    string
-	Password string
+	Name     string `validate:"notblank,max=100"`
+	Email    string `validate:"required,email,max=254"`
+	Password string `validate:"required,password"`
 	IsAdmin  bool
 }
 type RegisterUserResp struct {
@@ -20,12 +27,20 @@ type RegisterUserResp struct {
 }
 
 func (s *Service) RegisterUser(ctx context.Context, req RegisterUserReq) (*RegisterUserResp, error) {
+	ctx, span := tracer.Start(ctx, "user.RegisterUser")
+	defer span.End()
+	if err := validation.Struct(req); err != nil {
+		return nil, err
+	}
 	passwordHash, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
 	if err != nil {
 		return nil, fmt.Errorf("fail to hash password:%w", err)
 	}
 	user := user.New(req.Email, string(passwordHash), req.Name, req.IsAdmin)
 	if err := s.userRepo.Create(ctx, user); err != nil {
+		if errors.Is(err, ports.ErrConflict) {
+			return nil, ErrEmailTaken
+		}
 		return nil, fmt.Errorf("fail to register user:%w", err)
 	}
 	resp := RegisterUserResp{
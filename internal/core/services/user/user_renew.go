@@ -2,11 +2,20 @@ package user
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"os"
 	"time"
 
 	"github.com/frostnzx/go-ecommerce-api/internal/core/utils"
+	"github.com/frostnzx/go-ecommerce-api/internal/logging"
+	"github.com/frostnzx/go-ecommerce-api/internal/ports"
+)
+
+var (
+	ErrInvalidRefreshToken = errors.New("invalid refresh token")
+	ErrRefreshTokenReused  = errors.New("refresh token reuse detected, session revoked")
+	ErrSessionRevoked      = errors.New("session revoked")
+	ErrSessionExpired      = errors.New("session expired")
 )
 
 type RenewAccessTokenReq struct {
@@ -14,17 +23,33 @@ type RenewAccessTokenReq struct {
 }
 
 type RenewAccessTokenResp struct {
-	AccessToken          string
-	AccessTokenExpiresAt time.Time
+	AccessToken           string
+	RefreshToken          string
+	AccessTokenExpiresAt  time.Time
+	RefreshTokenExpiresAt time.Time
 }
 
 func (s *Service) RenewAccessToken(ctx context.Context, req RenewAccessTokenReq) (*RenewAccessTokenResp, error) {
-	var secretKey = os.Getenv("JWT_SECRET")
-	tokenMaker := utils.NewJWTMaker(secretKey)
+	ctx, span := tracer.Start(ctx, "user.RenewAccessToken")
+	defer span.End()
+	refreshClaims, err := s.tokenMaker.VerifyToken(req.RefreshToken, utils.RefreshToken)
+	if err != nil {
+		return nil, ErrInvalidRefreshToken
+	}
 
-	refreshClaims, err := tokenMaker.VerifyToken(req.RefreshToken)
+	// Look the token up by hash; anything we did not issue as a refresh token is rejected
+	stored, err := s.sessionService.GetRefreshToken(ctx, utils.HashToken(req.RefreshToken))
 	if err != nil {
-		return nil, fmt.Errorf("error verifying token")
+		if errors.Is(err, ports.ErrNotFound) {
+			return nil, ErrInvalidRefreshToken
+		}
+		return nil, fmt.Errorf("error getting refresh token:%w", err)
+	}
+	if stored.SessionID != refreshClaims.SessionID {
+		return nil, ErrInvalidRefreshToken
+	}
+	if stored.IsConsumed() {
+		return nil, s.revokeReusedSession(ctx, stored.SessionID)
 	}
 
 	// Use SessionID (shared between access and refresh tokens)
@@ -33,32 +58,70 @@ func (s *Service) RenewAccessToken(ctx context.Context, req RenewAccessTokenReq)
 		return nil, fmt.Errorf("error getting session:%w", err)
 	}
 	if session.IsRevoked {
-		return nil, fmt.Errorf("session revoked")
+		return nil, ErrSessionRevoked
 	}
 	if session.Email != refreshClaims.Email {
-		return nil, fmt.Errorf("invalid session")
+		return nil, ErrInvalidRefreshToken
+	}
+	remaining := time.Until(session.ExpiresAt)
+	if remaining <= 0 {
+		return nil, ErrSessionExpired
+	}
+
+	// The rotated refresh token never outlives the session it belongs to
+	refreshToken, newRefreshClaims, err := s.tokenMaker.CreateRefreshToken(
+		refreshClaims.SessionID,
+		refreshClaims.ID,
+		refreshClaims.Email,
+		refreshClaims.IsAdmin,
+		remaining,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error creating refresh token:%w", err)
 	}
 
 	// Create new access token with the same session ID
-	accessToken, accessClaims, err := tokenMaker.CreateToken(
+	accessToken, accessClaims, err := s.tokenMaker.CreateToken(
 		refreshClaims.SessionID,
 		refreshClaims.ID,
 		refreshClaims.Email,
 		refreshClaims.IsAdmin,
-		15*time.Minute,
+		s.tokenLifetimes.Access,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("error creating access token:%w", err)
 	}
 
+	// Swap the presented token for the new one atomically, so a failure leaves the old
+	// token usable for a retry; losing this race means someone else already used it
+	next := newRefreshToken(refreshToken, newRefreshClaims)
+	if err := s.sessionService.RotateRefreshToken(ctx, stored.ID, &next); err != nil {
+		if errors.Is(err, ports.ErrRefreshTokenConsumed) {
+			return nil, s.revokeReusedSession(ctx, stored.SessionID)
+		}
+		return nil, fmt.Errorf("error rotating refresh token:%w", err)
+	}
+
 	return &RenewAccessTokenResp{
-		AccessToken:          accessToken,
-		AccessTokenExpiresAt: accessClaims.RegisteredClaims.ExpiresAt.Time,
+		AccessToken:           accessToken,
+		RefreshToken:          refreshToken,
+		AccessTokenExpiresAt:  accessClaims.RegisteredClaims.ExpiresAt.Time,
+		RefreshTokenExpiresAt: newRefreshClaims.RegisteredClaims.ExpiresAt.Time,
 	}, nil
 }
 
+// revokeReusedSession revokes the whole session family once a consumed refresh token is replayed
+func (s *Service) revokeReusedSession(ctx context.Context, sessionID string) error {
+	if err := s.sessionService.RevokeSession(ctx, sessionID); err != nil {
+		return fmt.Errorf("error revoking session after refresh token reuse:%w", err)
+	}
+	logging.FromContext(ctx).Warn("refresh token reuse detected, session revoked", "session_id", sessionID)
+	return ErrRefreshTokenReused
+}
+
 /*
 	- VerifyToken gets refreshClaims from the refreshToken string
-	- GetSession use sessionID inside refreshClaims to get a session object
-	- Create new accessToken with properties from refreshClaims
+	- GetRefreshToken looks up the stored hash; a consumed token means it was replayed, so the session is revoked
+	- Create a new refresh token (bounded by the session expiry) and access token with properties from refreshClaims
+	- RotateRefreshToken marks the presented token as used and stores the new one in one transaction
 */
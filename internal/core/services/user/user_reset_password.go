@@ -0,0 +1,55 @@
+package user
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/audit"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/lockout"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/utils"
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type ResetUserPasswordReq struct {
+	AdminID uuid.UUID
+	ID      uuid.UUID
+}
+type ResetUserPasswordResp struct {
+	TemporaryPassword string // Handed to the user out of band, who should change it after logging in
+}
+
+// ResetUserPassword replaces the password with a random temporary one, logs the user out
+// everywhere and clears any login lockout
+func (s *Service) ResetUserPassword(ctx context.Context, req ResetUserPasswordReq) (*ResetUserPasswordResp, error) {
+	ctx, span := tracer.Start(ctx, "user.ResetUserPassword")
+	defer span.End()
+	user, err := s.getUser(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	password, err := utils.RandomToken(12)
+	if err != nil {
+		return nil, err
+	}
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, fmt.Errorf("fail to hash password: %w", err)
+	}
+	if err := s.userRepo.UpdatePasswordHash(ctx, user.ID, string(passwordHash)); err != nil {
+		return nil, fmt.Errorf("error resetting password: %w", err)
+	}
+
+	if _, err := s.sessionService.RevokeUserSessions(ctx, user.ID, ""); err != nil {
+		return nil, fmt.Errorf("error revoking sessions: %w", err)
+	}
+	if err := s.loginAttemptRepo.Reset(ctx, lockout.ScopeAccount, normalizeEmail(user.Email)); err != nil {
+		return nil, fmt.Errorf("error unlocking user: %w", err)
+	}
+	if err := s.recordAudit(ctx, req.AdminID, audit.ActionResetPassword, user.ID, ""); err != nil {
+		return nil, err
+	}
+
+	return &ResetUserPasswordResp{TemporaryPassword: password}, nil
+}
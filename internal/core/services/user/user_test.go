@@ -0,0 +1,352 @@
+package user
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/frostnzx/go-ecommerce-api/internal/adapters/secondary/memory"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/audit"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/lockout"
+	domainsession "github.com/frostnzx/go-ecommerce-api/internal/core/domain/session"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/user"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/services/session"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/utils"
+	"github.com/frostnzx/go-ecommerce-api/internal/ports"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type nopMetrics struct{}
+
+func (nopMetrics) OrderPlaced(float64) {}
+func (nopMetrics) OrderCancelled()     {}
+func (nopMetrics) LoginFailed(string)  {}
+
+// flakySessions fails refresh token rotation while fail is set, the way a dropped connection does
+type flakySessions struct {
+	ports.SessionRepo
+	fail bool
+}
+
+func (f *flakySessions) RotateRefreshToken(ctx context.Context, consumedID string, next *domainsession.RefreshToken) error {
+	if f.fail {
+		return errors.New("connection reset")
+	}
+	return f.SessionRepo.RotateRefreshToken(ctx, consumedID, next)
+}
+
+type fixture struct {
+	svc           *Service
+	users         ports.UserRepo
+	sessions      *flakySessions
+	loginAttempts ports.LoginAttemptRepo
+	auditLog      ports.AuditRepo
+	user          *user.User
+}
+
+const testPassword = "correct-horse-1"
+
+func newTestTokenMaker(t *testing.T) *utils.JWTMaker {
+	t.Helper()
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tm, err := utils.NewJWTMaker("", []utils.SigningKey{{ID: "test", Method: jwt.SigningMethodEdDSA, Private: priv, Public: pub}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tm
+}
+
+func newFixture(t *testing.T) fixture {
+	t.Helper()
+	s := memory.NewStore()
+	users, _ := memory.NewUserRepo(s)
+	sessionRepo, _ := memory.NewSessionRepo(s)
+	loginAttempts, _ := memory.NewLoginAttemptRepo(s)
+	auditLog, _ := memory.NewAuditRepo(s)
+	sessions := &flakySessions{SessionRepo: sessionRepo}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(testPassword), bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+	u := user.New("ada@example.com", string(hash), "Ada", false)
+	if err := users.Create(context.Background(), u); err != nil {
+		t.Fatal(err)
+	}
+	svc := NewService(users, session.NewService(sessions), newTestTokenMaker(t), loginAttempts, lockout.DefaultPolicy(), auditLog, DefaultTokenLifetimes(), nopMetrics{})
+	return fixture{svc: svc, users: users, sessions: sessions, loginAttempts: loginAttempts, auditLog: auditLog, user: &u}
+}
+
+func (f fixture) login(t *testing.T) *LoginUserResp {
+	t.Helper()
+	resp, err := f.svc.issueSession(context.Background(), f.user, "test", "127.0.0.1", time.Now().UTC())
+	if err != nil {
+		t.Fatalf("issueSession: %v", err)
+	}
+	return resp
+}
+
+func (f fixture) renew(token string) (*RenewAccessTokenResp, error) {
+	return f.svc.RenewAccessToken(context.Background(), RenewAccessTokenReq{RefreshToken: token})
+}
+
+func TestRenewAccessTokenRotatesRefreshToken(t *testing.T) {
+	f := newFixture(t)
+	login := f.login(t)
+
+	first, err := f.renew(login.RefreshToken)
+	if err != nil {
+		t.Fatalf("renew: %v", err)
+	}
+	if first.RefreshToken == login.RefreshToken || first.AccessToken == "" {
+		t.Fatal("renewal did not issue a new token pair")
+	}
+	if first.RefreshTokenExpiresAt.After(login.RefreshTokenExpiresAt.Add(time.Second)) {
+		t.Fatalf("rotated token expires at %v, after the session at %v", first.RefreshTokenExpiresAt, login.RefreshTokenExpiresAt)
+	}
+	stored, err := f.sessions.GetRefreshToken(context.Background(), utils.HashToken(login.RefreshToken))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !stored.IsConsumed() {
+		t.Fatal("presented token was not consumed")
+	}
+
+	if _, err := f.renew(first.RefreshToken); err != nil {
+		t.Fatalf("renewing with the rotated token: %v", err)
+	}
+}
+
+func TestRenewAccessTokenReuseRevokesSession(t *testing.T) {
+	f := newFixture(t)
+	login := f.login(t)
+	rotated, err := f.renew(login.RefreshToken)
+	if err != nil {
+		t.Fatalf("renew: %v", err)
+	}
+
+	if _, err := f.renew(login.RefreshToken); !errors.Is(err, ErrRefreshTokenReused) {
+		t.Fatalf("replay: err = %v, want ErrRefreshTokenReused", err)
+	}
+	sess, err := f.sessions.GetSession(context.Background(), login.SessionID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !sess.IsRevoked {
+		t.Fatal("session not revoked after the replay")
+	}
+	// The legitimate holder of the newest token is logged out as well
+	if _, err := f.renew(rotated.RefreshToken); !errors.Is(err, ErrSessionRevoked) {
+		t.Fatalf("renewing after the replay: err = %v, want ErrSessionRevoked", err)
+	}
+}
+
+func TestRenewAccessTokenRetriesAfterFailedRotation(t *testing.T) {
+	f := newFixture(t)
+	login := f.login(t)
+
+	f.sessions.fail = true
+	if _, err := f.renew(login.RefreshToken); err == nil || errors.Is(err, ErrRefreshTokenReused) {
+		t.Fatalf("err = %v, want the storage failure", err)
+	}
+	f.sessions.fail = false
+	if _, err := f.renew(login.RefreshToken); err != nil {
+		t.Fatalf("retry after a failed rotation: %v", err)
+	}
+}
+
+func TestRenewAccessTokenRejectsExpiredTokens(t *testing.T) {
+	f := newFixture(t)
+	ctx := context.Background()
+
+	// The refresh token itself has expired
+	expired, _, err := f.svc.tokenMaker.CreateRefreshToken(uuid.NewString(), f.user.ID, f.user.Email, false, -time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.renew(expired); !errors.Is(err, ErrInvalidRefreshToken) {
+		t.Fatalf("expired token: err = %v, want ErrInvalidRefreshToken", err)
+	}
+
+	// The token is still valid but the session behind it has ended
+	sess := domainsession.New(uuid.NewString(), f.user.ID, f.user.Email, false, time.Now().Add(-time.Minute))
+	if _, err := f.sessions.CreateSession(ctx, &sess); err != nil {
+		t.Fatal(err)
+	}
+	token, claims, err := f.svc.tokenMaker.CreateRefreshToken(sess.ID, f.user.ID, f.user.Email, false, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := f.svc.storeRefreshToken(ctx, token, claims); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.renew(token); !errors.Is(err, ErrSessionExpired) {
+		t.Fatalf("expired session: err = %v, want ErrSessionExpired", err)
+	}
+}
+
+func TestRenewAccessTokenRejectsAccessTokens(t *testing.T) {
+	f := newFixture(t)
+	login := f.login(t)
+	if _, err := f.renew(login.AccessToken); !errors.Is(err, ErrInvalidRefreshToken) {
+		t.Fatalf("err = %v, want ErrInvalidRefreshToken", err)
+	}
+	// Refusing the wrong kind of token is not reuse, so the session carries on
+	if _, err := f.renew(login.RefreshToken); err != nil {
+		t.Fatalf("renew with the refresh token: %v", err)
+	}
+}
+
+func TestRenewAccessTokenRejectsUnknownToken(t *testing.T) {
+	f := newFixture(t)
+	token, _, err := f.svc.tokenMaker.CreateRefreshToken(uuid.NewString(), f.user.ID, f.user.Email, false, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.renew(token); !errors.Is(err, ErrInvalidRefreshToken) {
+		t.Fatalf("err = %v, want ErrInvalidRefreshToken", err)
+	}
+}
+
+func TestParallelLoginFailuresAreAllCounted(t *testing.T) {
+	f := newFixture(t)
+	f.svc.lockoutPolicy.BaseDelay = 0 // No progressive delay, so only the lockout stops the guesses
+	const guesses = 20
+
+	var wg sync.WaitGroup
+	results := make(chan error, guesses)
+	for i := range guesses {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := f.svc.LoginUser(context.Background(), LoginUserReq{
+				Email:     f.user.Email,
+				Password:  "guess-" + strconv.Itoa(i),
+				IPAddress: "192.0.2." + strconv.Itoa(i), // Spread over IPs to dodge the per-IP limit
+			})
+			results <- err
+		}()
+	}
+	wg.Wait()
+	close(results)
+	checked := 0
+	for err := range results {
+		if errors.Is(err, ErrInvalidCredentials) {
+			checked++
+		} else if !errors.Is(err, ErrTooManyAttempts) {
+			t.Fatalf("login = %v, want invalid credentials or throttled", err)
+		}
+	}
+
+	// Every guess whose password was checked is counted, none is lost to a concurrent update
+	attempts, err := f.loginAttempts.Get(context.Background(), lockout.ScopeAccount, f.user.Email)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if attempts.Failures != checked || attempts.LockedUntil == nil {
+		t.Fatalf("failures = %d for %d checked guesses, locked = %v, want all counted and the account locked", attempts.Failures, checked, attempts.LockedUntil != nil)
+	}
+	_, err = f.svc.LoginUser(context.Background(), LoginUserReq{Email: f.user.Email, Password: testPassword, IPAddress: "198.51.100.1"})
+	if !errors.Is(err, ErrTooManyAttempts) {
+		t.Fatalf("login with the right password = %v, want ErrTooManyAttempts", err)
+	}
+}
+
+func TestLockedIPStillAcceptsTheRightPassword(t *testing.T) {
+	f := newFixture(t)
+	f.svc.lockoutPolicy.MaxAttemptsPerIP = 3
+	const ip = "203.0.113.7"
+	for i := range 3 {
+		req := LoginUserReq{Email: "user" + strconv.Itoa(i) + "@example.com", Password: "guess", IPAddress: ip}
+		if _, err := f.svc.LoginUser(context.Background(), req); err == nil {
+			t.Fatal("login with a wrong password succeeded")
+		}
+	}
+
+	_, err := f.svc.LoginUser(context.Background(), LoginUserReq{Email: "other@example.com", Password: "guess", IPAddress: ip})
+	if !errors.Is(err, ErrTooManyAttempts) {
+		t.Fatalf("wrong password from the locked IP = %v, want ErrTooManyAttempts", err)
+	}
+	// Someone sharing the IP with the attacker still gets in with their own password
+	if _, err := f.svc.LoginUser(context.Background(), LoginUserReq{Email: f.user.Email, Password: testPassword, IPAddress: ip}); err != nil {
+		t.Fatalf("right password from the locked IP = %v, want success", err)
+	}
+}
+
+func TestImpersonateUser(t *testing.T) {
+	f := newFixture(t)
+	ctx := context.Background()
+	admin := user.New("root@example.com", "hash", "Root", true)
+	if err := f.users.Create(ctx, admin); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := f.svc.ImpersonateUser(ctx, ImpersonateUserReq{AdminID: admin.ID, ID: f.user.ID, Reason: " "}); !errors.Is(err, ErrReasonRequired) {
+		t.Fatalf("no reason: err = %v, want ErrReasonRequired", err)
+	}
+	if _, err := f.svc.ImpersonateUser(ctx, ImpersonateUserReq{AdminID: admin.ID, ID: admin.ID, Reason: "T-1"}); !errors.Is(err, ErrCannotImpersonateAdmin) {
+		t.Fatalf("admin target: err = %v, want ErrCannotImpersonateAdmin", err)
+	}
+
+	res, err := f.svc.ImpersonateUser(ctx, ImpersonateUserReq{AdminID: admin.ID, ID: f.user.ID, Reason: "ticket T-42"})
+	if err != nil {
+		t.Fatalf("ImpersonateUser: %v", err)
+	}
+	claims, err := f.svc.tokenMaker.VerifyToken(res.AccessToken, utils.AccessToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if claims.ID != f.user.ID || claims.IsAdmin || claims.ImpersonatorID != admin.ID.String() || claims.SessionID != res.SessionID {
+		t.Fatalf("claims = %+v, want the user's, naming the admin", claims)
+	}
+	sess, err := f.sessions.GetSession(ctx, res.SessionID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sess.ImpersonatorID == nil || *sess.ImpersonatorID != admin.ID {
+		t.Fatalf("session impersonator = %v, want the admin", sess.ImpersonatorID)
+	}
+
+	entries, err := f.auditLog.List(ctx, f.user.ID, 10, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Action != audit.ActionImpersonate || entries[0].ActorID != admin.ID || !strings.Contains(entries[0].Details, "ticket T-42") {
+		t.Fatalf("audit log = %+v, want the impersonation with its reason", entries)
+	}
+}
+
+func TestRecordImpersonatedRequest(t *testing.T) {
+	f := newFixture(t)
+	adminID := uuid.New()
+	err := f.svc.RecordImpersonatedRequest(context.Background(), RecordImpersonatedRequestReq{
+		AdminID:   adminID,
+		UserID:    f.user.ID,
+		SessionID: "session-1",
+		Request:   "POST /orders",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	entries, err := f.auditLog.List(context.Background(), f.user.ID, 10, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("got %d audit entries, want 1", len(entries))
+	}
+	if e := entries[0]; e.Action != audit.ActionImpersonatedRequest || e.ActorID != adminID || e.Details != "session session-1: POST /orders" {
+		t.Fatalf("entry = %+v, want the request attributed to the admin", e)
+	}
+}
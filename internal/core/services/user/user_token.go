@@ -0,0 +1,27 @@
+package user
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/session"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/utils"
+)
+
+// storeRefreshToken persists the hash of a freshly signed refresh token so it can be rotated later
+func (s *Service) storeRefreshToken(ctx context.Context, token string, claims *utils.UserClaims) error {
+	rt := newRefreshToken(token, claims)
+	if err := s.sessionService.CreateRefreshToken(ctx, &rt); err != nil {
+		return fmt.Errorf("error storing refresh token:%w", err)
+	}
+	return nil
+}
+
+func newRefreshToken(token string, claims *utils.UserClaims) session.RefreshToken {
+	return session.NewRefreshToken(
+		claims.RegisteredClaims.ID,
+		claims.SessionID,
+		utils.HashToken(token),
+		claims.RegisteredClaims.ExpiresAt.Time,
+	)
+}
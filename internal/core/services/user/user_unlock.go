@@ -0,0 +1,30 @@
+package user
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/lockout"
+	"github.com/google/uuid"
+)
+
+type UnlockUserReq struct {
+	ID uuid.UUID
+}
+
+// UnlockUser clears the failed login counter and any lockout on the user's account
+func (s *Service) UnlockUser(ctx context.Context, req UnlockUserReq) error {
+	ctx, span := tracer.Start(ctx, "user.UnlockUser")
+	defer span.End()
+	user, err := s.getUser(ctx, req.ID)
+	if err != nil {
+		return err
+	}
+
+	err = s.loginAttemptRepo.Reset(ctx, lockout.ScopeAccount, normalizeEmail(user.Email))
+	if err != nil {
+		return fmt.Errorf("error unlocking user: %w", err)
+	}
+
+	return nil
+}
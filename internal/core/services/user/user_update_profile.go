@@ -2,21 +2,29 @@ package user
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
+	"github.com/frostnzx/go-ecommerce-api/internal/core/validation"
+	"github.com/frostnzx/go-ecommerce-api/internal/ports"
 	"github.com/google/uuid"
 )
 
 type UpdateUserProfileReq struct {
-	ID    uuid.UUID // Extracted from JWT claims in handler/middleware
-	Name  string
-	Email string
+	ID    uuid.UUID // Resolved and authorized by the handler
+	Name  string    `validate:"notblank,max=100"`
+	Email string    `validate:"required,email,max=254"`
 }
 
 func (s *Service) UpdateUserProfile(ctx context.Context, req UpdateUserProfileReq) error {
-	user, err := s.userRepo.GetUserByID(ctx, req.ID)
+	ctx, span := tracer.Start(ctx, "user.UpdateUserProfile")
+	defer span.End()
+	if err := validation.Struct(req); err != nil {
+		return err
+	}
+	user, err := s.getUser(ctx, req.ID)
 	if err != nil {
-		return fmt.Errorf("error getting user: %w", err)
+		return err
 	}
 
 	user.Name = req.Name
@@ -24,6 +32,9 @@ func (s *Service) UpdateUserProfile(ctx context.Context, req UpdateUserProfileRe
 
 	err = s.userRepo.UpdateUser(ctx, *user)
 	if err != nil {
+		if errors.Is(err, ports.ErrConflict) {
+			return ErrEmailTaken
+		}
 		return fmt.Errorf("error updating user profile: %w", err)
 	}
 
@@ -1,24 +1,42 @@
 package utils
 
 import (
+	"errors"
 	"fmt"
+	"sort"
 	"time"
 
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/apikey"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 )
 
+// TokenType tells access tokens from refresh tokens, so neither is accepted in place of the other
+type TokenType string
+
+const (
+	AccessToken  TokenType = "access"
+	RefreshToken TokenType = "refresh"
+)
+
 // claims
 type UserClaims struct {
 	ID        uuid.UUID `json:"id"`
 	Email     string    `json:"email"`
 	IsAdmin   bool      `json:"is_admin"`
 	SessionID string    `json:"session_id"` // Shared session ID for both access and refresh tokens
+	Type      TokenType `json:"typ"`
+	// ImpersonatorID is the admin acting as this user; empty for the user's own tokens
+	ImpersonatorID string `json:"impersonator_id,omitempty"`
 	jwt.RegisteredClaims
+
+	// Set by the auth middleware for requests authenticated with an API key; never part of a JWT
+	APIKeyID string        `json:"-"`
+	Scopes   apikey.Scopes `json:"-"`
 }
 
-// NewUserClaims creates claims with a new session ID (used for refresh tokens)
-func NewUserClaims(sessionID string, id uuid.UUID, email string, isAdmin bool, duration time.Duration) (*UserClaims, error) {
+// NewUserClaims creates claims of the given type for a session
+func NewUserClaims(tokenType TokenType, sessionID string, id uuid.UUID, email string, isAdmin bool, duration time.Duration) (*UserClaims, error) {
 	tokenID, err := uuid.NewRandom()
 	if err != nil {
 		return nil, fmt.Errorf("error generating token ID: %w", err)
@@ -29,6 +47,7 @@ func NewUserClaims(sessionID string, id uuid.UUID, email string, isAdmin bool, d
 		ID:        id,
 		IsAdmin:   isAdmin,
 		SessionID: sessionID,
+		Type:      tokenType,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ID:        tokenID.String(),
 			Subject:   email,
@@ -39,21 +58,97 @@ func NewUserClaims(sessionID string, id uuid.UUID, email string, isAdmin bool, d
 }
 
 // token
+//
+// JWTMaker signs tokens with a single active key and verifies tokens signed by
+// any key in its set, selected by the "kid" header. Rotating keys means adding
+// a new key, making it active, and keeping the previous one (its public half is
+// enough) until every token it signed has expired.
 type JWTMaker struct {
-	secretKey string
+	active *SigningKey
+	keys   map[string]*SigningKey
+}
+
+func NewJWTMaker(activeKeyID string, keys []SigningKey) (*JWTMaker, error) {
+	if len(keys) == 0 {
+		return nil, errors.New("no JWT signing keys configured")
+	}
+
+	maker := &JWTMaker{keys: make(map[string]*SigningKey, len(keys))}
+	for i := range keys {
+		k := &keys[i]
+		if k.ID == "" {
+			return nil, errors.New("JWT key without key ID")
+		}
+		if _, dup := maker.keys[k.ID]; dup {
+			return nil, fmt.Errorf("duplicate JWT key ID %q", k.ID)
+		}
+		maker.keys[k.ID] = k
+	}
+
+	// Fall back to the only private key when no active key is named
+	if activeKeyID == "" {
+		for _, k := range maker.keys {
+			if k.CanSign() {
+				if maker.active != nil {
+					return nil, errors.New("multiple JWT private keys configured, the active key ID must be set")
+				}
+				maker.active = k
+			}
+		}
+		if maker.active == nil {
+			return nil, errors.New("no JWT private key configured")
+		}
+		return maker, nil
+	}
+
+	active, ok := maker.keys[activeKeyID]
+	if !ok {
+		return nil, fmt.Errorf("active JWT key %q not found", activeKeyID)
+	}
+	if !active.CanSign() {
+		return nil, fmt.Errorf("active JWT key %q has no private key", activeKeyID)
+	}
+	maker.active = active
+	return maker, nil
 }
 
-func NewJWTMaker(secretKey string) *JWTMaker {
-	return &JWTMaker{secretKey}
+// ActiveKeyID returns the ID of the key new tokens are signed with
+func (maker *JWTMaker) ActiveKeyID() string {
+	return maker.active.ID
 }
+
+// CreateToken creates an access token
 func (maker *JWTMaker) CreateToken(sessionID string, id uuid.UUID, email string, isAdmin bool, duration time.Duration) (string, *UserClaims, error) {
-	claims, err := NewUserClaims(sessionID, id, email, isAdmin, duration)
+	claims, err := NewUserClaims(AccessToken, sessionID, id, email, isAdmin, duration)
 	if err != nil {
 		return "", nil, err
 	}
+	return maker.sign(claims)
+}
+
+// CreateRefreshToken creates a refresh token, which only RenewAccessToken accepts
+func (maker *JWTMaker) CreateRefreshToken(sessionID string, id uuid.UUID, email string, isAdmin bool, duration time.Duration) (string, *UserClaims, error) {
+	claims, err := NewUserClaims(RefreshToken, sessionID, id, email, isAdmin, duration)
+	if err != nil {
+		return "", nil, err
+	}
+	return maker.sign(claims)
+}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenStr, err := token.SignedString([]byte(maker.secretKey))
+// CreateImpersonationToken creates a token for a user that records the admin acting as them
+func (maker *JWTMaker) CreateImpersonationToken(sessionID string, id uuid.UUID, email string, impersonatorID uuid.UUID, duration time.Duration) (string, *UserClaims, error) {
+	claims, err := NewUserClaims(AccessToken, sessionID, id, email, false, duration)
+	if err != nil {
+		return "", nil, err
+	}
+	claims.ImpersonatorID = impersonatorID.String()
+	return maker.sign(claims)
+}
+
+func (maker *JWTMaker) sign(claims *UserClaims) (string, *UserClaims, error) {
+	token := jwt.NewWithClaims(maker.active.Method, claims)
+	token.Header["kid"] = maker.active.ID
+	tokenStr, err := token.SignedString(maker.active.Private)
 	if err != nil {
 		return "", nil, fmt.Errorf("error signing token: %w", err)
 	}
@@ -61,16 +156,25 @@ func (maker *JWTMaker) CreateToken(sessionID string, id uuid.UUID, email string,
 	return tokenStr, claims, nil
 }
 
-func (maker *JWTMaker) VerifyToken(tokenStr string) (*UserClaims, error) {
+// VerifyToken checks the signature and expiry of a token and that it is of the wanted type
+func (maker *JWTMaker) VerifyToken(tokenStr string, want TokenType) (*UserClaims, error) {
 	token, err := jwt.ParseWithClaims(tokenStr, &UserClaims{}, func(token *jwt.Token) (interface{}, error) {
-		// verify the signing method
-		_, ok := token.Method.(*jwt.SigningMethodHMAC)
+		kid, ok := token.Header["kid"].(string)
+		if !ok || kid == "" {
+			return nil, fmt.Errorf("token has no key ID")
+		}
+		key, ok := maker.keys[kid]
 		if !ok {
+			return nil, fmt.Errorf("unknown key ID %q", kid)
+		}
+
+		// verify the signing method matches the key, never trust the alg header alone
+		if token.Method.Alg() != key.Method.Alg() {
 			return nil, fmt.Errorf("invalid token signing method")
 		}
 
-		return []byte(maker.secretKey), nil
-	})
+		return key.Public, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg(), jwt.SigningMethodEdDSA.Alg()}))
 	if err != nil {
 		return nil, fmt.Errorf("error parsing token: %w", err)
 	}
@@ -79,6 +183,23 @@ func (maker *JWTMaker) VerifyToken(tokenStr string) (*UserClaims, error) {
 	if !ok {
 		return nil, fmt.Errorf("invalid token claims")
 	}
+	if claims.Type != want {
+		return nil, fmt.Errorf("token is not an %s token", want)
+	}
 
 	return claims, nil
 }
+
+// JWKS returns the public half of every key so other services can verify tokens
+func (maker *JWTMaker) JWKS() JWKSet {
+	set := JWKSet{Keys: make([]JWK, 0, len(maker.keys))}
+	for _, k := range maker.keys {
+		jwk, err := k.JWK()
+		if err != nil {
+			continue
+		}
+		set.Keys = append(set.Keys, jwk)
+	}
+	sort.Slice(set.Keys, func(i, j int) bool { return set.Keys[i].KeyID < set.Keys[j].KeyID })
+	return set
+}
@@ -0,0 +1,155 @@
+package utils
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// SigningKey is one entry of the JWT key set. Retired keys only need the public half.
+type SigningKey struct {
+	ID      string // Published as "kid" in the token header and the JWKS
+	Method  jwt.SigningMethod
+	Private crypto.Signer
+	Public  crypto.PublicKey
+}
+
+// CanSign reports whether the key holds private key material
+func (k SigningKey) CanSign() bool {
+	return k.Private != nil
+}
+
+// ParseSigningKey reads an RSA (RS256) or Ed25519 (EdDSA) key from PEM.
+// Both private keys (PKCS#8 or PKCS#1) and public keys (PKIX) are accepted.
+func ParseSigningKey(id string, pemBytes []byte) (SigningKey, error) {
+	block, _ := pem.Decode(pemBytes)
+	if block == nil {
+		return SigningKey{}, fmt.Errorf("key %q: no PEM data found", id)
+	}
+
+	var parsed any
+	var err error
+	switch block.Type {
+	case "PRIVATE KEY":
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		parsed, err = x509.ParsePKIXPublicKey(block.Bytes)
+	default:
+		return SigningKey{}, fmt.Errorf("key %q: unsupported PEM block %q", id, block.Type)
+	}
+	if err != nil {
+		return SigningKey{}, fmt.Errorf("key %q: %w", id, err)
+	}
+
+	key := SigningKey{ID: id}
+	switch k := parsed.(type) {
+	case *rsa.PrivateKey:
+		if k.N.BitLen() < 2048 {
+			return SigningKey{}, fmt.Errorf("key %q: RSA keys must be at least 2048 bits", id)
+		}
+		key.Method, key.Private, key.Public = jwt.SigningMethodRS256, k, &k.PublicKey
+	case *rsa.PublicKey:
+		if k.N.BitLen() < 2048 {
+			return SigningKey{}, fmt.Errorf("key %q: RSA keys must be at least 2048 bits", id)
+		}
+		key.Method, key.Public = jwt.SigningMethodRS256, k
+	case ed25519.PrivateKey:
+		key.Method, key.Private, key.Public = jwt.SigningMethodEdDSA, k, k.Public()
+	case ed25519.PublicKey:
+		key.Method, key.Public = jwt.SigningMethodEdDSA, k
+	default:
+		return SigningKey{}, fmt.Errorf("key %q: unsupported key type %T", id, parsed)
+	}
+	return key, nil
+}
+
+// LoadSigningKeys reads every *.pem file in dir. The file name without its
+// extension is the key ID, so "2026-10.pem" becomes kid "2026-10". Public-only
+// keys of retired signers can be kept as "<kid>.pub.pem".
+func LoadSigningKeys(dir string) ([]SigningKey, error) {
+	if dir == "" {
+		return nil, errors.New("JWT key directory is not set")
+	}
+	paths, err := filepath.Glob(filepath.Join(dir, "*.pem"))
+	if err != nil {
+		return nil, fmt.Errorf("error listing JWT keys: %w", err)
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no JWT keys found in %s", dir)
+	}
+	sort.Strings(paths)
+
+	keys := make([]SigningKey, 0, len(paths))
+	index := make(map[string]int, len(paths))
+	for _, path := range paths {
+		id := strings.TrimSuffix(strings.TrimSuffix(filepath.Base(path), ".pem"), ".pub")
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("error reading JWT key: %w", err)
+		}
+		key, err := ParseSigningKey(id, data)
+		if err != nil {
+			return nil, err
+		}
+		// A private key and its exported public half may sit side by side; keep the private one
+		if i, seen := index[id]; seen {
+			if key.CanSign() {
+				keys[i] = key
+			}
+			continue
+		}
+		index[id] = len(keys)
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// JWK is a public key in JSON Web Key format (RFC 7517)
+type JWK struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	// RSA
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+	// Ed25519 (OKP)
+	Curve string `json:"crv,omitempty"`
+	X     string `json:"x,omitempty"`
+}
+
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWK returns the public key in JSON Web Key format
+func (k SigningKey) JWK() (JWK, error) {
+	jwk := JWK{KeyID: k.ID, Use: "sig", Algorithm: k.Method.Alg()}
+	switch pub := k.Public.(type) {
+	case *rsa.PublicKey:
+		jwk.KeyType = "RSA"
+		jwk.N = base64.RawURLEncoding.EncodeToString(pub.N.Bytes())
+		jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes())
+	case ed25519.PublicKey:
+		jwk.KeyType = "OKP"
+		jwk.Curve = "Ed25519"
+		jwk.X = base64.RawURLEncoding.EncodeToString(pub)
+	default:
+		return JWK{}, fmt.Errorf("unsupported public key type %T", k.Public)
+	}
+	return jwk, nil
+}
@@ -0,0 +1,258 @@
+package utils
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// RSA key generation is slow, so the tests share one key
+var (
+	rsaKeyOnce sync.Once
+	rsaKey     *rsa.PrivateKey
+)
+
+func testRSAKey(t *testing.T) *rsa.PrivateKey {
+	t.Helper()
+	rsaKeyOnce.Do(func() {
+		var err error
+		if rsaKey, err = rsa.GenerateKey(rand.Reader, 2048); err != nil {
+			panic(err)
+		}
+	})
+	return rsaKey
+}
+
+func testEd25519Key(t *testing.T) ed25519.PrivateKey {
+	t.Helper()
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return priv
+}
+
+func pemBlock(blockType string, der []byte) []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der})
+}
+
+func pkcs8PEM(t *testing.T, key any) []byte {
+	t.Helper()
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pemBlock("PRIVATE KEY", der)
+}
+
+func publicPEM(t *testing.T, key any) []byte {
+	t.Helper()
+	der, err := x509.MarshalPKIXPublicKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pemBlock("PUBLIC KEY", der)
+}
+
+func TestParseSigningKey(t *testing.T) {
+	rsaPriv := testRSAKey(t)
+	edPriv := testEd25519Key(t)
+
+	tests := []struct {
+		name    string
+		pem     []byte
+		alg     string
+		canSign bool
+	}{
+		{"RSA PKCS#8", pkcs8PEM(t, rsaPriv), "RS256", true},
+		{"RSA PKCS#1", pemBlock("RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(rsaPriv)), "RS256", true},
+		{"RSA public", publicPEM(t, &rsaPriv.PublicKey), "RS256", false},
+		{"Ed25519 PKCS#8", pkcs8PEM(t, edPriv), "EdDSA", true},
+		{"Ed25519 public", publicPEM(t, edPriv.Public()), "EdDSA", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, err := ParseSigningKey("k1", tt.pem)
+			if err != nil {
+				t.Fatalf("ParseSigningKey: %v", err)
+			}
+			if key.ID != "k1" || key.Method.Alg() != tt.alg || key.CanSign() != tt.canSign || key.Public == nil {
+				t.Fatalf("got ID=%q alg=%s canSign=%v, want alg=%s canSign=%v", key.ID, key.Method.Alg(), key.CanSign(), tt.alg, tt.canSign)
+			}
+		})
+	}
+}
+
+func TestParseSigningKeyRejectsUnsupportedKeys(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	smallRSA, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		pem  []byte
+		want string
+	}{
+		{"not PEM", []byte("not a key"), "no PEM data"},
+		{"certificate", pemBlock("CERTIFICATE", []byte{1, 2, 3}), "unsupported PEM block"},
+		{"ECDSA", pkcs8PEM(t, ecKey), "unsupported key type"},
+		{"ECDSA public", publicPEM(t, &ecKey.PublicKey), "unsupported key type"},
+		{"short RSA", pkcs8PEM(t, smallRSA), "at least 2048 bits"},
+		{"short RSA public", publicPEM(t, &smallRSA.PublicKey), "at least 2048 bits"},
+		{"corrupt DER", pemBlock("PRIVATE KEY", []byte{1, 2, 3}), `key "k1"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseSigningKey("k1", tt.pem)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("err = %v, want it to mention %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadSigningKeys(t *testing.T) {
+	dir := t.TempDir()
+	edPriv := testEd25519Key(t)
+	retired := testEd25519Key(t)
+	files := map[string][]byte{
+		"2026-10.pem":     pkcs8PEM(t, edPriv),
+		"2026-10.pub.pem": publicPEM(t, edPriv.Public()), // Exported next to its private key
+		"2026-04.pub.pem": publicPEM(t, retired.Public()),
+		"notes.txt":       []byte("ignored"),
+	}
+	for name, data := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), data, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	keys, err := LoadSigningKeys(dir)
+	if err != nil {
+		t.Fatalf("LoadSigningKeys: %v", err)
+	}
+	if len(keys) != 2 || keys[0].ID != "2026-04" || keys[1].ID != "2026-10" {
+		t.Fatalf("got %d keys, want 2026-04 and 2026-10", len(keys))
+	}
+	if keys[0].CanSign() || !keys[1].CanSign() {
+		t.Fatalf("canSign = %v, %v, want only the private key to sign", keys[0].CanSign(), keys[1].CanSign())
+	}
+
+	if _, err := LoadSigningKeys(t.TempDir()); err == nil {
+		t.Error("empty directory: want an error")
+	}
+	if _, err := LoadSigningKeys(""); err == nil {
+		t.Error("unset directory: want an error")
+	}
+}
+
+func TestNewJWTMakerNeedsAPrivateActiveKey(t *testing.T) {
+	edPriv := testEd25519Key(t)
+	signer := SigningKey{ID: "new", Method: jwt.SigningMethodEdDSA, Private: edPriv, Public: edPriv.Public()}
+	retired := SigningKey{ID: "old", Method: jwt.SigningMethodEdDSA, Public: testEd25519Key(t).Public()}
+
+	tests := []struct {
+		name   string
+		active string
+		keys   []SigningKey
+	}{
+		{"no keys", "", nil},
+		{"public keys only", "", []SigningKey{retired}},
+		{"public active key", "old", []SigningKey{signer, retired}},
+		{"unknown active key", "missing", []SigningKey{signer}},
+		{"duplicate key ID", "", []SigningKey{signer, signer}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewJWTMaker(tt.active, tt.keys); err == nil {
+				t.Fatal("want an error")
+			}
+		})
+	}
+
+	maker, err := NewJWTMaker("", []SigningKey{signer, retired})
+	if err != nil {
+		t.Fatalf("NewJWTMaker: %v", err)
+	}
+	if maker.ActiveKeyID() != "new" {
+		t.Fatalf("active key = %q, want the only private key", maker.ActiveKeyID())
+	}
+}
+
+func TestJWKSPublishesPublicKeys(t *testing.T) {
+	rsaPriv := testRSAKey(t)
+	edPriv := testEd25519Key(t)
+	maker, err := NewJWTMaker("b-ed", []SigningKey{
+		{ID: "b-ed", Method: jwt.SigningMethodEdDSA, Private: edPriv, Public: edPriv.Public()},
+		{ID: "a-rsa", Method: jwt.SigningMethodRS256, Public: &rsaPriv.PublicKey},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	raw, err := json.Marshal(maker.JWKS())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var set struct {
+		Keys []map[string]string `json:"keys"`
+	}
+	if err := json.Unmarshal(raw, &set); err != nil {
+		t.Fatal(err)
+	}
+	if len(set.Keys) != 2 {
+		t.Fatalf("got %d keys, want 2", len(set.Keys))
+	}
+
+	rsaJWK, edJWK := set.Keys[0], set.Keys[1]
+	wantRSA := map[string]string{"kty": "RSA", "kid": "a-rsa", "use": "sig", "alg": "RS256", "e": "AQAB"}
+	for k, v := range wantRSA {
+		if rsaJWK[k] != v {
+			t.Errorf("RSA %s = %q, want %q", k, rsaJWK[k], v)
+		}
+	}
+	if len(rsaJWK["n"]) != 342 { // 256 bytes, unpadded base64url
+		t.Errorf("RSA n has length %d, want 342", len(rsaJWK["n"]))
+	}
+	wantEd := map[string]string{"kty": "OKP", "kid": "b-ed", "use": "sig", "alg": "EdDSA", "crv": "Ed25519"}
+	for k, v := range wantEd {
+		if edJWK[k] != v {
+			t.Errorf("Ed25519 %s = %q, want %q", k, edJWK[k], v)
+		}
+	}
+	if len(edJWK["x"]) != 43 { // 32 bytes, unpadded base64url
+		t.Errorf("Ed25519 x has length %d, want 43", len(edJWK["x"]))
+	}
+
+	// Nothing private, and no members that belong to the other key type
+	for _, jwk := range set.Keys {
+		for _, private := range []string{"d", "p", "q", "dp", "dq", "qi"} {
+			if _, ok := jwk[private]; ok {
+				t.Errorf("key %s publishes private member %q", jwk["kid"], private)
+			}
+		}
+	}
+	if _, ok := rsaJWK["x"]; ok {
+		t.Error("RSA key has an x member")
+	}
+	if _, ok := edJWK["n"]; ok {
+		t.Error("Ed25519 key has an n member")
+	}
+}
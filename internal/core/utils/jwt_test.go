@@ -0,0 +1,160 @@
+package utils
+
+import (
+	"crypto/x509"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+func newEd25519Signer(t *testing.T, id string) SigningKey {
+	t.Helper()
+	priv := testEd25519Key(t)
+	return SigningKey{ID: id, Method: jwt.SigningMethodEdDSA, Private: priv, Public: priv.Public()}
+}
+
+func newRSASigner(t *testing.T, id string) SigningKey {
+	t.Helper()
+	priv := testRSAKey(t)
+	return SigningKey{ID: id, Method: jwt.SigningMethodRS256, Private: priv, Public: &priv.PublicKey}
+}
+
+func publicOnly(k SigningKey) SigningKey {
+	k.Private = nil
+	return k
+}
+
+func mustMaker(t *testing.T, active string, keys ...SigningKey) *JWTMaker {
+	t.Helper()
+	maker, err := NewJWTMaker(active, keys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return maker
+}
+
+// forge signs claims with an arbitrary method and key, the way an attacker would
+func forge(t *testing.T, method jwt.SigningMethod, kid string, key any) string {
+	t.Helper()
+	claims, err := NewUserClaims(AccessToken, uuid.NewString(), uuid.New(), "mallory@example.com", true, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	token := jwt.NewWithClaims(method, claims)
+	token.Header["kid"] = kid
+	s, err := token.SignedString(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func TestVerifyTokenRoundTrip(t *testing.T) {
+	for _, signer := range []SigningKey{newRSASigner(t, "rsa"), newEd25519Signer(t, "ed")} {
+		t.Run(signer.Method.Alg(), func(t *testing.T) {
+			maker := mustMaker(t, "", signer)
+			userID := uuid.New()
+			token, _, err := maker.CreateToken("session-1", userID, "ada@example.com", true, time.Minute)
+			if err != nil {
+				t.Fatal(err)
+			}
+			claims, err := maker.VerifyToken(token, AccessToken)
+			if err != nil {
+				t.Fatalf("VerifyToken: %v", err)
+			}
+			if claims.ID != userID || claims.Email != "ada@example.com" || !claims.IsAdmin || claims.SessionID != "session-1" {
+				t.Fatalf("got %+v", claims)
+			}
+		})
+	}
+}
+
+func TestVerifyTokenAcceptsRetiredKeys(t *testing.T) {
+	old := newEd25519Signer(t, "2026-04")
+	token, _, err := mustMaker(t, "", old).CreateToken("s", uuid.New(), "ada@example.com", false, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// After rotation only the public half of the old key is kept
+	rotated := mustMaker(t, "2026-10", newEd25519Signer(t, "2026-10"), publicOnly(old))
+	if _, err := rotated.VerifyToken(token, AccessToken); err != nil {
+		t.Fatalf("token signed by the retired key: %v", err)
+	}
+	fresh, _, err := rotated.CreateToken("s", uuid.New(), "ada@example.com", false, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed, _, err := jwt.NewParser().ParseUnverified(fresh, &UserClaims{})
+	if err != nil || parsed.Header["kid"] != "2026-10" {
+		t.Fatalf("new token kid = %v (%v), want the active key", parsed.Header["kid"], err)
+	}
+
+	// Once the old key is dropped its tokens stop verifying
+	if _, err := mustMaker(t, "", newEd25519Signer(t, "2026-10")).VerifyToken(token, AccessToken); err == nil {
+		t.Fatal("token signed by a removed key verified")
+	}
+}
+
+func TestVerifyTokenChecksTheTokenType(t *testing.T) {
+	maker := mustMaker(t, "", newEd25519Signer(t, "ed"))
+	access, _, err := maker.CreateToken("s", uuid.New(), "ada@example.com", false, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	refresh, _, err := maker.CreateRefreshToken("s", uuid.New(), "ada@example.com", false, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := maker.VerifyToken(refresh, AccessToken); err == nil {
+		t.Error("refresh token accepted as an access token")
+	}
+	if _, err := maker.VerifyToken(access, RefreshToken); err == nil {
+		t.Error("access token accepted as a refresh token")
+	}
+	if claims, err := maker.VerifyToken(refresh, RefreshToken); err != nil || claims.Type != RefreshToken {
+		t.Errorf("refresh token = %+v, %v", claims, err)
+	}
+}
+
+func TestVerifyTokenRejectsForgedTokens(t *testing.T) {
+	rsaKey := newRSASigner(t, "rsa")
+	edKey := newEd25519Signer(t, "ed")
+	maker := mustMaker(t, "ed", edKey, rsaKey)
+	rsaPublicDER, err := x509.MarshalPKIXPublicKey(rsaKey.Public)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	valid, _, err := maker.CreateToken("s", uuid.New(), "ada@example.com", false, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expired, _, err := maker.CreateToken("s", uuid.New(), "ada@example.com", false, -time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]string{
+		"unknown kid": forge(t, jwt.SigningMethodEdDSA, "missing", edKey.Private),
+		"no kid":      forge(t, jwt.SigningMethodEdDSA, "", edKey.Private),
+		// The public key is known to everyone; it must never work as an HMAC secret
+		"HS256 with the public key": forge(t, jwt.SigningMethodHS256, "rsa", rsaPublicDER),
+		"none":                      forge(t, jwt.SigningMethodNone, "ed", jwt.UnsafeAllowNoneSignatureType),
+		"RS256 under an EdDSA kid":  forge(t, jwt.SigningMethodRS256, "ed", rsaKey.Private),
+		"EdDSA under an RS256 kid":  forge(t, jwt.SigningMethodEdDSA, "rsa", edKey.Private),
+		"other EdDSA key":           forge(t, jwt.SigningMethodEdDSA, "ed", testEd25519Key(t)),
+		"tampered signature":        valid[:len(valid)-4] + "AAAA",
+		"expired":                   expired,
+		"garbage":                   "not.a.token",
+	}
+	for name, token := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := maker.VerifyToken(token, AccessToken); err == nil {
+				t.Fatal("forged token verified")
+			}
+		})
+	}
+}
@@ -0,0 +1,16 @@
+package utils
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+)
+
+// RandomToken returns n bytes from crypto/rand encoded as unpadded base64url
+func RandomToken(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("error generating random token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,190 @@
+// Package validation checks request structs against their `validate` tags and
+// reports every violation at once. Field names follow the struct's json tags.
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+
+	"github.com/go-playground/validator/v10"
+)
+
+const (
+	PasswordMinLength = 8
+	PasswordMaxLength = 72 // bcrypt ignores anything longer
+)
+
+// Violation is one field that failed a rule
+type Violation struct {
+	Field   string // json name, dotted for nested fields, e.g. items[0].quantity
+	Code    string // required, invalid, too_short, too_long, out_of_range, not_allowed, weak_password
+	Message string
+}
+
+// Error lists every violation found in a request
+type Error struct {
+	Violations []Violation
+}
+
+func (e *Error) Error() string {
+	msgs := make([]string, 0, len(e.Violations))
+	for _, v := range e.Violations {
+		msgs = append(msgs, v.Field+": "+v.Message)
+	}
+	return "invalid request: " + strings.Join(msgs, "; ")
+}
+
+var validate = newValidator()
+
+func newValidator() *validator.Validate {
+	v := validator.New(validator.WithRequiredStructEnabled())
+	v.RegisterTagNameFunc(func(f reflect.StructField) string {
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			return ""
+		}
+		if name == "" {
+			return snakeCase(f.Name)
+		}
+		return name
+	})
+	v.RegisterValidation("password", func(fl validator.FieldLevel) bool {
+		return StrongPassword(fl.Field().String())
+	})
+	v.RegisterValidation("notblank", func(fl validator.FieldLevel) bool {
+		return strings.TrimSpace(fl.Field().String()) != ""
+	})
+	return v
+}
+
+// Struct validates s and returns an *Error listing every violation, or nil
+func Struct(s any) error {
+	err := validate.Struct(s)
+	if err == nil {
+		return nil
+	}
+	var verrs validator.ValidationErrors
+	if !errors.As(err, &verrs) {
+		return fmt.Errorf("error validating request: %w", err)
+	}
+	out := &Error{Violations: make([]Violation, 0, len(verrs))}
+	for _, fe := range verrs {
+		out.Violations = append(out.Violations, toViolation(fe))
+	}
+	return out
+}
+
+// StrongPassword reports whether p satisfies the password policy: 8 to 72 bytes
+// with at least one letter and one digit
+func StrongPassword(p string) bool {
+	if len(p) < PasswordMinLength || len(p) > PasswordMaxLength {
+		return false
+	}
+	var letter, digit bool
+	for _, r := range p {
+		switch {
+		case unicode.IsLetter(r):
+			letter = true
+		case unicode.IsDigit(r):
+			digit = true
+		}
+	}
+	return letter && digit
+}
+
+func toViolation(fe validator.FieldError) Violation {
+	v := Violation{Field: fieldPath(fe)}
+	switch fe.Tag() {
+	case "required", "notblank", "required_with", "required_without":
+		v.Code, v.Message = "required", "is required"
+	case "email":
+		v.Code, v.Message = "invalid", "must be a valid email address"
+	case "password":
+		v.Code = "weak_password"
+		v.Message = fmt.Sprintf("must be %d to %d characters with at least one letter and one digit", PasswordMinLength, PasswordMaxLength)
+	case "oneof":
+		v.Code, v.Message = "not_allowed", "must be one of: "+strings.Join(strings.Fields(fe.Param()), ", ")
+	case "min", "max", "len":
+		v.Code, v.Message = lengthOrRange(fe)
+	case "gt", "gte", "lt", "lte":
+		v.Code, v.Message = "out_of_range", "must be "+comparison(fe.Tag())+" "+fe.Param()
+	default:
+		v.Code, v.Message = "invalid", "is invalid"
+	}
+	return v
+}
+
+// lengthOrRange words min/max/len rules, which mean a length for strings and slices and a value for numbers
+func lengthOrRange(fe validator.FieldError) (string, string) {
+	switch fe.Kind() {
+	case reflect.String, reflect.Slice, reflect.Map, reflect.Array:
+		unit := "characters"
+		if fe.Kind() != reflect.String {
+			unit = "entries"
+		}
+		switch fe.Tag() {
+		case "min":
+			return "too_short", fmt.Sprintf("must have at least %s %s", fe.Param(), unit)
+		case "max":
+			return "too_long", fmt.Sprintf("must have at most %s %s", fe.Param(), unit)
+		default:
+			return "invalid", fmt.Sprintf("must have exactly %s %s", fe.Param(), unit)
+		}
+	default:
+		switch fe.Tag() {
+		case "min":
+			return "out_of_range", "must be at least " + fe.Param()
+		case "max":
+			return "out_of_range", "must be at most " + fe.Param()
+		default:
+			return "out_of_range", "must be " + fe.Param()
+		}
+	}
+}
+
+func comparison(tag string) string {
+	switch tag {
+	case "gt":
+		return "greater than"
+	case "gte":
+		return "at least"
+	case "lt":
+		return "less than"
+	default:
+		return "at most"
+	}
+}
+
+// fieldPath drops the top-level struct name from the namespace, e.g. PlaceOrderReq.items[0].quantity
+func fieldPath(fe validator.FieldError) string {
+	ns := fe.Namespace()
+	if _, rest, ok := strings.Cut(ns, "."); ok {
+		return rest
+	}
+	return fe.Field()
+}
+
+func snakeCase(s string) string {
+	var b strings.Builder
+	runes := []rune(s)
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			// Start a new word at a lower-to-upper change or at the end of an acronym (APIKey),
+			// but keep the "s" of a plural acronym (IDs) with it
+			if i > 0 && (unicode.IsLower(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]) && unicode.IsUpper(runes[i-1]) && !pluralEnd(runes, i+1))) {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// pluralEnd reports whether runes[i] is the "s" closing a plural acronym such as IDs or URLsCount
+func pluralEnd(runes []rune, i int) bool {
+	return runes[i] == 's' && (i+1 == len(runes) || unicode.IsUpper(runes[i+1]))
+}
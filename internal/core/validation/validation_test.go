@@ -0,0 +1,162 @@
+package validation
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestStrongPassword(t *testing.T) {
+	tests := []struct {
+		name     string
+		password string
+		want     bool
+	}{
+		{"7 bytes", "abcdef1", false},
+		{"8 bytes", "abcdefg1", true},
+		{"72 bytes", strings.Repeat("a", 71) + "1", true},
+		{"73 bytes", strings.Repeat("a", 72) + "1", false},
+		{"no digit", "abcdefgh", false},
+		{"no letter", "12345678", false},
+		{"symbols with a letter and digit", "!!!!!!a1", true},
+		{"non-ASCII letter", "éééééé12", true},
+		{"multi-byte runes over 72 bytes", strings.Repeat("é", 36) + "1", false}, // 36 runes but 73 bytes
+		{"empty", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := StrongPassword(tt.password); got != tt.want {
+				t.Fatalf("StrongPassword(%d bytes) = %v, want %v", len(tt.password), got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSnakeCase(t *testing.T) {
+	tests := map[string]string{
+		"Name":       "name",
+		"UserID":     "user_id",
+		"APIKey":     "api_key",
+		"IDs":        "ids",
+		"ProductIDs": "product_ids",
+		"URLsCount":  "urls_count",
+		"HTTPServer": "http_server",
+		"ExpiresIn":  "expires_in",
+		"A":          "a",
+	}
+	for in, want := range tests {
+		if got := snakeCase(in); got != want {
+			t.Errorf("snakeCase(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+type item struct {
+	ProductID string `json:"product_id" validate:"required"`
+	Quantity  int    `json:"quantity" validate:"gt=0,lte=100"`
+}
+
+type request struct {
+	Name       string   `json:"name" validate:"notblank,min=2,max=5"`
+	Email      string   `json:"email" validate:"required,email"`
+	Password   string   `json:"password" validate:"password"`
+	Status     string   `json:"status" validate:"oneof=pending shipped"`
+	Age        int      `json:"age" validate:"min=18,max=130"`
+	Tags       []string `json:"tags" validate:"max=2"`
+	Items      []item   `json:"items" validate:"dive"`
+	APIKeyName string   `validate:"max=3"` // No json tag, the name is derived
+}
+
+func valid() request {
+	return request{
+		Name:       "Ada",
+		Email:      "ada@example.com",
+		Password:   "correct-horse-1",
+		Status:     "pending",
+		Age:        36,
+		Items:      []item{{ProductID: "p1", Quantity: 1}},
+		APIKeyName: "ci",
+	}
+}
+
+// violations validates r and returns its violations by field
+func violations(t *testing.T, r request) map[string]Violation {
+	t.Helper()
+	err := Struct(r)
+	if err == nil {
+		return nil
+	}
+	var verr *Error
+	if !errors.As(err, &verr) {
+		t.Fatalf("err = %v, want *Error", err)
+	}
+	byField := make(map[string]Violation, len(verr.Violations))
+	for _, v := range verr.Violations {
+		byField[v.Field] = v
+	}
+	return byField
+}
+
+func TestStructAcceptsValidRequest(t *testing.T) {
+	if err := Struct(valid()); err != nil {
+		t.Fatalf("Struct = %v, want nil", err)
+	}
+}
+
+func TestStructMapsRulesToCodes(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(*request)
+		field   string
+		code    string
+		message string
+	}{
+		{"blank", func(r *request) { r.Name = "   " }, "name", "required", "is required"},
+		{"string min", func(r *request) { r.Name = "A" }, "name", "too_short", "must have at least 2 characters"},
+		{"string max", func(r *request) { r.Name = "Adelaide" }, "name", "too_long", "must have at most 5 characters"},
+		{"email", func(r *request) { r.Email = "not-an-email" }, "email", "invalid", "must be a valid email address"},
+		{"password", func(r *request) { r.Password = "password" }, "password", "weak_password", "must be 8 to 72 characters with at least one letter and one digit"},
+		{"oneof", func(r *request) { r.Status = "lost" }, "status", "not_allowed", "must be one of: pending, shipped"},
+		{"number min", func(r *request) { r.Age = 17 }, "age", "out_of_range", "must be at least 18"},
+		{"number max", func(r *request) { r.Age = 131 }, "age", "out_of_range", "must be at most 130"},
+		{"slice max", func(r *request) { r.Tags = []string{"a", "b", "c"} }, "tags", "too_long", "must have at most 2 entries"},
+		{"gt", func(r *request) { r.Items[0].Quantity = 0 }, "items[0].quantity", "out_of_range", "must be greater than 0"},
+		{"lte", func(r *request) { r.Items[0].Quantity = 101 }, "items[0].quantity", "out_of_range", "must be at most 100"},
+		{"nested required", func(r *request) { r.Items = append(r.Items, item{Quantity: 1}) }, "items[1].product_id", "required", "is required"},
+		{"derived name", func(r *request) { r.APIKeyName = "deploy" }, "api_key_name", "too_long", "must have at most 3 characters"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := valid()
+			tt.mutate(&r)
+			got := violations(t, r)
+			if len(got) != 1 {
+				t.Fatalf("got violations %v, want one on %s", got, tt.field)
+			}
+			v, ok := got[tt.field]
+			if !ok {
+				t.Fatalf("got violations %v, want one on %s", got, tt.field)
+			}
+			if v.Code != tt.code || v.Message != tt.message {
+				t.Fatalf("%s: got %q %q, want %q %q", tt.field, v.Code, v.Message, tt.code, tt.message)
+			}
+		})
+	}
+}
+
+func TestStructReportsEveryViolation(t *testing.T) {
+	r := valid()
+	r.Name, r.Email, r.Items[0].Quantity = "", "", 0
+	got := violations(t, r)
+	for _, field := range []string{"name", "email", "items[0].quantity"} {
+		if _, ok := got[field]; !ok {
+			t.Errorf("missing violation on %s", field)
+		}
+	}
+	if len(got) != 3 {
+		t.Fatalf("got %d violations, want 3", len(got))
+	}
+	if err := Struct(r); !strings.HasPrefix(err.Error(), "invalid request: ") {
+		t.Fatalf("Error() = %q", err)
+	}
+}
@@ -0,0 +1,117 @@
+// Package metrics exposes Prometheus metrics for HTTP traffic, database access
+// and business events. It implements ports.BusinessMetrics for the core services.
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+type Metrics struct {
+	registry *prometheus.Registry
+
+	httpRequests *prometheus.CounterVec
+	httpDuration *prometheus.HistogramVec
+
+	queryDuration *prometheus.HistogramVec
+	queryErrors   *prometheus.CounterVec
+
+	ordersPlaced    prometheus.Counter
+	orderValue      prometheus.Histogram
+	ordersCancelled prometheus.Counter
+	loginFailures   *prometheus.CounterVec
+}
+
+// New registers every metric, along with the Go runtime and process collectors,
+// on a fresh registry
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "http_requests_total",
+			Help: "HTTP requests by method, route pattern and status code.",
+		}, []string{"method", "route", "status"}),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "http_request_duration_seconds",
+			Help:    "HTTP request latency by method and route pattern.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"method", "route"}),
+		queryDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "db_query_duration_seconds",
+			Help:    "Database query latency by statement type and table.",
+			Buckets: []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+		}, []string{"operation", "table"}),
+		queryErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "db_query_errors_total",
+			Help: "Failed database queries by statement type and table.",
+		}, []string{"operation", "table"}),
+		ordersPlaced: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "ecommerce_orders_placed_total",
+			Help: "Orders placed.",
+		}),
+		orderValue: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Name:    "ecommerce_order_value",
+			Help:    "Total amount of placed orders.",
+			Buckets: []float64{5, 10, 25, 50, 100, 250, 500, 1000, 2500, 5000, 10000},
+		}),
+		ordersCancelled: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "ecommerce_orders_cancelled_total",
+			Help: "Orders cancelled by customers or admins.",
+		}),
+		loginFailures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "ecommerce_login_failures_total",
+			Help: "Rejected password logins by reason.",
+		}, []string{"reason"}),
+	}
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.httpRequests, m.httpDuration,
+		m.queryDuration, m.queryErrors,
+		m.ordersPlaced, m.orderValue, m.ordersCancelled, m.loginFailures,
+	)
+	return m
+}
+
+// Handler serves the metrics in the Prometheus exposition format
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
+
+// RegisterDB exports the connection pool statistics of db as go_sql_* metrics
+func (m *Metrics) RegisterDB(db *sql.DB, name string) {
+	m.registry.MustRegister(collectors.NewDBStatsCollector(db, name))
+}
+
+// ObserveHTTP records one served request; route is the ServeMux pattern that matched it
+func (m *Metrics) ObserveHTTP(method, route string, status int, d time.Duration) {
+	m.httpRequests.WithLabelValues(method, route, strconv.Itoa(status)).Inc()
+	m.httpDuration.WithLabelValues(method, route).Observe(d.Seconds())
+}
+
+// ObserveQuery records one database round trip
+func (m *Metrics) ObserveQuery(operation, table string, d time.Duration, err error) {
+	m.queryDuration.WithLabelValues(operation, table).Observe(d.Seconds())
+	if err != nil {
+		m.queryErrors.WithLabelValues(operation, table).Inc()
+	}
+}
+
+func (m *Metrics) OrderPlaced(total float64) {
+	m.ordersPlaced.Inc()
+	m.orderValue.Observe(total)
+}
+
+func (m *Metrics) OrderCancelled() {
+	m.ordersCancelled.Inc()
+}
+
+func (m *Metrics) LoginFailed(reason string) {
+	m.loginFailures.WithLabelValues(reason).Inc()
+}
@@ -0,0 +1,24 @@
+package ports
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/apikey"
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrAPIKeyNotFound wraps ErrNotFound, so either matches a missing key
+	ErrAPIKeyNotFound = fmt.Errorf("api key %w", ErrNotFound)
+)
+
+type APIKeyRepo interface {
+	Create(ctx context.Context, k apikey.APIKey) error
+	GetByHash(ctx context.Context, keyHash string) (*apikey.APIKey, error)
+	ListByUserID(ctx context.Context, userID uuid.UUID) ([]apikey.APIKey, error)
+	// Revoke returns ErrAPIKeyNotFound unless the key belongs to userID and is not revoked yet
+	Revoke(ctx context.Context, id, userID uuid.UUID) error
+	TouchLastUsed(ctx context.Context, id uuid.UUID, at time.Time) error
+}
@@ -0,0 +1,14 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/audit"
+	"github.com/google/uuid"
+)
+
+type AuditRepo interface {
+	Record(ctx context.Context, e audit.Entry) error
+	// List returns the newest entries first, only those about targetUserID unless it is uuid.Nil
+	List(ctx context.Context, targetUserID uuid.UUID, limit, offset int) ([]audit.Entry, error)
+}
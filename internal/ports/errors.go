@@ -0,0 +1,11 @@
+package ports
+
+import "errors"
+
+// Every repository reports missing rows and constraint violations with these,
+// wrapped, so services can tell them apart from infrastructure failures
+var (
+	ErrNotFound  = errors.New("record not found")
+	ErrConflict  = errors.New("record conflicts with an existing one")
+	ErrReference = errors.New("record references a missing record or is still referenced")
+)
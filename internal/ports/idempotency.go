@@ -0,0 +1,27 @@
+package ports
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/idempotency"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrIdempotencyKeyExists = errors.New("idempotency key already in use")
+)
+
+type IdempotencyRepo interface {
+	// CreateKey stores a new in-progress record. A record already holding the key is
+	// replaced when it has expired or is still in progress but was created before
+	// staleBefore; otherwise ErrIdempotencyKeyExists is returned.
+	CreateKey(ctx context.Context, r idempotency.Record, staleBefore time.Time) error
+	GetKey(ctx context.Context, userID uuid.UUID, key string) (*idempotency.Record, error)
+	// CompleteKey stores the response of an in-progress record
+	CompleteKey(ctx context.Context, r idempotency.Record) error
+	// DeleteKey removes the record if its request is still in progress
+	DeleteKey(ctx context.Context, userID uuid.UUID, key string) error
+	DeleteExpiredKeys(ctx context.Context, before time.Time) (int64, error)
+}
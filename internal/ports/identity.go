@@ -0,0 +1,29 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/identity"
+	"github.com/google/uuid"
+)
+
+// IdentityProvider is an external OpenID Connect provider using the authorization code flow with PKCE
+type IdentityProvider interface {
+	Name() string
+	// AuthCodeURL builds the provider's authorization URL, sending only the S256 challenge of codeVerifier
+	AuthCodeURL(state, nonce, codeVerifier string) string
+	// Exchange redeems the authorization code and returns the verified ID token claims
+	Exchange(ctx context.Context, code, codeVerifier, nonce string) (*identity.External, error)
+}
+
+type IdentityRepo interface {
+	Create(ctx context.Context, i identity.Identity) error
+	GetByProviderSubject(ctx context.Context, provider, subject string) (*identity.Identity, error)
+	ListByUserID(ctx context.Context, userID uuid.UUID) ([]identity.Identity, error)
+}
+
+type OIDCStateRepo interface {
+	Create(ctx context.Context, s identity.LoginState) error
+	// Take returns the state and deletes it, so each authorization response is accepted once
+	Take(ctx context.Context, state string) (*identity.LoginState, error)
+}
@@ -0,0 +1,18 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/lockout"
+)
+
+type LoginAttemptRepo interface {
+	// Get returns the recorded failures, or zero Attempts if the subject has none
+	Get(ctx context.Context, scope lockout.Scope, subject string) (*lockout.Attempts, error)
+	// RecordFailure counts one failed attempt with Attempts.RecordFailure and returns the
+	// updated counter. Concurrent calls for the same subject are serialised, so every
+	// failure is counted.
+	RecordFailure(ctx context.Context, scope lockout.Scope, subject string, p lockout.Policy, maxAttempts int, now time.Time) (*lockout.Attempts, error)
+	Reset(ctx context.Context, scope lockout.Scope, subject string) error
+}
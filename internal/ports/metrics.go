@@ -0,0 +1,11 @@
+package ports
+
+// BusinessMetrics records business events for monitoring. Implementations must
+// be safe for concurrent use and must not fail the operation being recorded.
+type BusinessMetrics interface {
+	OrderPlaced(total float64)
+	OrderCancelled()
+	// LoginFailed counts a rejected password login; reason is one of
+	// invalid_credentials, throttled or suspended
+	LoginFailed(reason string)
+}
@@ -0,0 +1,87 @@
+package porttest
+
+import (
+	"testing"
+
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/address"
+	"github.com/google/uuid"
+)
+
+func testAddressRepo(t *testing.T, newRepos Factory) {
+	t.Run("CreateAndGet", func(t *testing.T) {
+		r := newRepos(t)
+		u := createUser(t, r, "ada@example.com")
+		want := createAddress(t, r, u.ID)
+
+		got, err := r.Addresses.GetByID(t.Context(), want.ID)
+		mustNoError(t, err)
+		if got != want {
+			t.Fatalf("got %+v, want %+v", got, want)
+		}
+		_, err = r.Addresses.GetByID(t.Context(), uuid.New())
+		mustBeNotFound(t, err)
+	})
+
+	t.Run("RequiresUser", func(t *testing.T) {
+		r := newRepos(t)
+		_, err := r.Addresses.Create(t.Context(), address.New(uuid.New(), "1 Main Street", "Springfield", "IL", "62701", "United States"))
+		mustBreakReference(t, err, "address for a missing user")
+	})
+
+	t.Run("Default", func(t *testing.T) {
+		r := newRepos(t)
+		u := createUser(t, r, "ada@example.com")
+		other := createUser(t, r, "grace@example.com")
+		a := createAddress(t, r, u.ID)
+		b := createAddress(t, r, u.ID)
+		foreign := createAddress(t, r, other.ID)
+
+		_, err := r.Addresses.GetDefault(t.Context(), u.ID)
+		mustBeNotFound(t, err)
+
+		mustNoError(t, r.Addresses.SetDefault(t.Context(), u.ID, a.ID))
+		mustNoError(t, r.Addresses.SetDefault(t.Context(), u.ID, b.ID))
+		got, err := r.Addresses.GetDefault(t.Context(), u.ID)
+		mustNoError(t, err)
+		if got.ID != b.ID {
+			t.Fatalf("default = %s, want %s", got.ID, b.ID)
+		}
+
+		list, err := r.Addresses.ListByUserID(t.Context(), u.ID)
+		mustNoError(t, err)
+		if len(list) != 2 || list[0].ID != b.ID || list[1].IsDefault {
+			t.Fatalf("got %+v, want the default address first", list)
+		}
+
+		// Another user's address, or a missing one, never becomes the default and keeps the old one
+		mustBeNotFound(t, r.Addresses.SetDefault(t.Context(), u.ID, foreign.ID))
+		mustBeNotFound(t, r.Addresses.SetDefault(t.Context(), u.ID, uuid.New()))
+		got, err = r.Addresses.GetByID(t.Context(), foreign.ID)
+		mustNoError(t, err)
+		if got.IsDefault {
+			t.Fatal("another user's address became the default")
+		}
+		got, err = r.Addresses.GetDefault(t.Context(), u.ID)
+		mustNoError(t, err)
+		if got.ID != b.ID {
+			t.Fatalf("default = %s after a refused change, want %s", got.ID, b.ID)
+		}
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		r := newRepos(t)
+		u := createUser(t, r, "ada@example.com")
+		unused := createAddress(t, r, u.ID)
+		used := createAddress(t, r, u.ID)
+		createOrder(t, r, u.ID, used.ID)
+
+		mustNoError(t, r.Addresses.DeleteById(t.Context(), unused.ID))
+		_, err := r.Addresses.GetByID(t.Context(), unused.ID)
+		mustBeNotFound(t, err)
+
+		// Orders keep the address they were shipped to
+		mustBreakReference(t, r.Addresses.DeleteById(t.Context(), used.ID), "deleting an address an order references")
+		_, err = r.Addresses.GetByID(t.Context(), used.ID)
+		mustNoError(t, err)
+	})
+}
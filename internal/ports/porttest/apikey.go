@@ -0,0 +1,92 @@
+package porttest
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/apikey"
+	"github.com/frostnzx/go-ecommerce-api/internal/ports"
+	"github.com/google/uuid"
+)
+
+func testAPIKeyRepo(t *testing.T, newRepos Factory) {
+	newKey := func(userID uuid.UUID, hash string, createdAt time.Time) apikey.APIKey {
+		k := apikey.New(userID, "CI", "ecom_abc", hash, apikey.Scopes{apikey.ScopeRead, apikey.ScopeWrite}, createdAt.Add(24*time.Hour))
+		k.CreatedAt = createdAt
+		return k
+	}
+
+	t.Run("CreateAndGet", func(t *testing.T) {
+		r := newRepos(t)
+		u := createUser(t, r, "ada@example.com")
+		want := newKey(u.ID, "hash-1", baseTime)
+		mustNoError(t, r.APIKeys.Create(t.Context(), want))
+
+		got, err := r.APIKeys.GetByHash(t.Context(), "hash-1")
+		mustNoError(t, err)
+		if got.ID != want.ID || got.UserID != u.ID || got.Prefix != want.Prefix || len(got.Scopes) != 2 || got.Scopes[1] != apikey.ScopeWrite ||
+			!sameTime(got.ExpiresAt, want.ExpiresAt) || got.LastUsedAt != nil || got.RevokedAt != nil {
+			t.Fatalf("got %+v, want %+v", got, want)
+		}
+		_, err = r.APIKeys.GetByHash(t.Context(), "unknown")
+		mustBeNotFound(t, err)
+
+		mustConflict(t, r.APIKeys.Create(t.Context(), newKey(u.ID, "hash-1", baseTime)), "second key with the same hash")
+		mustBreakReference(t, r.APIKeys.Create(t.Context(), newKey(uuid.New(), "hash-2", baseTime)), "key for a missing user")
+	})
+
+	t.Run("ListNewestFirst", func(t *testing.T) {
+		r := newRepos(t)
+		u := createUser(t, r, "ada@example.com")
+		other := createUser(t, r, "grace@example.com")
+		older := newKey(u.ID, "hash-1", baseTime)
+		newer := newKey(u.ID, "hash-2", baseTime.Add(time.Minute))
+		for _, k := range []apikey.APIKey{older, newer, newKey(other.ID, "hash-3", baseTime)} {
+			mustNoError(t, r.APIKeys.Create(t.Context(), k))
+		}
+
+		got, err := r.APIKeys.ListByUserID(t.Context(), u.ID)
+		mustNoError(t, err)
+		if len(got) != 2 || got[0].ID != newer.ID || got[1].ID != older.ID {
+			t.Fatalf("got %d keys, want the user's 2 newest first", len(got))
+		}
+	})
+
+	t.Run("RevokeAndTouch", func(t *testing.T) {
+		r := newRepos(t)
+		u := createUser(t, r, "ada@example.com")
+		other := createUser(t, r, "grace@example.com")
+		k := newKey(u.ID, "hash-1", baseTime)
+		mustNoError(t, r.APIKeys.Create(t.Context(), k))
+
+		used := baseTime.Add(time.Hour)
+		mustNoError(t, r.APIKeys.TouchLastUsed(t.Context(), k.ID, used))
+
+		// Only the owner can revoke, and only once
+		if err := r.APIKeys.Revoke(t.Context(), k.ID, other.ID); !errors.Is(err, ports.ErrAPIKeyNotFound) {
+			t.Fatalf("revoking another user's key: err = %v, want ErrAPIKeyNotFound", err)
+		}
+		mustNoError(t, r.APIKeys.Revoke(t.Context(), k.ID, u.ID))
+		if err := r.APIKeys.Revoke(t.Context(), k.ID, u.ID); !errors.Is(err, ports.ErrAPIKeyNotFound) {
+			t.Fatalf("second revoke: err = %v, want ErrAPIKeyNotFound", err)
+		}
+		mustBeNotFound(t, r.APIKeys.Revoke(t.Context(), uuid.New(), u.ID))
+
+		got, err := r.APIKeys.GetByHash(t.Context(), "hash-1")
+		mustNoError(t, err)
+		if got.RevokedAt == nil || got.LastUsedAt == nil || !sameTime(*got.LastUsedAt, used) {
+			t.Fatalf("got RevokedAt=%v LastUsedAt=%v", got.RevokedAt, got.LastUsedAt)
+		}
+	})
+
+	t.Run("DeletedWithUser", func(t *testing.T) {
+		r := newRepos(t)
+		u := createUser(t, r, "ada@example.com")
+		mustNoError(t, r.APIKeys.Create(t.Context(), newKey(u.ID, "hash-1", baseTime)))
+
+		mustNoError(t, r.Users.DeleteUser(t.Context(), u.ID))
+		_, err := r.APIKeys.GetByHash(t.Context(), "hash-1")
+		mustBeNotFound(t, err)
+	})
+}
@@ -0,0 +1,60 @@
+package porttest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/audit"
+	"github.com/google/uuid"
+)
+
+func testAuditRepo(t *testing.T, newRepos Factory) {
+	t.Run("RecordAndList", func(t *testing.T) {
+		r := newRepos(t)
+		admin := createUser(t, r, "root@example.com")
+		ada := createUser(t, r, "ada@example.com")
+		grace := createUser(t, r, "grace@example.com")
+
+		var recorded []audit.Entry
+		for i, target := range []uuid.UUID{ada.ID, grace.ID, ada.ID} {
+			e := audit.New(admin.ID, audit.ActionSuspendUser, target, "reason")
+			e.CreatedAt = baseTime.Add(time.Duration(i) * time.Minute)
+			mustNoError(t, r.Audit.Record(t.Context(), e))
+			recorded = append(recorded, e)
+		}
+
+		all, err := r.Audit.List(t.Context(), uuid.Nil, 10, 0)
+		mustNoError(t, err)
+		if len(all) != 3 || all[0].ID != recorded[2].ID || all[2].ID != recorded[0].ID {
+			t.Fatalf("got %d entries, want all 3 newest first", len(all))
+		}
+		if got := all[0]; got.ActorID != admin.ID || got.Action != audit.ActionSuspendUser || got.Details != "reason" || !sameTime(got.CreatedAt, recorded[2].CreatedAt) {
+			t.Fatalf("got %+v, want %+v", got, recorded[2])
+		}
+
+		about, err := r.Audit.List(t.Context(), ada.ID, 10, 0)
+		mustNoError(t, err)
+		if len(about) != 2 || about[0].ID != recorded[2].ID || about[1].ID != recorded[0].ID {
+			t.Fatalf("got %d entries about the user, want 2 newest first", len(about))
+		}
+		paged, err := r.Audit.List(t.Context(), uuid.Nil, 1, 1)
+		mustNoError(t, err)
+		if len(paged) != 1 || paged[0].ID != recorded[1].ID {
+			t.Fatalf("second page = %v, want the middle entry", paged)
+		}
+		paged, err = r.Audit.List(t.Context(), uuid.Nil, 10, 5)
+		mustNoError(t, err)
+		if len(paged) != 0 {
+			t.Fatalf("page past the end has %d entries, want none", len(paged))
+		}
+
+		// The trail outlives the accounts it mentions
+		mustNoError(t, r.Users.DeleteUser(t.Context(), ada.ID))
+		mustNoError(t, r.Users.DeleteUser(t.Context(), admin.ID))
+		about, err = r.Audit.List(t.Context(), ada.ID, 10, 0)
+		mustNoError(t, err)
+		if len(about) != 2 {
+			t.Fatalf("got %d entries after the users were deleted, want 2", len(about))
+		}
+	})
+}
@@ -0,0 +1,50 @@
+package porttest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/identity"
+	"github.com/google/uuid"
+)
+
+func testIdentityRepo(t *testing.T, newRepos Factory) {
+	t.Run("CreateGetAndList", func(t *testing.T) {
+		r := newRepos(t)
+		u := createUser(t, r, "ada@example.com")
+		google := identity.New(u.ID, "google", "sub-1", u.Email)
+		google.CreatedAt = baseTime
+		github := identity.New(u.ID, "github", "sub-1", u.Email)
+		github.CreatedAt = baseTime.Add(time.Minute)
+		for _, i := range []identity.Identity{github, google} {
+			mustNoError(t, r.Identities.Create(t.Context(), i))
+		}
+
+		got, err := r.Identities.GetByProviderSubject(t.Context(), "google", "sub-1")
+		mustNoError(t, err)
+		if got.ID != google.ID || got.UserID != u.ID || got.Email != u.Email || !sameTime(got.CreatedAt, baseTime) {
+			t.Fatalf("got %+v, want %+v", got, google)
+		}
+		_, err = r.Identities.GetByProviderSubject(t.Context(), "google", "sub-2")
+		mustBeNotFound(t, err)
+
+		list, err := r.Identities.ListByUserID(t.Context(), u.ID)
+		mustNoError(t, err)
+		if len(list) != 2 || list[0].ID != google.ID || list[1].ID != github.ID {
+			t.Fatalf("got %d identities, want both oldest first", len(list))
+		}
+
+		mustConflict(t, r.Identities.Create(t.Context(), identity.New(u.ID, "google", "sub-1", u.Email)), "second link of the same provider subject")
+		mustBreakReference(t, r.Identities.Create(t.Context(), identity.New(uuid.New(), "google", "sub-3", u.Email)), "identity of a missing user")
+	})
+
+	t.Run("DeletedWithUser", func(t *testing.T) {
+		r := newRepos(t)
+		u := createUser(t, r, "ada@example.com")
+		mustNoError(t, r.Identities.Create(t.Context(), identity.New(u.ID, "google", "sub-1", u.Email)))
+		mustNoError(t, r.Users.DeleteUser(t.Context(), u.ID))
+
+		_, err := r.Identities.GetByProviderSubject(t.Context(), "google", "sub-1")
+		mustBeNotFound(t, err)
+	})
+}
@@ -0,0 +1,80 @@
+package porttest
+
+import (
+	"testing"
+
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/items"
+	"github.com/google/uuid"
+)
+
+func testItemsRepo(t *testing.T, newRepos Factory) {
+	t.Run("CreateAndGet", func(t *testing.T) {
+		r := newRepos(t)
+		u := createUser(t, r, "ada@example.com")
+		a := createAddress(t, r, u.ID)
+		p := createProduct(t, r, "SKU-1")
+		o := createOrder(t, r, u.ID, a.ID)
+		want := createItem(t, r, o.ID, p.ID)
+
+		got, err := r.Items.GetByID(t.Context(), want.ID)
+		mustNoError(t, err)
+		if got != want {
+			t.Fatalf("got %+v, want %+v", got, want)
+		}
+		_, err = r.Items.GetByID(t.Context(), uuid.New())
+		mustBeNotFound(t, err)
+	})
+
+	t.Run("RequiresOrderAndProduct", func(t *testing.T) {
+		r := newRepos(t)
+		u := createUser(t, r, "ada@example.com")
+		a := createAddress(t, r, u.ID)
+		p := createProduct(t, r, "SKU-1")
+		o := createOrder(t, r, u.ID, a.ID)
+
+		_, err := r.Items.Create(t.Context(), items.New(uuid.New(), p.ID, 1, 1))
+		mustBreakReference(t, err, "item on a missing order")
+		_, err = r.Items.Create(t.Context(), items.New(o.ID, uuid.New(), 1, 1))
+		mustBreakReference(t, err, "item for a missing product")
+	})
+
+	t.Run("List", func(t *testing.T) {
+		r := newRepos(t)
+		u := createUser(t, r, "ada@example.com")
+		other := createUser(t, r, "grace@example.com")
+		a := createAddress(t, r, u.ID)
+		oa := createAddress(t, r, other.ID)
+		p := createProduct(t, r, "SKU-1")
+		first := createOrder(t, r, u.ID, a.ID)
+		second := createOrder(t, r, u.ID, a.ID)
+		foreign := createOrder(t, r, other.ID, oa.ID)
+		createItem(t, r, first.ID, p.ID)
+		createItem(t, r, first.ID, p.ID)
+		createItem(t, r, second.ID, p.ID)
+		createItem(t, r, foreign.ID, p.ID)
+
+		byOrder, err := r.Items.ListByOrderID(t.Context(), first.ID)
+		mustNoError(t, err)
+		if len(byOrder) != 2 {
+			t.Fatalf("got %d items on the order, want 2", len(byOrder))
+		}
+		byUser, err := r.Items.ListByUserID(t.Context(), u.ID)
+		mustNoError(t, err)
+		if len(byUser) != 3 {
+			t.Fatalf("got %d items for the user, want 3", len(byUser))
+		}
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		r := newRepos(t)
+		u := createUser(t, r, "ada@example.com")
+		a := createAddress(t, r, u.ID)
+		p := createProduct(t, r, "SKU-1")
+		o := createOrder(t, r, u.ID, a.ID)
+		it := createItem(t, r, o.ID, p.ID)
+
+		mustNoError(t, r.Items.DeleteById(t.Context(), it.ID))
+		_, err := r.Items.GetByID(t.Context(), it.ID)
+		mustBeNotFound(t, err)
+	})
+}
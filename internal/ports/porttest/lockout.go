@@ -0,0 +1,89 @@
+package porttest
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/lockout"
+)
+
+func testLoginAttemptRepo(t *testing.T, newRepos Factory) {
+	policy := lockout.DefaultPolicy()
+
+	t.Run("RecordAndReset", func(t *testing.T) {
+		r := newRepos(t)
+		got, err := r.LoginAttempts.Get(t.Context(), lockout.ScopeAccount, "ada@example.com")
+		mustNoError(t, err)
+		if got.Failures != 0 || got.LockedUntil != nil {
+			t.Fatalf("got %+v for an unknown subject, want no failures", got)
+		}
+
+		for i := 1; i <= 3; i++ {
+			got, err = r.LoginAttempts.RecordFailure(t.Context(), lockout.ScopeAccount, "ada@example.com", policy, 3, baseTime.Add(time.Duration(i)*time.Second))
+			mustNoError(t, err)
+			if got.Failures != i {
+				t.Fatalf("failures = %d, want %d", got.Failures, i)
+			}
+		}
+		if got.LockedUntil == nil || !sameTime(*got.LockedUntil, baseTime.Add(3*time.Second+policy.LockoutDuration)) {
+			t.Fatalf("LockedUntil = %v, want locked by the third failure", got.LockedUntil)
+		}
+		stored, err := r.LoginAttempts.Get(t.Context(), lockout.ScopeAccount, "ada@example.com")
+		mustNoError(t, err)
+		if stored.Failures != 3 || stored.LockedUntil == nil || !sameTime(stored.LastFailedAt, baseTime.Add(3*time.Second)) {
+			t.Fatalf("stored %+v", stored)
+		}
+
+		// Scopes are counted separately
+		other, err := r.LoginAttempts.Get(t.Context(), lockout.ScopeIP, "ada@example.com")
+		mustNoError(t, err)
+		if other.Failures != 0 {
+			t.Fatalf("ip scope has %d failures, want 0", other.Failures)
+		}
+
+		mustNoError(t, r.LoginAttempts.Reset(t.Context(), lockout.ScopeAccount, "ada@example.com"))
+		got, err = r.LoginAttempts.Get(t.Context(), lockout.ScopeAccount, "ada@example.com")
+		mustNoError(t, err)
+		if got.Failures != 0 || got.LockedUntil != nil {
+			t.Fatalf("got %+v after a reset", got)
+		}
+	})
+
+	t.Run("ForgetsOldFailures", func(t *testing.T) {
+		r := newRepos(t)
+		_, err := r.LoginAttempts.RecordFailure(t.Context(), lockout.ScopeIP, "192.0.2.1", policy, 5, baseTime)
+		mustNoError(t, err)
+		got, err := r.LoginAttempts.RecordFailure(t.Context(), lockout.ScopeIP, "192.0.2.1", policy, 5, baseTime.Add(policy.FailureWindow+time.Second))
+		mustNoError(t, err)
+		if got.Failures != 1 {
+			t.Fatalf("failures = %d, want the count restarted", got.Failures)
+		}
+	})
+
+	t.Run("ConcurrentFailuresAreAllCounted", func(t *testing.T) {
+		r := newRepos(t)
+		const n = 20
+		var wg sync.WaitGroup
+		errs := make(chan error, n)
+		for range n {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := r.LoginAttempts.RecordFailure(t.Context(), lockout.ScopeAccount, "ada@example.com", policy, 5, baseTime)
+				errs <- err
+			}()
+		}
+		wg.Wait()
+		close(errs)
+		for err := range errs {
+			mustNoError(t, err)
+		}
+
+		got, err := r.LoginAttempts.Get(t.Context(), lockout.ScopeAccount, "ada@example.com")
+		mustNoError(t, err)
+		if got.Failures != n || got.LockedUntil == nil {
+			t.Fatalf("failures = %d, locked = %v, want all %d counted and the account locked", got.Failures, got.LockedUntil != nil, n)
+		}
+	})
+}
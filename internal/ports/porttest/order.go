@@ -0,0 +1,76 @@
+package porttest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/order"
+	"github.com/google/uuid"
+)
+
+func testOrderRepo(t *testing.T, newRepos Factory) {
+	t.Run("CreateAndGet", func(t *testing.T) {
+		r := newRepos(t)
+		u := createUser(t, r, "ada@example.com")
+		a := createAddress(t, r, u.ID)
+		want := createOrder(t, r, u.ID, a.ID)
+
+		got, err := r.Orders.GetByID(t.Context(), want.ID)
+		mustNoError(t, err)
+		if got.UserId != u.ID || got.AddressID != a.ID || got.Status != order.OrderPending ||
+			got.TotalAmount != 25 || !sameTime(got.CreatedAt, want.CreatedAt) {
+			t.Fatalf("got %+v, want %+v", got, want)
+		}
+		_, err = r.Orders.GetByID(t.Context(), uuid.New())
+		mustBeNotFound(t, err)
+	})
+
+	t.Run("RequiresUserAndAddress", func(t *testing.T) {
+		r := newRepos(t)
+		u := createUser(t, r, "ada@example.com")
+		a := createAddress(t, r, u.ID)
+
+		_, err := r.Orders.Create(t.Context(), order.New(uuid.New(), a.ID, order.OrderPending, 10))
+		mustBreakReference(t, err, "order for a missing user")
+		_, err = r.Orders.Create(t.Context(), order.New(u.ID, uuid.New(), order.OrderPending, 10))
+		mustBreakReference(t, err, "order to a missing address")
+	})
+
+	t.Run("ListNewestFirst", func(t *testing.T) {
+		r := newRepos(t)
+		u := createUser(t, r, "ada@example.com")
+		other := createUser(t, r, "grace@example.com")
+		a := createAddress(t, r, u.ID)
+		oa := createAddress(t, r, other.ID)
+
+		var ids []uuid.UUID
+		for i := range 3 {
+			o := order.New(u.ID, a.ID, order.OrderPending, 10)
+			o.CreatedAt = baseTime.Add(time.Duration(i) * time.Minute)
+			_, err := r.Orders.Create(t.Context(), o)
+			mustNoError(t, err)
+			ids = append(ids, o.ID)
+		}
+		createOrder(t, r, other.ID, oa.ID)
+
+		got, err := r.Orders.ListByUserID(t.Context(), u.ID)
+		mustNoError(t, err)
+		if len(got) != 3 || got[0].ID != ids[2] || got[1].ID != ids[1] || got[2].ID != ids[0] {
+			t.Fatalf("got %d orders, want the user's 3 newest first", len(got))
+		}
+	})
+
+	t.Run("UpdateStatus", func(t *testing.T) {
+		r := newRepos(t)
+		u := createUser(t, r, "ada@example.com")
+		a := createAddress(t, r, u.ID)
+		o := createOrder(t, r, u.ID, a.ID)
+
+		mustNoError(t, r.Orders.UpdateStatus(t.Context(), o.ID, order.OrderShipped))
+		got, err := r.Orders.GetByID(t.Context(), o.ID)
+		mustNoError(t, err)
+		if got.Status != order.OrderShipped {
+			t.Fatalf("status = %q, want %q", got.Status, order.OrderShipped)
+		}
+	})
+}
@@ -0,0 +1,144 @@
+// Package porttest is a contract test suite for the repository ports. Each
+// adapter runs the same suite, so the in-memory adapters keep behaving like
+// the postgres ones: the same keys and foreign keys are enforced, deletes
+// cascade the same way and missing rows and violations are reported with the
+// same port errors.
+package porttest
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/address"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/items"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/order"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/product"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/session"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/user"
+	"github.com/frostnzx/go-ecommerce-api/internal/ports"
+	"github.com/google/uuid"
+)
+
+// Repos is one set of adapters over a shared store, so foreign keys and cascades
+// between them can be checked
+type Repos struct {
+	Users     ports.UserRepo
+	Sessions  ports.SessionRepo
+	Addresses ports.AddressRepo
+	Orders    ports.OrderRepo
+	Items     ports.ItemsRepo
+	Products  ports.ProductRepo
+
+	LoginAttempts ports.LoginAttemptRepo
+	APIKeys       ports.APIKeyRepo
+	Identities    ports.IdentityRepo
+	Privacy       ports.PrivacyRepo
+	Audit         ports.AuditRepo
+}
+
+// Factory returns adapters over an empty store. It is called once per test and
+// may register cleanups on t.
+type Factory func(t *testing.T) Repos
+
+// Run runs the whole suite. Tests run one after another, so a factory may reuse
+// a single database as long as it empties it each time.
+func Run(t *testing.T, newRepos Factory) {
+	t.Run("UserRepo", func(t *testing.T) { testUserRepo(t, newRepos) })
+	t.Run("SessionRepo", func(t *testing.T) { testSessionRepo(t, newRepos) })
+	t.Run("AddressRepo", func(t *testing.T) { testAddressRepo(t, newRepos) })
+	t.Run("OrderRepo", func(t *testing.T) { testOrderRepo(t, newRepos) })
+	t.Run("ItemsRepo", func(t *testing.T) { testItemsRepo(t, newRepos) })
+	t.Run("ProductRepo", func(t *testing.T) { testProductRepo(t, newRepos) })
+	t.Run("LoginAttemptRepo", func(t *testing.T) { testLoginAttemptRepo(t, newRepos) })
+	t.Run("APIKeyRepo", func(t *testing.T) { testAPIKeyRepo(t, newRepos) })
+	t.Run("IdentityRepo", func(t *testing.T) { testIdentityRepo(t, newRepos) })
+	t.Run("PrivacyRepo", func(t *testing.T) { testPrivacyRepo(t, newRepos) })
+	t.Run("AuditRepo", func(t *testing.T) { testAuditRepo(t, newRepos) })
+}
+
+// baseTime has microsecond precision, which every adapter keeps
+var baseTime = time.Date(2026, 3, 14, 15, 9, 26, 535897000, time.UTC)
+
+func mustNoError(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func mustBeNotFound(t *testing.T, err error) {
+	t.Helper()
+	if !errors.Is(err, ports.ErrNotFound) {
+		t.Fatalf("err = %v, want ErrNotFound", err)
+	}
+}
+
+func mustConflict(t *testing.T, err error, what string) {
+	t.Helper()
+	if !errors.Is(err, ports.ErrConflict) {
+		t.Fatalf("%s: err = %v, want ErrConflict", what, err)
+	}
+}
+
+func mustBreakReference(t *testing.T, err error, what string) {
+	t.Helper()
+	if !errors.Is(err, ports.ErrReference) {
+		t.Fatalf("%s: err = %v, want ErrReference", what, err)
+	}
+}
+
+func sameTime(a, b time.Time) bool {
+	return a.Round(time.Microsecond).Equal(b.Round(time.Microsecond))
+}
+
+// Fixtures
+
+func createUser(t *testing.T, r Repos, email string) user.User {
+	t.Helper()
+	u := user.New(email, "hash-"+email, "User "+email, false)
+	u.CreatedAt = baseTime
+	mustNoError(t, r.Users.Create(t.Context(), u))
+	return u
+}
+
+func createAddress(t *testing.T, r Repos, userID uuid.UUID) address.Address {
+	t.Helper()
+	a, err := r.Addresses.Create(t.Context(), address.New(userID, "1 Main Street", "Springfield", "IL", "62701", "United States"))
+	mustNoError(t, err)
+	return a
+}
+
+func createProduct(t *testing.T, r Repos, sku string) product.Product {
+	t.Helper()
+	p := product.New(sku, "Product "+sku, "A product", 12.5, 10)
+	p.CreatedAt = baseTime
+	created, err := r.Products.Create(t.Context(), p)
+	mustNoError(t, err)
+	return created
+}
+
+func createOrder(t *testing.T, r Repos, userID, addressID uuid.UUID) order.Order {
+	t.Helper()
+	o := order.New(userID, addressID, order.OrderPending, 25)
+	o.CreatedAt = baseTime
+	created, err := r.Orders.Create(t.Context(), o)
+	mustNoError(t, err)
+	return created
+}
+
+func createItem(t *testing.T, r Repos, orderID, productID uuid.UUID) items.Items {
+	t.Helper()
+	created, err := r.Items.Create(t.Context(), items.New(orderID, productID, 2, 12.5))
+	mustNoError(t, err)
+	return created
+}
+
+func createSession(t *testing.T, r Repos, u user.User, expiresAt time.Time) session.Session {
+	t.Helper()
+	s := session.New(uuid.NewString(), u.ID, u.Email, false, expiresAt)
+	s.CreatedAt, s.LastUsedAt = baseTime, baseTime
+	_, err := r.Sessions.CreateSession(t.Context(), &s)
+	mustNoError(t, err)
+	return s
+}
@@ -0,0 +1,105 @@
+package porttest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/apikey"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/identity"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/lockout"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/user"
+)
+
+func testPrivacyRepo(t *testing.T, newRepos Factory) {
+	// personalData gives the user one of everything erasure has to deal with
+	personalData := func(t *testing.T, r Repos, u user.User) {
+		t.Helper()
+		shipped := createAddress(t, r, u.ID)
+		createAddress(t, r, u.ID)
+		o := createOrder(t, r, u.ID, shipped.ID)
+		createItem(t, r, o.ID, createProduct(t, r, "SKU-"+u.ID.String()).ID)
+		mustNoError(t, r.Addresses.SetDefault(t.Context(), u.ID, shipped.ID))
+		createSession(t, r, u, time.Now().Add(time.Hour)) // Listed sessions must not have expired
+		mustNoError(t, r.APIKeys.Create(t.Context(), apikey.New(u.ID, "CI", "ecom_abc", "hash-"+u.Email, apikey.Scopes{apikey.ScopeRead}, baseTime.Add(24*time.Hour))))
+		mustNoError(t, r.Identities.Create(t.Context(), identity.New(u.ID, "google", "sub-"+u.Email, u.Email)))
+		_, err := r.LoginAttempts.RecordFailure(t.Context(), lockout.ScopeAccount, u.Email, lockout.DefaultPolicy(), 5, baseTime)
+		mustNoError(t, err)
+	}
+
+	t.Run("EraseUser", func(t *testing.T) {
+		r := newRepos(t)
+		ada := createUser(t, r, "ada@example.com")
+		grace := createUser(t, r, "grace@example.com")
+		personalData(t, r, ada)
+		personalData(t, r, grace)
+
+		erased := ada.Anonymize(baseTime)
+		mustNoError(t, r.Privacy.EraseUser(t.Context(), erased))
+
+		got, err := r.Users.GetUserByID(t.Context(), ada.ID)
+		mustNoError(t, err)
+		if got.Email != erased.Email || got.Name != erased.Name || got.PasswordHash != erased.PasswordHash || got.IsAdmin ||
+			got.ErasedAt == nil || !sameTime(*got.ErasedAt, baseTime) || !sameTime(got.CreatedAt, ada.CreatedAt) {
+			t.Fatalf("user row = %+v, want it anonymised", got)
+		}
+
+		// The address the order shipped to stays, without the street and postal code
+		addresses, err := r.Addresses.ListByUserID(t.Context(), ada.ID)
+		mustNoError(t, err)
+		if len(addresses) != 1 {
+			t.Fatalf("got %d addresses, want only the one referenced by the order", len(addresses))
+		}
+		if a := addresses[0]; a.Line1 != "" || a.PostalCode != "" || a.IsDefault || a.City != "Springfield" || a.Country != "United States" {
+			t.Fatalf("kept address = %+v, want the street and postal code stripped", a)
+		}
+
+		orders, err := r.Orders.ListByUserID(t.Context(), ada.ID)
+		mustNoError(t, err)
+		if len(orders) != 1 {
+			t.Fatalf("got %d orders, want the order kept", len(orders))
+		}
+		its, err := r.Items.ListByOrderID(t.Context(), orders[0].ID)
+		mustNoError(t, err)
+		if len(its) != 1 {
+			t.Fatalf("got %d items, want the order's items kept", len(its))
+		}
+
+		sessions, err := r.Sessions.ListSessionsByUserID(t.Context(), ada.ID)
+		mustNoError(t, err)
+		if len(sessions) != 0 {
+			t.Fatalf("got %d sessions, want none", len(sessions))
+		}
+		_, err = r.APIKeys.GetByHash(t.Context(), "hash-"+ada.Email)
+		mustBeNotFound(t, err)
+		_, err = r.Identities.GetByProviderSubject(t.Context(), "google", "sub-"+ada.Email)
+		mustBeNotFound(t, err)
+		attempts, err := r.LoginAttempts.Get(t.Context(), lockout.ScopeAccount, ada.Email)
+		mustNoError(t, err)
+		if attempts.Failures != 0 {
+			t.Fatalf("login attempts for the old email = %d, want them forgotten", attempts.Failures)
+		}
+
+		// Nobody else is touched
+		other, err := r.Users.GetUserByID(t.Context(), grace.ID)
+		mustNoError(t, err)
+		if other.Email != grace.Email || other.IsErased() {
+			t.Fatalf("other user = %+v, want it untouched", other)
+		}
+		addresses, err = r.Addresses.ListByUserID(t.Context(), grace.ID)
+		mustNoError(t, err)
+		sessions, err = r.Sessions.ListSessionsByUserID(t.Context(), grace.ID)
+		mustNoError(t, err)
+		if len(addresses) != 2 || addresses[0].Line1 == "" || len(sessions) != 1 {
+			t.Fatalf("other user has %d addresses and %d sessions, want 2 and 1", len(addresses), len(sessions))
+		}
+		_, err = r.APIKeys.GetByHash(t.Context(), "hash-"+grace.Email)
+		mustNoError(t, err)
+		_, err = r.Identities.GetByProviderSubject(t.Context(), "google", "sub-"+grace.Email)
+		mustNoError(t, err)
+		attempts, err = r.LoginAttempts.Get(t.Context(), lockout.ScopeAccount, grace.Email)
+		mustNoError(t, err)
+		if attempts.Failures != 1 {
+			t.Fatalf("other user's login attempts = %d, want 1", attempts.Failures)
+		}
+	})
+}
@@ -0,0 +1,177 @@
+package porttest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/product"
+	"github.com/google/uuid"
+)
+
+func testProductRepo(t *testing.T, newRepos Factory) {
+	t.Run("CreateAndGet", func(t *testing.T) {
+		r := newRepos(t)
+		want := createProduct(t, r, "SKU-1")
+
+		byID, err := r.Products.GetByID(t.Context(), want.ID)
+		mustNoError(t, err)
+		bySKU, err := r.Products.GetBySKU(t.Context(), want.SKU)
+		mustNoError(t, err)
+		for _, got := range []product.Product{byID, bySKU} {
+			if got.ID != want.ID || got.SKU != want.SKU || got.Name != want.Name || got.Description != want.Description ||
+				got.Price != want.Price || got.StockQty != want.StockQty || got.Active != want.Active || !sameTime(got.CreatedAt, want.CreatedAt) {
+				t.Fatalf("got %+v, want %+v", got, want)
+			}
+		}
+
+		_, err = r.Products.GetByID(t.Context(), uuid.New())
+		mustBeNotFound(t, err)
+		_, err = r.Products.GetBySKU(t.Context(), "missing")
+		mustBeNotFound(t, err)
+	})
+
+	t.Run("UniqueSKU", func(t *testing.T) {
+		r := newRepos(t)
+		createProduct(t, r, "SKU-1")
+		p := createProduct(t, r, "SKU-2")
+
+		_, err := r.Products.Create(t.Context(), product.New("SKU-1", "Dup", "", 1, 1))
+		mustConflict(t, err, "second product with the same SKU")
+		p.SKU = "SKU-1"
+		_, err = r.Products.UpdateById(t.Context(), p)
+		mustConflict(t, err, "taking another product's SKU")
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		r := newRepos(t)
+		p := createProduct(t, r, "SKU-1")
+
+		p.Name, p.Price, p.StockQty, p.Active = "Renamed", 19.99, 3, false
+		got, err := r.Products.UpdateById(t.Context(), p)
+		mustNoError(t, err)
+		if got.Name != "Renamed" || got.Price != 19.99 || got.StockQty != 3 || got.Active {
+			t.Fatalf("got %+v", got)
+		}
+		_, err = r.Products.UpdateById(t.Context(), product.New("SKU-9", "Missing", "", 1, 1))
+		mustBeNotFound(t, err)
+
+		mustNoError(t, r.Products.UpdateStock(t.Context(), p.ID, 42))
+		got, err = r.Products.GetByID(t.Context(), p.ID)
+		mustNoError(t, err)
+		if got.StockQty != 42 {
+			t.Fatalf("stock = %d, want 42", got.StockQty)
+		}
+		mustBeNotFound(t, r.Products.UpdateStock(t.Context(), uuid.New(), 1))
+	})
+
+	t.Run("ListActiveNewestFirst", func(t *testing.T) {
+		r := newRepos(t)
+		var ids []uuid.UUID
+		for i, sku := range []string{"SKU-1", "SKU-2", "SKU-3"} {
+			p := product.New(sku, sku, "", 1, 1)
+			p.CreatedAt = baseTime.Add(time.Duration(i) * time.Minute)
+			p.Active = sku != "SKU-2"
+			_, err := r.Products.Create(t.Context(), p)
+			mustNoError(t, err)
+			ids = append(ids, p.ID)
+		}
+
+		got, err := r.Products.List(t.Context())
+		mustNoError(t, err)
+		if len(got) != 2 || got[0].ID != ids[2] || got[1].ID != ids[0] {
+			t.Fatalf("got %d products, want the 2 active ones newest first", len(got))
+		}
+	})
+
+	t.Run("AdjustStock", func(t *testing.T) {
+		r := newRepos(t)
+		p := createProduct(t, r, "SKU-1")
+
+		qty, err := r.Products.AdjustStock(t.Context(), p.ID, 5)
+		mustNoError(t, err)
+		if qty != 15 {
+			t.Fatalf("stock = %d, want 15", qty)
+		}
+		qty, err = r.Products.AdjustStock(t.Context(), p.ID, -15)
+		mustNoError(t, err)
+		if qty != 0 {
+			t.Fatalf("stock = %d, want 0", qty)
+		}
+
+		// Stock never goes negative; the row is left untouched
+		_, err = r.Products.AdjustStock(t.Context(), p.ID, -1)
+		mustBeNotFound(t, err)
+		got, err := r.Products.GetByID(t.Context(), p.ID)
+		mustNoError(t, err)
+		if got.StockQty != 0 {
+			t.Fatalf("stock = %d after a rejected adjustment, want 0", got.StockQty)
+		}
+		_, err = r.Products.AdjustStock(t.Context(), uuid.New(), 1)
+		mustBeNotFound(t, err)
+	})
+
+	t.Run("UpsertBySKU", func(t *testing.T) {
+		r := newRepos(t)
+		existing := createProduct(t, r, "SKU-1")
+		existing.Active = false
+		_, err := r.Products.UpdateById(t.Context(), existing)
+		mustNoError(t, err)
+
+		update := product.New("SKU-1", "Updated", "New description", 7.5, 99)
+		insert := product.New("SKU-2", "Inserted", "", 3, 4)
+		created, err := r.Products.UpsertBySKU(t.Context(), []product.Product{update, insert})
+		mustNoError(t, err)
+		if created != 1 {
+			t.Fatalf("created = %d, want 1", created)
+		}
+
+		got, err := r.Products.GetBySKU(t.Context(), "SKU-1")
+		mustNoError(t, err)
+		// Updates keep the row's identity and visibility
+		if got.ID != existing.ID || got.Active || got.Name != "Updated" || got.Price != 7.5 || got.StockQty != 99 {
+			t.Fatalf("got %+v", got)
+		}
+		got, err = r.Products.GetBySKU(t.Context(), "SKU-2")
+		mustNoError(t, err)
+		if got.ID != insert.ID || got.Name != "Inserted" {
+			t.Fatalf("got %+v", got)
+		}
+	})
+
+	t.Run("UpsertBySKUIsAtomic", func(t *testing.T) {
+		r := newRepos(t)
+		existing := createProduct(t, r, "SKU-1")
+
+		update := product.New("SKU-1", "Updated", "", 1, 1)
+		clash := product.New("SKU-3", "Clash", "", 1, 1)
+		clash.ID = existing.ID
+		_, err := r.Products.UpsertBySKU(t.Context(), []product.Product{update, clash})
+		mustConflict(t, err, "upsert that reuses an existing ID under a new SKU")
+
+		got, err := r.Products.GetBySKU(t.Context(), "SKU-1")
+		mustNoError(t, err)
+		if got.Name != existing.Name {
+			t.Fatalf("name = %q, want the failed import rolled back", got.Name)
+		}
+		_, err = r.Products.GetBySKU(t.Context(), "SKU-3")
+		mustBeNotFound(t, err)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		r := newRepos(t)
+		u := createUser(t, r, "ada@example.com")
+		a := createAddress(t, r, u.ID)
+		unsold := createProduct(t, r, "SKU-1")
+		sold := createProduct(t, r, "SKU-2")
+		createItem(t, r, createOrder(t, r, u.ID, a.ID).ID, sold.ID)
+
+		mustNoError(t, r.Products.DeleteById(t.Context(), unsold.ID))
+		_, err := r.Products.GetByID(t.Context(), unsold.ID)
+		mustBeNotFound(t, err)
+
+		// Order items keep pointing at the product they were sold as
+		mustBreakReference(t, r.Products.DeleteById(t.Context(), sold.ID), "deleting a product that was sold")
+		_, err = r.Products.GetByID(t.Context(), sold.ID)
+		mustNoError(t, err)
+	})
+}
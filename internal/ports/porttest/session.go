@@ -0,0 +1,191 @@
+package porttest
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/session"
+	"github.com/frostnzx/go-ecommerce-api/internal/ports"
+	"github.com/google/uuid"
+)
+
+func testSessionRepo(t *testing.T, newRepos Factory) {
+	// Adapters compare against their own clock, so keep clear of it
+	future := time.Now().Add(24 * time.Hour).UTC()
+	past := time.Now().Add(-24 * time.Hour).UTC()
+
+	t.Run("CreateAndGet", func(t *testing.T) {
+		r := newRepos(t)
+		u := createUser(t, r, "ada@example.com")
+		admin := createUser(t, r, "admin@example.com")
+
+		s := session.New(uuid.NewString(), u.ID, u.Email, false, future)
+		s.UserAgent, s.IPAddress, s.ImpersonatorID = "curl/8.0", "203.0.113.7", &admin.ID
+		_, err := r.Sessions.CreateSession(t.Context(), &s)
+		mustNoError(t, err)
+
+		got, err := r.Sessions.GetSession(t.Context(), s.ID)
+		mustNoError(t, err)
+		if got.UserID != u.ID || got.Email != u.Email || got.UserAgent != s.UserAgent || got.IPAddress != s.IPAddress ||
+			got.IsRevoked || !sameTime(got.ExpiresAt, s.ExpiresAt) || got.ImpersonatorID == nil || *got.ImpersonatorID != admin.ID {
+			t.Fatalf("got %+v, want %+v", got, s)
+		}
+
+		_, err = r.Sessions.GetSession(t.Context(), uuid.NewString())
+		mustBeNotFound(t, err)
+	})
+
+	t.Run("RequiresUser", func(t *testing.T) {
+		r := newRepos(t)
+		s := session.New(uuid.NewString(), uuid.New(), "ghost@example.com", false, future)
+		_, err := r.Sessions.CreateSession(t.Context(), &s)
+		mustBreakReference(t, err, "session for a missing user")
+	})
+
+	t.Run("ListActiveSessions", func(t *testing.T) {
+		r := newRepos(t)
+		u := createUser(t, r, "ada@example.com")
+		other := createUser(t, r, "grace@example.com")
+
+		older := createSession(t, r, u, future)
+		newer := session.New(uuid.NewString(), u.ID, u.Email, false, future)
+		newer.LastUsedAt = baseTime.Add(time.Minute)
+		_, err := r.Sessions.CreateSession(t.Context(), &newer)
+		mustNoError(t, err)
+		revoked := createSession(t, r, u, future)
+		mustNoError(t, r.Sessions.RevokeSession(t.Context(), revoked.ID))
+		createSession(t, r, u, past)
+		createSession(t, r, other, future)
+
+		got, err := r.Sessions.ListSessionsByUserID(t.Context(), u.ID)
+		mustNoError(t, err)
+		if len(got) != 2 || got[0].ID != newer.ID || got[1].ID != older.ID {
+			t.Fatalf("got %d sessions, want the two active ones, most recently used first", len(got))
+		}
+	})
+
+	t.Run("RevokeSessionsByUserID", func(t *testing.T) {
+		r := newRepos(t)
+		u := createUser(t, r, "ada@example.com")
+		keep := createSession(t, r, u, future)
+		a := createSession(t, r, u, future)
+		b := createSession(t, r, u, future)
+		already := createSession(t, r, u, future)
+		mustNoError(t, r.Sessions.RevokeSession(t.Context(), already.ID))
+
+		ids, err := r.Sessions.RevokeSessionsByUserID(t.Context(), u.ID, keep.ID)
+		mustNoError(t, err)
+		slices.Sort(ids)
+		want := []string{a.ID, b.ID}
+		slices.Sort(want)
+		if !slices.Equal(ids, want) {
+			t.Fatalf("revoked %v, want %v", ids, want)
+		}
+		got, err := r.Sessions.GetSession(t.Context(), keep.ID)
+		mustNoError(t, err)
+		if got.IsRevoked {
+			t.Fatal("excepted session was revoked")
+		}
+	})
+
+	t.Run("TouchSession", func(t *testing.T) {
+		r := newRepos(t)
+		u := createUser(t, r, "ada@example.com")
+		s := createSession(t, r, u, future)
+
+		mustNoError(t, r.Sessions.TouchSession(t.Context(), s.ID))
+		got, err := r.Sessions.GetSession(t.Context(), s.ID)
+		mustNoError(t, err)
+		if !got.LastUsedAt.After(s.LastUsedAt) {
+			t.Fatalf("LastUsedAt = %v, want later than %v", got.LastUsedAt, s.LastUsedAt)
+		}
+	})
+
+	t.Run("DeleteSessions", func(t *testing.T) {
+		r := newRepos(t)
+		u := createUser(t, r, "ada@example.com")
+		other := createUser(t, r, "grace@example.com")
+		expired := createSession(t, r, u, past)
+		tok := session.NewRefreshToken(uuid.NewString(), expired.ID, "expired-hash", past)
+		mustNoError(t, r.Sessions.CreateRefreshToken(t.Context(), &tok))
+		live := createSession(t, r, u, future)
+		otherLive := createSession(t, r, other, future)
+
+		n, err := r.Sessions.DeleteExpiredSessions(t.Context(), time.Now())
+		mustNoError(t, err)
+		if n != 1 {
+			t.Fatalf("deleted %d expired sessions, want 1", n)
+		}
+		_, err = r.Sessions.GetRefreshToken(t.Context(), tok.TokenHash)
+		mustBeNotFound(t, err)
+
+		mustNoError(t, r.Sessions.DeleteSessionsByEmail(t.Context(), u.Email))
+		_, err = r.Sessions.GetSession(t.Context(), live.ID)
+		mustBeNotFound(t, err)
+		_, err = r.Sessions.GetSession(t.Context(), otherLive.ID)
+		mustNoError(t, err)
+
+		mustNoError(t, r.Sessions.DeleteSession(t.Context(), otherLive.ID))
+		_, err = r.Sessions.GetSession(t.Context(), otherLive.ID)
+		mustBeNotFound(t, err)
+	})
+
+	t.Run("RefreshTokens", func(t *testing.T) {
+		r := newRepos(t)
+		u := createUser(t, r, "ada@example.com")
+		s := createSession(t, r, u, future)
+
+		tok := session.NewRefreshToken(uuid.NewString(), s.ID, "hash-1", future)
+		mustNoError(t, r.Sessions.CreateRefreshToken(t.Context(), &tok))
+		dup := session.NewRefreshToken(uuid.NewString(), s.ID, "hash-1", future)
+		mustConflict(t, r.Sessions.CreateRefreshToken(t.Context(), &dup), "second token with the same hash")
+		orphan := session.NewRefreshToken(uuid.NewString(), uuid.NewString(), "hash-2", future)
+		mustBreakReference(t, r.Sessions.CreateRefreshToken(t.Context(), &orphan), "token for a missing session")
+
+		got, err := r.Sessions.GetRefreshToken(t.Context(), "hash-1")
+		mustNoError(t, err)
+		if got.ID != tok.ID || got.SessionID != s.ID || got.IsConsumed() {
+			t.Fatalf("got %+v, want %+v", got, tok)
+		}
+		_, err = r.Sessions.GetRefreshToken(t.Context(), "unknown")
+		mustBeNotFound(t, err)
+
+		next := session.NewRefreshToken(uuid.NewString(), s.ID, "hash-2", future)
+		mustNoError(t, r.Sessions.RotateRefreshToken(t.Context(), tok.ID, &next))
+		replay := session.NewRefreshToken(uuid.NewString(), s.ID, "hash-3", future)
+		if err := r.Sessions.RotateRefreshToken(t.Context(), tok.ID, &replay); !errors.Is(err, ports.ErrRefreshTokenConsumed) {
+			t.Fatalf("second rotation: err = %v, want ErrRefreshTokenConsumed", err)
+		}
+		got, err = r.Sessions.GetRefreshToken(t.Context(), "hash-1")
+		mustNoError(t, err)
+		if !got.IsConsumed() {
+			t.Fatal("token not marked consumed")
+		}
+		got, err = r.Sessions.GetRefreshToken(t.Context(), "hash-2")
+		mustNoError(t, err)
+		if got.ID != next.ID || got.IsConsumed() {
+			t.Fatalf("got %+v, want the unused successor", got)
+		}
+		_, err = r.Sessions.GetRefreshToken(t.Context(), "hash-3")
+		mustBeNotFound(t, err)
+	})
+
+	t.Run("RotateRefreshTokenIsAtomic", func(t *testing.T) {
+		r := newRepos(t)
+		u := createUser(t, r, "ada@example.com")
+		s := createSession(t, r, u, future)
+		tok := session.NewRefreshToken(uuid.NewString(), s.ID, "hash-1", future)
+		mustNoError(t, r.Sessions.CreateRefreshToken(t.Context(), &tok))
+
+		// A successor that cannot be stored leaves the presented token usable for a retry
+		clash := session.NewRefreshToken(uuid.NewString(), s.ID, "hash-1", future)
+		mustConflict(t, r.Sessions.RotateRefreshToken(t.Context(), tok.ID, &clash), "successor with a taken hash")
+		got, err := r.Sessions.GetRefreshToken(t.Context(), "hash-1")
+		mustNoError(t, err)
+		if got.IsConsumed() {
+			t.Fatal("token consumed by a failed rotation")
+		}
+	})
+}
@@ -0,0 +1,169 @@
+package porttest
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/session"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/user"
+	"github.com/frostnzx/go-ecommerce-api/internal/ports"
+	"github.com/google/uuid"
+)
+
+func testUserRepo(t *testing.T, newRepos Factory) {
+	t.Run("CreateAndGet", func(t *testing.T) {
+		r := newRepos(t)
+		want := createUser(t, r, "ada@example.com")
+
+		byEmail, err := r.Users.GetUser(t.Context(), want.Email)
+		mustNoError(t, err)
+		byID, err := r.Users.GetUserByID(t.Context(), want.ID)
+		mustNoError(t, err)
+		for _, got := range []*user.User{byEmail, byID} {
+			if got.ID != want.ID || got.Email != want.Email || got.PasswordHash != want.PasswordHash ||
+				got.Name != want.Name || got.IsAdmin != want.IsAdmin || !sameTime(got.CreatedAt, want.CreatedAt) {
+				t.Fatalf("got %+v, want %+v", got, want)
+			}
+			if got.SuspendedAt != nil || got.ErasedAt != nil {
+				t.Fatalf("new user has SuspendedAt=%v ErasedAt=%v", got.SuspendedAt, got.ErasedAt)
+			}
+		}
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		r := newRepos(t)
+		_, err := r.Users.GetUser(t.Context(), "nobody@example.com")
+		mustBeNotFound(t, err)
+		_, err = r.Users.GetUserByID(t.Context(), uuid.New())
+		mustBeNotFound(t, err)
+	})
+
+	t.Run("UniqueEmail", func(t *testing.T) {
+		r := newRepos(t)
+		createUser(t, r, "ada@example.com")
+		mustConflict(t, r.Users.Create(t.Context(), user.New("ada@example.com", "hash", "Other", false)), "second user with the same email")
+
+		users, err := r.Users.ListUsers(t.Context())
+		mustNoError(t, err)
+		if len(users) != 1 {
+			t.Fatalf("got %d users, want 1", len(users))
+		}
+	})
+
+	t.Run("UpdateUser", func(t *testing.T) {
+		r := newRepos(t)
+		u := createUser(t, r, "ada@example.com")
+		other := createUser(t, r, "grace@example.com")
+
+		u.Name, u.Email, u.PasswordHash, u.IsAdmin = "Ada L.", "ada.l@example.com", "new-hash", true
+		mustNoError(t, r.Users.UpdateUser(t.Context(), u))
+		got, err := r.Users.GetUserByID(t.Context(), u.ID)
+		mustNoError(t, err)
+		if got.Name != u.Name || got.Email != u.Email || got.PasswordHash != u.PasswordHash || !got.IsAdmin {
+			t.Fatalf("got %+v, want %+v", got, u)
+		}
+
+		u.Email = other.Email
+		mustConflict(t, r.Users.UpdateUser(t.Context(), u), "taking another user's email")
+	})
+
+	t.Run("SuspendAndPassword", func(t *testing.T) {
+		r := newRepos(t)
+		u := createUser(t, r, "ada@example.com")
+
+		at := baseTime.Add(time.Hour)
+		mustNoError(t, r.Users.SetSuspended(t.Context(), u.ID, &at))
+		mustNoError(t, r.Users.UpdatePasswordHash(t.Context(), u.ID, "rotated"))
+		got, err := r.Users.GetUserByID(t.Context(), u.ID)
+		mustNoError(t, err)
+		if got.SuspendedAt == nil || !sameTime(*got.SuspendedAt, at) || got.PasswordHash != "rotated" {
+			t.Fatalf("got SuspendedAt=%v PasswordHash=%q", got.SuspendedAt, got.PasswordHash)
+		}
+
+		mustNoError(t, r.Users.SetSuspended(t.Context(), u.ID, nil))
+		got, err = r.Users.GetUserByID(t.Context(), u.ID)
+		mustNoError(t, err)
+		if got.SuspendedAt != nil {
+			t.Fatalf("SuspendedAt = %v after lifting the suspension", got.SuspendedAt)
+		}
+	})
+
+	t.Run("UpdatesReportMissingUser", func(t *testing.T) {
+		r := newRepos(t)
+		id := uuid.New()
+		now := baseTime
+		for name, err := range map[string]error{
+			"SetSuspended":       r.Users.SetSuspended(t.Context(), id, &now),
+			"UpdatePasswordHash": r.Users.UpdatePasswordHash(t.Context(), id, "hash"),
+			"UpdateUser":         r.Users.UpdateUser(t.Context(), user.User{ID: id, Email: "x@example.com"}),
+		} {
+			// Both sentinels match, so callers may check either one
+			if !errors.Is(err, ports.ErrUserNotFound) || !errors.Is(err, ports.ErrNotFound) {
+				t.Errorf("%s: err = %v, want ErrUserNotFound", name, err)
+			}
+		}
+	})
+
+	t.Run("SearchUsers", func(t *testing.T) {
+		r := newRepos(t)
+		var ids []uuid.UUID
+		for i, email := range []string{"ada@example.com", "grace@example.com", "alan@example.org", "50%off@example.com"} {
+			u := user.New(email, "hash", "User "+email, false)
+			u.CreatedAt = baseTime.Add(time.Duration(i) * time.Minute)
+			mustNoError(t, r.Users.Create(t.Context(), u))
+			ids = append(ids, u.ID)
+		}
+
+		got, total, err := r.Users.SearchUsers(t.Context(), ports.UserFilter{Query: "EXAMPLE.COM", Limit: 2})
+		mustNoError(t, err)
+		if total != 3 || len(got) != 2 || got[0].ID != ids[3] || got[1].ID != ids[1] {
+			t.Fatalf("first page: total=%d, got %d users", total, len(got))
+		}
+		got, _, err = r.Users.SearchUsers(t.Context(), ports.UserFilter{Query: "EXAMPLE.COM", Limit: 2, Offset: 2})
+		mustNoError(t, err)
+		if len(got) != 1 || got[0].ID != ids[0] {
+			t.Fatalf("second page: got %d users", len(got))
+		}
+
+		// Wildcards in the query are matched literally
+		got, total, err = r.Users.SearchUsers(t.Context(), ports.UserFilter{Query: "%", Limit: 10})
+		mustNoError(t, err)
+		if total != 1 || len(got) != 1 || got[0].ID != ids[3] {
+			t.Fatalf("wildcard query: total=%d, got %d users", total, len(got))
+		}
+	})
+
+	t.Run("DeleteUserCascades", func(t *testing.T) {
+		r := newRepos(t)
+		u := createUser(t, r, "ada@example.com")
+		keep := createUser(t, r, "grace@example.com")
+		a := createAddress(t, r, u.ID)
+		p := createProduct(t, r, "SKU-1")
+		o := createOrder(t, r, u.ID, a.ID)
+		it := createItem(t, r, o.ID, p.ID)
+		s := createSession(t, r, u, baseTime.Add(time.Hour))
+		tok := session.NewRefreshToken(uuid.NewString(), s.ID, "token-hash", baseTime.Add(time.Hour))
+		mustNoError(t, r.Sessions.CreateRefreshToken(t.Context(), &tok))
+
+		mustNoError(t, r.Users.DeleteUser(t.Context(), u.ID))
+
+		_, err := r.Users.GetUserByID(t.Context(), u.ID)
+		mustBeNotFound(t, err)
+		_, err = r.Addresses.GetByID(t.Context(), a.ID)
+		mustBeNotFound(t, err)
+		_, err = r.Orders.GetByID(t.Context(), o.ID)
+		mustBeNotFound(t, err)
+		_, err = r.Items.GetByID(t.Context(), it.ID)
+		mustBeNotFound(t, err)
+		_, err = r.Sessions.GetSession(t.Context(), s.ID)
+		mustBeNotFound(t, err)
+		_, err = r.Sessions.GetRefreshToken(t.Context(), tok.TokenHash)
+		mustBeNotFound(t, err)
+
+		_, err = r.Users.GetUserByID(t.Context(), keep.ID)
+		mustNoError(t, err)
+		_, err = r.Products.GetByID(t.Context(), p.ID)
+		mustNoError(t, err)
+	})
+}
@@ -0,0 +1,14 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/user"
+)
+
+type PrivacyRepo interface {
+	// EraseUser atomically replaces the user row with the anonymised u, strips the
+	// street and postal code from addresses still referenced by orders, and deletes
+	// the user's other addresses, sessions, API keys, linked identities and login attempts
+	EraseUser(ctx context.Context, u user.User) error
+}
@@ -14,8 +14,15 @@ var (
 type ProductRepo interface {
 	Create(ctx context.Context, item product.Product) (product.Product, error)
 	GetByID(ctx context.Context, id uuid.UUID) (product.Product, error)
+	GetBySKU(ctx context.Context, sku string) (product.Product, error)
 	List(ctx context.Context) ([]product.Product, error)
 	DeleteById(ctx context.Context, id uuid.UUID) error
 	UpdateById(ctx context.Context, p product.Product) (product.Product, error)
 	UpdateStock(ctx context.Context, id uuid.UUID, quantity int) error
+	// AdjustStock adds delta to the stock and returns the new quantity; it returns
+	// ErrNotFound instead of letting the stock go below zero
+	AdjustStock(ctx context.Context, id uuid.UUID, delta int) (int, error)
+	// UpsertBySKU creates or updates every product, matched on SKU, in one transaction.
+	// Existing products keep their ID, active flag and creation time.
+	UpsertBySKU(ctx context.Context, products []product.Product) (created int, err error)
 }
@@ -2,8 +2,15 @@ package ports
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/session"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrRefreshTokenConsumed = errors.New("refresh token already consumed")
 )
 
 type SessionRepo interface {
@@ -11,4 +18,22 @@ type SessionRepo interface {
 	GetSession(ctx context.Context, id string) (*session.Session, error)
 	RevokeSession(ctx context.Context, id string) error
 	DeleteSession(ctx context.Context, id string) error
+	DeleteSessionsByEmail(ctx context.Context, email string) error
+	TouchSession(ctx context.Context, id string) error
+	// DeleteExpiredSessions removes sessions that expired before the cutoff, along
+	// with their refresh tokens, and returns how many were removed
+	DeleteExpiredSessions(ctx context.Context, before time.Time) (int64, error)
+
+	// ListSessionsByUserID returns the user's sessions that are neither revoked nor expired
+	ListSessionsByUserID(ctx context.Context, userID uuid.UUID) ([]session.Session, error)
+	// RevokeSessionsByUserID revokes all of the user's active sessions except exceptID
+	// (pass "" to revoke every session) and returns the IDs it revoked.
+	RevokeSessionsByUserID(ctx context.Context, userID uuid.UUID, exceptID string) ([]string, error)
+
+	CreateRefreshToken(ctx context.Context, t *session.RefreshToken) error
+	GetRefreshToken(ctx context.Context, tokenHash string) (*session.RefreshToken, error)
+	// RotateRefreshToken marks the token as used, stores its successor and touches the
+	// session in one transaction. It returns ErrRefreshTokenConsumed and stores nothing
+	// if the token had already been consumed, so concurrent renewals cannot both succeed.
+	RotateRefreshToken(ctx context.Context, consumedID string, next *session.RefreshToken) error
 }
@@ -3,21 +3,35 @@ package ports
 import (
 	"context"
 	"errors"
+	"fmt"
+	"time"
 
 	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/user"
 	"github.com/google/uuid"
 )
 
 var (
-	ErrUserNotFound = errors.New("user does not exist")
+	// ErrUserNotFound wraps ErrNotFound, so either matches a missing user
+	ErrUserNotFound = fmt.Errorf("user %w", ErrNotFound)
 	ErrCreateUser   = errors.New("cannot create user")
 )
 
+// UserFilter selects a page of users; Query matches email or name (case-insensitive substring)
+type UserFilter struct {
+	Query  string
+	Limit  int
+	Offset int
+}
+
 type UserRepo interface {
 	Create(ctx context.Context, u user.User) error
 	GetUser(ctx context.Context, email string) (*user.User, error)
 	GetUserByID(ctx context.Context, id uuid.UUID) (*user.User, error)
 	ListUsers(ctx context.Context) ([]*user.User, error)
+	// SearchUsers returns one page of matching users, newest first, and the total number of matches
+	SearchUsers(ctx context.Context, f UserFilter) ([]*user.User, int, error)
+	SetSuspended(ctx context.Context, id uuid.UUID, suspendedAt *time.Time) error
+	UpdatePasswordHash(ctx context.Context, id uuid.UUID, passwordHash string) error
 	UpdateUser(ctx context.Context, u user.User) error
 	DeleteUser(ctx context.Context, id uuid.UUID) error
 }
@@ -0,0 +1,119 @@
+// Package ratelimit implements token bucket rate limiting behind a pluggable store.
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// Limit allows Requests per Period on average, in bursts of up to Burst requests
+type Limit struct {
+	Requests int
+	Period   time.Duration
+	Burst    int // Defaults to Requests when zero
+}
+
+// Capacity is the size of the bucket, i.e. the most requests allowed at once
+func (l Limit) Capacity() int {
+	if l.Burst > 0 {
+		return l.Burst
+	}
+	return l.Requests
+}
+
+// rate is the refill rate in tokens per second
+func (l Limit) rate() float64 {
+	return float64(l.Requests) / l.Period.Seconds()
+}
+
+// Result describes the bucket after a request was counted against it
+type Result struct {
+	Allowed    bool
+	Limit      int           // Bucket capacity
+	Remaining  int           // Requests left before the caller is limited
+	Reset      time.Duration // Until the bucket is full again
+	RetryAfter time.Duration // Until the next request is allowed; zero when Allowed
+}
+
+// Store keeps one token bucket per key. Implementations backed by shared
+// storage let several instances enforce a common limit.
+type Store interface {
+	Allow(ctx context.Context, key string, l Limit) (Result, error)
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+	limit  Limit
+}
+
+// refill adds the tokens earned since the last request
+func (b *bucket) refill(now time.Time) {
+	capacity := float64(b.limit.Capacity())
+	b.tokens = math.Min(capacity, b.tokens+now.Sub(b.last).Seconds()*b.limit.rate())
+	b.last = now
+}
+
+// MemoryStore keeps buckets in process memory. Limits are per instance.
+type MemoryStore struct {
+	mu      sync.Mutex
+	buckets map[string]*bucket
+	now     func() time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{buckets: make(map[string]*bucket), now: time.Now}
+}
+
+// Allow takes one token from the bucket for key, creating a full one on first use
+func (s *MemoryStore) Allow(ctx context.Context, key string, l Limit) (Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	b, ok := s.buckets[key]
+	if !ok || b.limit != l {
+		b = &bucket{tokens: float64(l.Capacity()), last: now, limit: l}
+		s.buckets[key] = b
+	}
+	b.refill(now)
+
+	res := Result{Limit: l.Capacity()}
+	if b.tokens >= 1 {
+		b.tokens--
+		res.Allowed = true
+	} else {
+		res.RetryAfter = seconds((1 - b.tokens) / l.rate())
+	}
+	res.Remaining = int(b.tokens)
+	res.Reset = seconds((float64(l.Capacity()) - b.tokens) / l.rate())
+	return res, nil
+}
+
+// Sweep drops buckets that have refilled completely, which behave exactly like
+// new ones. Run it periodically to keep memory bounded.
+func (s *MemoryStore) Sweep(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	for key, b := range s.buckets {
+		b.refill(now)
+		if b.tokens >= float64(b.limit.Capacity()) {
+			delete(s.buckets, key)
+		}
+	}
+	return nil
+}
+
+// Len returns the number of buckets held
+func (s *MemoryStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.buckets)
+}
+
+func seconds(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
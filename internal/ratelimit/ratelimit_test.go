@@ -0,0 +1,84 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// fakeClock is a MemoryStore clock moved by hand
+type fakeClock struct{ t time.Time }
+
+func (c *fakeClock) now() time.Time          { return c.t }
+func (c *fakeClock) advance(d time.Duration) { c.t = c.t.Add(d) }
+
+func newTestStore() (*MemoryStore, *fakeClock) {
+	clock := &fakeClock{t: time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)}
+	s := NewMemoryStore()
+	s.now = clock.now
+	return s, clock
+}
+
+func TestAllowSpendsBurstThenLimits(t *testing.T) {
+	s, _ := newTestStore()
+	l := Limit{Requests: 3, Period: 3 * time.Second}
+	ctx := context.Background()
+
+	for i := 2; i >= 0; i-- {
+		res, _ := s.Allow(ctx, "k", l)
+		if !res.Allowed || res.Remaining != i || res.Limit != 3 {
+			t.Fatalf("request %d = %+v, want allowed with %d remaining", 3-i, res, i)
+		}
+	}
+	res, _ := s.Allow(ctx, "k", l)
+	if res.Allowed {
+		t.Fatal("fourth request allowed, want limited")
+	}
+	if res.RetryAfter != time.Second {
+		t.Errorf("RetryAfter = %v, want 1s", res.RetryAfter)
+	}
+	if res.Reset != 3*time.Second {
+		t.Errorf("Reset = %v, want 3s", res.Reset)
+	}
+
+	if res, _ := s.Allow(ctx, "other", l); !res.Allowed {
+		t.Error("a different key was limited")
+	}
+}
+
+func TestAllowRefillsOverTime(t *testing.T) {
+	s, clock := newTestStore()
+	l := Limit{Requests: 60, Period: time.Minute, Burst: 1}
+	ctx := context.Background()
+
+	if res, _ := s.Allow(ctx, "k", l); !res.Allowed {
+		t.Fatal("first request limited")
+	}
+	if res, _ := s.Allow(ctx, "k", l); res.Allowed {
+		t.Fatal("burst of 1 allowed a second request")
+	}
+	clock.advance(500 * time.Millisecond)
+	if res, _ := s.Allow(ctx, "k", l); res.Allowed || res.RetryAfter != 500*time.Millisecond {
+		t.Fatalf("half refilled = %+v, want limited for another 500ms", res)
+	}
+	clock.advance(500 * time.Millisecond)
+	if res, _ := s.Allow(ctx, "k", l); !res.Allowed {
+		t.Error("request after the refill was limited")
+	}
+}
+
+func TestSweepDropsOnlyFullBuckets(t *testing.T) {
+	s, clock := newTestStore()
+	ctx := context.Background()
+	s.Allow(ctx, "slow", Limit{Requests: 1, Period: time.Hour})
+	s.Allow(ctx, "fast", Limit{Requests: 10, Period: time.Second})
+
+	clock.advance(time.Second)
+	s.Sweep(ctx)
+	if s.Len() != 1 {
+		t.Fatalf("Len = %d after sweep, want only the still-draining bucket kept", s.Len())
+	}
+	if res, _ := s.Allow(ctx, "slow", Limit{Requests: 1, Period: time.Hour}); res.Allowed {
+		t.Error("sweep reset a bucket that had not refilled")
+	}
+}
@@ -0,0 +1,93 @@
+package seed
+
+// Word lists the generators draw from. Appending to a list changes the data
+// generated for existing seeds, so extend them only alongside a deliberate change.
+
+var firstNames = []string{
+	"Olivia", "Liam", "Emma", "Noah", "Ava", "Oliver", "Sophia", "Elijah", "Isabella", "Lucas",
+	"Mia", "Mateo", "Amelia", "Levi", "Harper", "Kai", "Evelyn", "Ezra", "Aria", "Leo",
+	"Priya", "Arjun", "Yuki", "Haruto", "Mei", "Wei", "Fatima", "Omar", "Chloe", "Hugo",
+	"Zara", "Tariq", "Ines", "Mateus", "Anya", "Niko", "Sofia", "Jonas", "Lina", "Rafael",
+}
+
+var lastNames = []string{
+	"Smith", "Johnson", "Williams", "Brown", "Jones", "Garcia", "Miller", "Davis", "Rodriguez", "Martinez",
+	"Hernandez", "Lopez", "Wilson", "Anderson", "Thomas", "Taylor", "Moore", "Jackson", "Martin", "Lee",
+	"Nguyen", "Kim", "Patel", "Singh", "Tanaka", "Sato", "Chen", "Wang", "Rossi", "Muller",
+	"Dubois", "Silva", "Kowalski", "Novak", "Jensen", "Larsen", "Okafor", "Mensah", "Haddad", "Cohen",
+}
+
+var streetNames = []string{
+	"Maple", "Oak", "Cedar", "Pine", "Elm", "Washington", "Lake", "Hill", "Park", "River",
+	"Sunset", "Highland", "Church", "Mill", "Spring", "Market", "King", "Queen", "Station", "Harbor",
+}
+
+var streetTypes = []string{"Street", "Avenue", "Road", "Lane", "Drive", "Way", "Boulevard", "Court"}
+
+type city struct {
+	name, province, postalPrefix, country string
+}
+
+var cities = []city{
+	{"Seattle", "WA", "98", "United States"},
+	{"Austin", "TX", "78", "United States"},
+	{"Chicago", "IL", "60", "United States"},
+	{"Boston", "MA", "02", "United States"},
+	{"Toronto", "ON", "M5", "Canada"},
+	{"Vancouver", "BC", "V6", "Canada"},
+	{"London", "", "EC", "United Kingdom"},
+	{"Manchester", "", "M1", "United Kingdom"},
+	{"Berlin", "Berlin", "10", "Germany"},
+	{"Munich", "Bavaria", "80", "Germany"},
+	{"Paris", "Ile-de-France", "75", "France"},
+	{"Lyon", "Auvergne-Rhone-Alpes", "69", "France"},
+	{"Madrid", "Madrid", "28", "Spain"},
+	{"Amsterdam", "North Holland", "10", "Netherlands"},
+	{"Stockholm", "", "11", "Sweden"},
+	{"Tokyo", "Tokyo", "10", "Japan"},
+	{"Osaka", "Osaka", "53", "Japan"},
+	{"Bangkok", "Bangkok", "10", "Thailand"},
+	{"Chiang Mai", "Chiang Mai", "50", "Thailand"},
+	{"Singapore", "", "01", "Singapore"},
+	{"Sydney", "NSW", "20", "Australia"},
+	{"Melbourne", "VIC", "30", "Australia"},
+	{"Auckland", "", "10", "New Zealand"},
+	{"Sao Paulo", "SP", "01", "Brazil"},
+}
+
+type category struct {
+	code               string
+	nouns, materials   []string
+	minPrice, maxPrice float64
+}
+
+var categories = []category{
+	{"KIT", []string{"Mug", "Teapot", "Cutting Board", "Saucepan", "Skillet", "Salad Bowl", "Knife Set", "Coffee Grinder"},
+		[]string{"Ceramic", "Stoneware", "Walnut", "Bamboo", "Cast Iron", "Stainless Steel", "Copper"}, 8, 250},
+	{"HOM", []string{"Throw Blanket", "Cushion", "Table Lamp", "Vase", "Wall Clock", "Rug", "Candle", "Mirror"},
+		[]string{"Wool", "Linen", "Cotton", "Brass", "Glass", "Rattan", "Oak"}, 12, 400},
+	{"APP", []string{"T-Shirt", "Hoodie", "Jacket", "Scarf", "Beanie", "Tote Bag", "Backpack", "Socks"},
+		[]string{"Organic Cotton", "Merino Wool", "Denim", "Canvas", "Leather", "Fleece", "Recycled Nylon"}, 9, 300},
+	{"ELE", []string{"Headphones", "Bluetooth Speaker", "Phone Stand", "Charging Dock", "Desk Lamp", "Keyboard", "Webcam"},
+		[]string{"Aluminium", "Matte Black", "Walnut", "Silicone", "Carbon Fibre", "Polycarbonate"}, 15, 600},
+	{"OUT", []string{"Water Bottle", "Camping Chair", "Tent", "Hammock", "Lantern", "Daypack", "Cooler"},
+		[]string{"Ripstop", "Titanium", "Aluminium", "Canvas", "Recycled Polyester", "Stainless Steel"}, 10, 500},
+	{"STA", []string{"Notebook", "Fountain Pen", "Planner", "Desk Organizer", "Sketchbook", "Pencil Case"},
+		[]string{"Recycled Paper", "Leather", "Brass", "Cork", "Felt", "Cardboard"}, 4, 120},
+}
+
+var adjectives = []string{
+	"Classic", "Modern", "Minimal", "Rustic", "Vintage", "Essential", "Everyday", "Premium",
+	"Compact", "Handmade", "Nordic", "Heritage", "Urban", "Coastal", "Studio", "Signature",
+}
+
+var taglines = []string{
+	"Built to last and easy to care for.",
+	"Designed in small batches.",
+	"A customer favourite for everyday use.",
+	"Pairs well with the rest of the collection.",
+	"Backed by a two year warranty.",
+	"Thoughtfully made with responsibly sourced materials.",
+	"Lightweight and ready to travel.",
+	"Gift-ready packaging included.",
+}
@@ -0,0 +1,153 @@
+package seed
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"strings"
+	"time"
+
+	"github.com/frostnzx/go-ecommerce-api/internal/core/domain/order"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/services/address"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/services/product"
+)
+
+// Password is the password of every seeded user, so demo accounts can log in
+const Password = "seed-password-1"
+
+// Every record draws from its own random stream derived from the seed, its kind and
+// its index, so record i is the same whatever the counts and whatever order records
+// are generated in
+const (
+	streamUser uint64 = iota + 1
+	streamProduct
+	streamOrder
+)
+
+func newRand(seed, stream uint64, i int) *rand.Rand {
+	return rand.New(rand.NewPCG(seed, stream<<56|uint64(i)))
+}
+
+// UserSpec is a generated user with one to three addresses, the first being the default
+type UserSpec struct {
+	Name      string
+	Email     string
+	Addresses []address.AddAddressReq // UserID is filled in once the user exists
+}
+
+// GenerateUser returns user i of the data set for seed
+func GenerateUser(seed uint64, i int) UserSpec {
+	r := newRand(seed, streamUser, i)
+	first, last := pick(r, firstNames), pick(r, lastNames)
+	u := UserSpec{
+		Name:  first + " " + last,
+		Email: fmt.Sprintf("seed%d.user%06d@example.com", seed, i),
+	}
+
+	for range 1 + weighted(r, []int{70, 22, 8}) {
+		c := pick(r, cities)
+		u.Addresses = append(u.Addresses, address.AddAddressReq{
+			Line1:      fmt.Sprintf("%d %s %s", 1+r.IntN(9999), pick(r, streetNames), pick(r, streetTypes)),
+			City:       c.name,
+			Province:   c.province,
+			PostalCode: fmt.Sprintf("%s%04d", c.postalPrefix, r.IntN(10000)),
+			Country:    c.country,
+		})
+	}
+	return u
+}
+
+// GenerateProduct returns product i of the catalog for seed
+func GenerateProduct(seed uint64, i int) product.AddProductReq {
+	r := newRand(seed, streamProduct, i)
+	cat := pick(r, categories)
+	adjective, material, noun := pick(r, adjectives), pick(r, cat.materials), pick(r, cat.nouns)
+
+	// Prices are log-uniform within the category and end in .99 like a real shelf
+	price := math.Exp(math.Log(cat.minPrice) + r.Float64()*(math.Log(cat.maxPrice)-math.Log(cat.minPrice)))
+	price = math.Max(math.Floor(price), 1) - 0.01
+
+	return product.AddProductReq{
+		SKU:         fmt.Sprintf("SEED%d-%s-%06d", seed, cat.code, i),
+		Name:        fmt.Sprintf("%s %s %s", adjective, material, noun),
+		Description: fmt.Sprintf("%s, made from %s. %s", noun, strings.ToLower(material), pick(r, taglines)),
+		Price:       price,
+		// Stock never runs out while seeding, since placing an order does not consume it
+		StockQty: 50 + r.IntN(951),
+	}
+}
+
+// Orders are placed at generated times in the year before orderWindowEnd, so the
+// same seed always produces the same order history
+var orderWindowEnd = time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+const orderWindow = 365 * 24 * time.Hour
+
+// OrderSpec is a generated order. Users, addresses and products are indexes into the
+// generated data set.
+type OrderSpec struct {
+	User     int
+	Address  int
+	Items    []ItemSpec
+	Status   order.OrderStatus
+	PlacedAt time.Time
+}
+
+type ItemSpec struct {
+	Product  int
+	Quantity int
+}
+
+// MaxItemsPerOrder bounds the distinct products in a generated order
+const MaxItemsPerOrder = 5
+
+// GenerateOrder returns order i for seed over a data set with the given number of
+// users and products. Both are skewed, so a few customers and best sellers account
+// for most orders.
+func GenerateOrder(seed uint64, i, users, products int) OrderSpec {
+	r := newRand(seed, streamOrder, i)
+	o := OrderSpec{
+		User:   skewed(r, users),
+		Status: []order.OrderStatus{order.OrderShipped, order.OrderPaid, order.OrderPending, order.OrderCancelled}[weighted(r, []int{55, 20, 15, 10})],
+	}
+	o.Address = r.IntN(len(GenerateUser(seed, o.User).Addresses))
+
+	n := min(1+weighted(r, []int{45, 25, 15, 10, 5}), products, MaxItemsPerOrder)
+	seen := make(map[int]bool, n)
+	for len(o.Items) < n {
+		p := skewed(r, products)
+		if seen[p] {
+			continue
+		}
+		seen[p] = true
+		o.Items = append(o.Items, ItemSpec{Product: p, Quantity: 1 + weighted(r, []int{70, 20, 7, 3})})
+	}
+	o.PlacedAt = orderWindowEnd.Add(-time.Duration(1+r.Int64N(int64(orderWindow/time.Second))) * time.Second)
+	return o
+}
+
+func pick[T any](r *rand.Rand, s []T) T {
+	return s[r.IntN(len(s))]
+}
+
+// weighted returns an index into weights, chosen in proportion to its weight
+func weighted(r *rand.Rand, weights []int) int {
+	total := 0
+	for _, w := range weights {
+		total += w
+	}
+	n := r.IntN(total)
+	for i, w := range weights {
+		if n < w {
+			return i
+		}
+		n -= w
+	}
+	return len(weights) - 1
+}
+
+// skewed returns an index below n that favours small indexes
+func skewed(r *rand.Rand, n int) int {
+	f := r.Float64()
+	return min(int(f*f*float64(n)), n-1)
+}
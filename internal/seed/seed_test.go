@@ -0,0 +1,295 @@
+package seed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/frostnzx/go-ecommerce-api/internal/core/services/address"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/services/order"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/services/product"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/services/user"
+	"github.com/frostnzx/go-ecommerce-api/internal/core/validation"
+	"github.com/frostnzx/go-ecommerce-api/internal/ports"
+	"github.com/google/uuid"
+)
+
+func TestGeneratorsAreDeterministic(t *testing.T) {
+	for i := range 50 {
+		if a, b := GenerateUser(7, i), GenerateUser(7, i); !reflect.DeepEqual(a, b) {
+			t.Fatalf("user %d differs between calls: %+v, %+v", i, a, b)
+		}
+		if a, b := GenerateProduct(7, i), GenerateProduct(7, i); a != b {
+			t.Fatalf("product %d differs between calls: %+v, %+v", i, a, b)
+		}
+		if a, b := GenerateOrder(7, i, 20, 30), GenerateOrder(7, i, 20, 30); !reflect.DeepEqual(a, b) {
+			t.Fatalf("order %d differs between calls: %+v, %+v", i, a, b)
+		}
+	}
+
+	same := 0
+	for i := range 50 {
+		if GenerateProduct(7, i).Name == GenerateProduct(8, i).Name {
+			same++
+		}
+	}
+	if same > 10 {
+		t.Errorf("seeds 7 and 8 share %d of 50 product names", same)
+	}
+}
+
+func TestGeneratedRecordsAreValid(t *testing.T) {
+	if !validation.StrongPassword(Password) {
+		t.Fatalf("Password %q does not satisfy the password policy", Password)
+	}
+	skus := map[string]bool{}
+	for i := range 500 {
+		u := GenerateUser(3, i)
+		if err := validation.Struct(user.RegisterUserReq{Name: u.Name, Email: u.Email, Password: Password}); err != nil {
+			t.Fatalf("user %d: %v", i, err)
+		}
+		if n := len(u.Addresses); n < 1 || n > 3 {
+			t.Fatalf("user %d has %d addresses", i, n)
+		}
+		for _, a := range u.Addresses {
+			a.UserID = uuid.New()
+			if err := validation.Struct(a); err != nil {
+				t.Fatalf("address of user %d: %v", i, err)
+			}
+		}
+
+		p := GenerateProduct(3, i)
+		if err := validation.Struct(p); err != nil {
+			t.Fatalf("product %d: %v", i, err)
+		}
+		if skus[p.SKU] {
+			t.Fatalf("product %d repeats SKU %s", i, p.SKU)
+		}
+		skus[p.SKU] = true
+	}
+}
+
+func TestGenerateOrderStaysInRange(t *testing.T) {
+	for i := range 1000 {
+		o := GenerateOrder(5, i, 3, 4)
+		if o.User < 0 || o.User >= 3 {
+			t.Fatalf("order %d: user %d out of range", i, o.User)
+		}
+		if o.Address >= len(GenerateUser(5, o.User).Addresses) {
+			t.Fatalf("order %d: address %d out of range", i, o.Address)
+		}
+		if len(o.Items) == 0 || len(o.Items) > 4 {
+			t.Fatalf("order %d has %d items", i, len(o.Items))
+		}
+		seen := map[int]bool{}
+		for _, it := range o.Items {
+			if it.Product < 0 || it.Product >= 4 || seen[it.Product] || it.Quantity < 1 {
+				t.Fatalf("order %d: bad item %+v", i, it)
+			}
+			seen[it.Product] = true
+		}
+		if !o.Status.IsValid() {
+			t.Fatalf("order %d: invalid status %q", i, o.Status)
+		}
+		if !o.PlacedAt.Before(orderWindowEnd) || o.PlacedAt.Before(orderWindowEnd.Add(-orderWindow)) {
+			t.Fatalf("order %d placed at %s, outside the order window", i, o.PlacedAt)
+		}
+	}
+}
+
+func TestRunLoadsThroughServices(t *testing.T) {
+	svc, f := newFakeServices()
+	opts := Options{Seed: 11, Users: 25, Products: 40, Orders: 120, Concurrency: 4}
+
+	res, err := Run(context.Background(), svc, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Users != 25 || res.Products != 40 || res.Orders != 120 {
+		t.Fatalf("result = %+v", res)
+	}
+	if res.Addresses != len(f.addresses) || res.Items == 0 {
+		t.Fatalf("result = %+v, %d addresses stored", res, len(f.addresses))
+	}
+
+	// Orders are placed concurrently, so compare what was placed with what was generated as a multiset
+	want := map[string]int{}
+	for i := range opts.Orders {
+		spec := GenerateOrder(opts.Seed, i, opts.Users, opts.Products)
+		key := fmt.Sprint(f.users[GenerateUser(opts.Seed, spec.User).Email], spec.Status, spec.PlacedAt)
+		for _, it := range spec.Items {
+			key += fmt.Sprint(" ", f.products[GenerateProduct(opts.Seed, it.Product).SKU].ID, "x", it.Quantity)
+		}
+		want[key]++
+	}
+	got := map[string]int{}
+	for _, o := range f.orders {
+		if f.addresses[o.req.AddressID] != o.req.UserID {
+			t.Fatalf("order for user %s uses an address of another user", o.req.UserID)
+		}
+		key := fmt.Sprint(o.req.UserID, o.status, o.req.PlacedAt)
+		for _, it := range o.req.Items {
+			key += fmt.Sprint(" ", it.ProductID, "x", it.Quantity)
+		}
+		got[key]++
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("placed orders do not match the generated ones")
+	}
+
+	if _, err := Run(context.Background(), svc, opts); !errors.Is(err, ErrAlreadySeeded) {
+		t.Fatalf("second run: err = %v, want ErrAlreadySeeded", err)
+	}
+}
+
+func TestRunRejectsOrdersWithoutUsers(t *testing.T) {
+	svc, _ := newFakeServices()
+	if _, err := Run(context.Background(), svc, Options{Products: 3, Orders: 1}); !errors.Is(err, ErrInvalidCounts) {
+		t.Fatalf("err = %v, want ErrInvalidCounts", err)
+	}
+}
+
+// fakes keeps what the seeder created; each fake embeds the service API and
+// implements only the methods the seeder calls
+type fakes struct {
+	mu        sync.Mutex
+	users     map[string]uuid.UUID    // by email
+	addresses map[uuid.UUID]uuid.UUID // address ID to user ID
+	products  map[string]product.ProductInfo
+	orders    map[uuid.UUID]*fakeOrder
+}
+
+type fakeOrder struct {
+	req    order.PlaceOrderReq
+	status string
+}
+
+func newFakeServices() (Services, *fakes) {
+	f := &fakes{
+		users:     map[string]uuid.UUID{},
+		addresses: map[uuid.UUID]uuid.UUID{},
+		products:  map[string]product.ProductInfo{},
+		orders:    map[uuid.UUID]*fakeOrder{},
+	}
+	return Services{User: fakeUsers{f: f}, Address: fakeAddresses{f: f}, Product: fakeProducts{f: f}, Order: fakeOrders{f: f}}, f
+}
+
+type fakeUsers struct {
+	user.API
+	f *fakes
+}
+
+func (u fakeUsers) GetUserByEmail(_ context.Context, req user.GetUserByEmailReq) (*user.GetUserProfileResp, error) {
+	u.f.mu.Lock()
+	defer u.f.mu.Unlock()
+	id, ok := u.f.users[req.Email]
+	if !ok {
+		return nil, ports.ErrUserNotFound
+	}
+	return &user.GetUserProfileResp{ID: id, Email: req.Email}, nil
+}
+
+func (u fakeUsers) RegisterUser(_ context.Context, req user.RegisterUserReq) (*user.RegisterUserResp, error) {
+	if err := validation.Struct(req); err != nil {
+		return nil, err
+	}
+	u.f.mu.Lock()
+	defer u.f.mu.Unlock()
+	if _, ok := u.f.users[req.Email]; ok {
+		return nil, errors.New("duplicate email")
+	}
+	id := uuid.New()
+	u.f.users[req.Email] = id
+	return &user.RegisterUserResp{ID: id}, nil
+}
+
+type fakeAddresses struct {
+	address.API
+	f *fakes
+}
+
+func (a fakeAddresses) AddAddress(_ context.Context, req address.AddAddressReq) (*address.AddAddressResp, error) {
+	if err := validation.Struct(req); err != nil {
+		return nil, err
+	}
+	a.f.mu.Lock()
+	defer a.f.mu.Unlock()
+	id := uuid.New()
+	a.f.addresses[id] = req.UserID
+	return &address.AddAddressResp{ID: id}, nil
+}
+
+func (a fakeAddresses) SetDefaultAddress(_ context.Context, req address.SetDefaultAddressReq) error {
+	a.f.mu.Lock()
+	defer a.f.mu.Unlock()
+	if a.f.addresses[req.AddressID] != req.UserID {
+		return address.ErrNotAddressOwner
+	}
+	return nil
+}
+
+type fakeProducts struct {
+	product.API
+	f *fakes
+}
+
+func (p fakeProducts) ImportProducts(_ context.Context, req product.ImportProductsReq) (*product.ImportProductsResp, error) {
+	if err := validation.Struct(req); err != nil {
+		return nil, err
+	}
+	p.f.mu.Lock()
+	defer p.f.mu.Unlock()
+	resp := &product.ImportProductsResp{}
+	for _, in := range req.Products {
+		existing, ok := p.f.products[in.SKU]
+		if !ok {
+			existing = product.ProductInfo{ID: uuid.New(), Active: true}
+			resp.Created++
+		} else {
+			resp.Updated++
+		}
+		existing.SKU, existing.Name, existing.Price, existing.StockQty = in.SKU, in.Name, in.Price, in.StockQty
+		p.f.products[in.SKU] = existing
+	}
+	return resp, nil
+}
+
+func (p fakeProducts) ListProducts(context.Context) (*product.ListProductsResp, error) {
+	p.f.mu.Lock()
+	defer p.f.mu.Unlock()
+	resp := &product.ListProductsResp{}
+	for _, info := range p.f.products {
+		resp.Products = append(resp.Products, info)
+	}
+	return resp, nil
+}
+
+type fakeOrders struct {
+	order.API
+	f *fakes
+}
+
+func (o fakeOrders) PlaceOrder(_ context.Context, req order.PlaceOrderReq) (*order.PlaceOrderResp, error) {
+	if err := validation.Struct(req); err != nil {
+		return nil, err
+	}
+	o.f.mu.Lock()
+	defer o.f.mu.Unlock()
+	id := uuid.New()
+	o.f.orders[id] = &fakeOrder{req: req, status: "pending"}
+	return &order.PlaceOrderResp{ID: id, Status: "pending"}, nil
+}
+
+func (o fakeOrders) UpdateOrderStatus(_ context.Context, req order.UpdateOrderStatusReq) error {
+	o.f.mu.Lock()
+	defer o.f.mu.Unlock()
+	placed, ok := o.f.orders[req.OrderID]
+	if !ok {
+		return order.ErrOrderNotFound
+	}
+	placed.status = req.Status
+	return nil
+}
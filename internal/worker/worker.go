@@ -0,0 +1,140 @@
+// Package worker runs periodic background jobs and stops them together on shutdown.
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// Job is one unit of background work. Errors are logged and the job runs again
+// at its next tick.
+type Job func(ctx context.Context) error
+
+// maxConsecutiveFailures is how many runs in a row a job may fail before the group reports itself unhealthy
+const maxConsecutiveFailures = 3
+
+type task struct {
+	name     string
+	interval time.Duration
+	job      Job
+}
+
+// jobState is the outcome of a job's recent runs
+type jobState struct {
+	failures int
+	lastErr  error
+}
+
+// Group runs jobs on fixed intervals until it is stopped
+type Group struct {
+	tasks  []task
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	mu      sync.Mutex
+	running bool
+	states  map[string]*jobState
+}
+
+func NewGroup() *Group {
+	return &Group{}
+}
+
+// Every registers job to run once per interval; it must be called before Start
+func (g *Group) Every(name string, interval time.Duration, job Job) {
+	g.tasks = append(g.tasks, task{name: name, interval: interval, job: job})
+}
+
+// Start launches every registered job. Jobs stop when ctx is cancelled or Stop is called.
+func (g *Group) Start(ctx context.Context) {
+	ctx, g.cancel = context.WithCancel(ctx)
+	g.mu.Lock()
+	g.running = true
+	g.states = make(map[string]*jobState, len(g.tasks))
+	for _, t := range g.tasks {
+		g.states[t.name] = &jobState{}
+	}
+	g.mu.Unlock()
+	for _, t := range g.tasks {
+		g.wg.Add(1)
+		go func() {
+			defer g.wg.Done()
+			g.run(ctx, t)
+		}()
+	}
+}
+
+func (g *Group) run(ctx context.Context, t task) {
+	ticker := time.NewTicker(t.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			err := t.job(ctx)
+			if ctx.Err() != nil {
+				return
+			}
+			if err != nil {
+				slog.Error("background job failed", "job", t.name, "error", err)
+			}
+			g.record(t.name, err)
+		}
+	}
+}
+
+func (g *Group) record(name string, err error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	st := g.states[name]
+	if err != nil {
+		st.failures++
+		st.lastErr = err
+		return
+	}
+	st.failures = 0
+	st.lastErr = nil
+}
+
+// Check reports an error when the group is not running or a job has failed
+// several runs in a row. It is meant for readiness probes.
+func (g *Group) Check(ctx context.Context) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if !g.running {
+		return errors.New("background jobs are not running")
+	}
+	var errs []error
+	for _, t := range g.tasks {
+		if st := g.states[t.name]; st.failures >= maxConsecutiveFailures {
+			errs = append(errs, fmt.Errorf("%s failed %d times in a row: %w", t.name, st.failures, st.lastErr))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Stop cancels the running jobs and waits for them to return, giving up when ctx is done
+func (g *Group) Stop(ctx context.Context) error {
+	g.mu.Lock()
+	g.running = false
+	g.mu.Unlock()
+	if g.cancel != nil {
+		g.cancel()
+	}
+	done := make(chan struct{})
+	go func() {
+		g.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,112 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestGroupRunsJobsUntilStopped(t *testing.T) {
+	var runs atomic.Int32
+	g := NewGroup()
+	g.Every("count", time.Millisecond, func(ctx context.Context) error {
+		runs.Add(1)
+		return nil
+	})
+	g.Start(context.Background())
+
+	deadline := time.Now().Add(time.Second)
+	for runs.Load() < 3 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if err := g.Stop(context.Background()); err != nil {
+		t.Fatalf("Stop: %v", err)
+	}
+	stopped := runs.Load()
+	if stopped < 3 {
+		t.Fatalf("job ran %d times, want at least 3", stopped)
+	}
+	time.Sleep(10 * time.Millisecond)
+	if runs.Load() != stopped {
+		t.Error("job kept running after Stop returned")
+	}
+}
+
+func TestStopGivesUpOnStuckJobs(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	started := make(chan struct{})
+	g := NewGroup()
+	g.Every("stuck", time.Millisecond, func(ctx context.Context) error {
+		select {
+		case started <- struct{}{}:
+		default:
+		}
+		<-release // ignores cancellation
+		return nil
+	})
+	g.Start(context.Background())
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := g.Stop(ctx); err != context.DeadlineExceeded {
+		t.Errorf("Stop = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestCheckReportsRepeatedFailures(t *testing.T) {
+	var fail atomic.Bool
+	fail.Store(true)
+	var runs atomic.Int32
+	g := NewGroup()
+	g.Every("flaky", time.Millisecond, func(ctx context.Context) error {
+		defer runs.Add(1)
+		if fail.Load() {
+			return errors.New("boom")
+		}
+		return nil
+	})
+	if err := g.Check(context.Background()); err == nil {
+		t.Fatal("Check passed before Start")
+	}
+	g.Start(context.Background())
+	defer g.Stop(context.Background())
+
+	waitFor(t, func() bool { return g.Check(context.Background()) != nil })
+	if err := g.Check(context.Background()); !strings.Contains(err.Error(), "boom") {
+		t.Errorf("Check = %v, want the job's last error", err)
+	}
+
+	fail.Store(false)
+	waitFor(t, func() bool { return g.Check(context.Background()) == nil })
+}
+
+func TestCheckFailsAfterStop(t *testing.T) {
+	g := NewGroup()
+	g.Every("noop", time.Hour, func(ctx context.Context) error { return nil })
+	g.Start(context.Background())
+	if err := g.Check(context.Background()); err != nil {
+		t.Fatalf("Check = %v, want nil while running", err)
+	}
+	if err := g.Stop(context.Background()); err != nil {
+		t.Fatalf("Stop: %v", err)
+	}
+	if err := g.Check(context.Background()); err == nil {
+		t.Error("Check passed after Stop")
+	}
+}
+
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met within a second")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}